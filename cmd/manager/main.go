@@ -34,7 +34,9 @@ import (
 	"github.com/banzaicloud/istio-operator/pkg/controller"
 	"github.com/banzaicloud/istio-operator/pkg/controller/istio"
 	"github.com/banzaicloud/istio-operator/pkg/controller/remoteistio"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
 	"github.com/banzaicloud/istio-operator/pkg/remoteclusters"
+	"github.com/banzaicloud/istio-operator/pkg/version"
 	"github.com/banzaicloud/istio-operator/pkg/webhook"
 )
 
@@ -50,9 +52,18 @@ func main() {
 	flag.DurationVar(&shutdownWaitDuration, "shutdown-wait-duration", time.Duration(30)*time.Second, "Wait duration before shutting down")
 	var waitBeforeExitDuration time.Duration
 	flag.DurationVar(&waitBeforeExitDuration, "wait-before-exit-duration", time.Duration(3)*time.Second, "Wait for workers to finish before exiting and removing finalizers")
+	flag.DurationVar(&remoteistio.ProbeInterval, "remote-cluster-probe-interval", remoteistio.ProbeInterval, "Interval the health of the remote clusters is checked at")
+	flag.DurationVar(&remoteistio.ProbeTimeout, "remote-cluster-probe-timeout", remoteistio.ProbeTimeout, "Timeout of the requests made to a remote cluster during a health check")
+	var remoteClusterOptions remoteclusters.Options
+	flag.DurationVar(&remoteClusterOptions.ResyncPeriod, "remote-cluster-resync-period", 10*time.Minute, "Period the objects reconciled in the remote clusters are re-checked at, 0 disables the periodic resync")
+	flag.DurationVar(&remoteClusterOptions.RequestTimeout, "remote-cluster-request-timeout", 30*time.Second, "Timeout of the requests made to the API server of a remote cluster")
+	flag.DurationVar(&remoteClusterOptions.ReconcileTimeout, "remote-cluster-reconcile-timeout", 5*time.Minute, "Timeout of the reconciliation of a remote cluster")
+	flag.IntVar(&remoteistio.MaxConcurrentReconciles, "remote-max-concurrent-reconciles", remoteistio.MaxConcurrentReconciles, "Number of RemoteIstio resources reconciled in parallel")
+	flag.BoolVar(&k8sutil.PlanMode, "plan-mode", false, "Publish the changes of the reconciliation of every Istio resource instead of applying them")
 	flag.Parse()
 	logf.SetLogger(logf.ZapLogger(developmentMode))
 	log := logf.Log.WithName("entrypoint")
+	log.Info("starting istio operator", "version", version.Version)
 
 	// Get a config to talk to the apiserver
 	log.Info("setting up client for manager")
@@ -92,7 +103,7 @@ func main() {
 
 	// Setup all Controllers
 	log.Info("Setting up controller")
-	if err := controller.AddToManager(mgr, remoteclusters.NewManager()); err != nil {
+	if err := controller.AddToManager(mgr, remoteclusters.NewManager(remoteClusterOptions)); err != nil {
 		log.Error(err, "unable to register controllers to the manager")
 		os.Exit(1)
 	}
@@ -0,0 +1,209 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// render writes every object the operator would create for an Istio resource to the standard output
+// without contacting a cluster
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/ghodss/yaml"
+	"github.com/goph/emperror"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/crds"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources"
+	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
+	"github.com/banzaicloud/istio-operator/pkg/resources/cni"
+	"github.com/banzaicloud/istio-operator/pkg/resources/common"
+	"github.com/banzaicloud/istio-operator/pkg/resources/galley"
+	"github.com/banzaicloud/istio-operator/pkg/resources/gateways"
+	"github.com/banzaicloud/istio-operator/pkg/resources/istiocoredns"
+	"github.com/banzaicloud/istio-operator/pkg/resources/mixer"
+	"github.com/banzaicloud/istio-operator/pkg/resources/nodeagent"
+	"github.com/banzaicloud/istio-operator/pkg/resources/pilot"
+	"github.com/banzaicloud/istio-operator/pkg/resources/revisions"
+	"github.com/banzaicloud/istio-operator/pkg/resources/sidecarinjector"
+)
+
+const defaultNamespace = "istio-system"
+
+func main() {
+	var filename string
+	flag.StringVar(&filename, "f", "", "The Istio resource to render, use - to read it from the standard input")
+	var withCRDs bool
+	flag.BoolVar(&withCRDs, "crds", true, "Render the Istio CRDs managed by the operator as well")
+	flag.Parse()
+
+	if filename == "" && flag.NArg() > 0 {
+		filename = flag.Arg(0)
+	}
+	if filename == "" {
+		fmt.Fprintln(os.Stderr, "usage: render [-crds=false] -f <istio.yaml>")
+		os.Exit(2)
+	}
+
+	err := run(filename, withCRDs, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(filename string, withCRDs bool, out io.Writer) error {
+	config, err := readConfig(filename)
+	if err != nil {
+		return err
+	}
+
+	s := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		scheme.AddToScheme,
+		apiextensionsv1beta1.AddToScheme,
+	} {
+		if err := addToScheme(s); err != nil {
+			return emperror.Wrap(err, "could not build scheme")
+		}
+	}
+
+	objects := make([]interface{}, 0)
+	if withCRDs {
+		for _, crd := range crds.InitCrds() {
+			o, err := typedObject(s, crd)
+			if err != nil {
+				return err
+			}
+			objects = append(objects, o)
+		}
+	}
+
+	for _, renderer := range renderers(config) {
+		for _, res := range renderer.Resources() {
+			if res.DesiredState == k8sutil.DesiredStateAbsent {
+				continue
+			}
+			o, err := typedObject(s, res.Resource())
+			if err != nil {
+				return err
+			}
+			objects = append(objects, o)
+		}
+		for _, dr := range renderer.DynamicResources() {
+			if dr.DesiredState == k8sutil.DesiredStateAbsent {
+				continue
+			}
+			o := dr.DynamicResource().Unstructured()
+			o.SetOwnerReferences(nil)
+			objects = append(objects, o.Object)
+		}
+	}
+
+	w := bufio.NewWriter(out)
+	for _, o := range objects {
+		y, err := yaml.Marshal(o)
+		if err != nil {
+			return emperror.Wrap(err, "could not marshal object")
+		}
+		fmt.Fprintf(w, "---\n%s", y)
+	}
+
+	return w.Flush()
+}
+
+// readConfig reads the Istio resource and applies the defaults the same way the operator does
+func readConfig(filename string) (*istiov1beta1.Istio, error) {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not read Istio resource", "file", filename)
+	}
+
+	var config istiov1beta1.Istio
+	err = yaml.Unmarshal(data, &config)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not parse Istio resource", "file", filename)
+	}
+	if config.Kind != "" && config.Kind != "Istio" {
+		return nil, emperror.With(fmt.Errorf("unexpected kind %q, expected Istio", config.Kind), "file", filename)
+	}
+	if config.Namespace == "" {
+		config.Namespace = defaultNamespace
+	}
+
+	if errs := config.Validate(); len(errs) > 0 {
+		return nil, emperror.With(errs.ToAggregate(), "file", filename)
+	}
+
+	istiov1beta1.SetDefaults(&config)
+
+	return &config, nil
+}
+
+// renderers returns the components in the order the Istio controller reconciles them, none of them gets
+// a client, so the objects are built without looking at the cluster
+func renderers(config *istiov1beta1.Istio) []resources.ComponentRenderer {
+	return []resources.ComponentRenderer{
+		common.New(nil, config, false),
+		citadel.New(citadel.Configuration{
+			// the mesh policy is cluster wide, it is managed by the default mesh
+			DeployMeshPolicy: config.IsDefaultMesh(),
+		}, nil, nil, config),
+		galley.New(nil, config),
+		pilot.New(nil, nil, config),
+		gateways.New(nil, nil, config),
+		mixer.New(nil, nil, config),
+		cni.New(nil, config),
+		sidecarinjector.New(nil, config),
+		nodeagent.New(nil, config),
+		istiocoredns.New(nil, config),
+		revisions.New(nil, nil, config),
+	}
+}
+
+// typedObject sets the kind of the object and drops its owner references, the Istio resource has no UID
+// until it is created in the cluster
+func typedObject(s *runtime.Scheme, o runtime.Object) (runtime.Object, error) {
+	gvk, err := apiutil.GVKForObject(o, s)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not determine the kind of the object")
+	}
+	o.GetObjectKind().SetGroupVersionKind(gvk)
+
+	m, err := meta.Accessor(o)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not access object metadata")
+	}
+	m.SetOwnerReferences(nil)
+
+	return o, nil
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// crd-versions merges the CRDs controller-gen generates for each API version into one CRD per resource.
+// The storage version keeps its file, the schemas and printer columns become per-version settings, the
+// other versions are not served until the operator sets up the conversion webhook for them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+)
+
+func main() {
+	var dir string
+	flag.StringVar(&dir, "dir", "config/base/crds", "Directory of the generated CRDs")
+	var storageVersion string
+	flag.StringVar(&storageVersion, "storage-version", "v1beta1", "The storage version of the API")
+	flag.Parse()
+
+	for _, resource := range flag.Args() {
+		err := merge(dir, resource, storageVersion)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+}
+
+// merge merges the <group>_<version>_<resource>.yaml files of the resource into the file of the storage version
+func merge(dir, resource, storageVersion string) error {
+	storageFile := filepath.Join(dir, fmt.Sprintf("istio_%s_%s.yaml", storageVersion, resource))
+	crd, err := read(storageFile)
+	if err != nil {
+		return err
+	}
+
+	if len(crd.Spec.Versions) > 1 {
+		// already merged
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("istio_*_%s.yaml", resource)))
+	if err != nil {
+		return err
+	}
+
+	crd.Spec.Versions = []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+		version(crd, storageVersion, true),
+	}
+	for _, file := range files {
+		if file == storageFile {
+			continue
+		}
+		other, err := read(file)
+		if err != nil {
+			return err
+		}
+		crd.Spec.Versions = append(crd.Spec.Versions, version(other, other.Spec.Version, false))
+		err = os.Remove(file)
+		if err != nil {
+			return err
+		}
+	}
+
+	crd.Spec.Version = storageVersion
+	crd.Spec.Validation = nil
+	crd.Spec.AdditionalPrinterColumns = nil
+	crd.Spec.Conversion = &apiextensionsv1beta1.CustomResourceConversion{
+		Strategy: apiextensionsv1beta1.NoneConverter,
+	}
+
+	data, err := yaml.Marshal(crd)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(storageFile, data, 0644)
+}
+
+func read(file string) (*apiextensionsv1beta1.CustomResourceDefinition, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var crd apiextensionsv1beta1.CustomResourceDefinition
+	err = yaml.Unmarshal(data, &crd)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %s: %s", file, err)
+	}
+
+	return &crd, nil
+}
+
+func version(crd *apiextensionsv1beta1.CustomResourceDefinition, name string, storage bool) apiextensionsv1beta1.CustomResourceDefinitionVersion {
+	return apiextensionsv1beta1.CustomResourceDefinitionVersion{
+		Name:                     name,
+		Served:                   storage,
+		Storage:                  storage,
+		Schema:                   crd.Spec.Validation,
+		AdditionalPrinterColumns: crd.Spec.AdditionalPrinterColumns,
+	}
+}
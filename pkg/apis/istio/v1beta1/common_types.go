@@ -16,6 +16,11 @@ limitations under the License.
 
 package v1beta1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 type ConfigState string
 
 const (
@@ -24,4 +29,107 @@ const (
 	Reconciling     ConfigState = "Reconciling"
 	Available       ConfigState = "Available"
 	Unmanaged       ConfigState = "Unmanaged"
+	// Degraded is set on RemoteIstio resources whose remote cluster cannot be reached
+	Degraded ConfigState = "Degraded"
+)
+
+// ConditionType is the type of a status condition
+type ConditionType string
+
+const (
+	// Component conditions report the outcome of the last reconciliation of the given component
+	MeshConfigReady      ConditionType = "MeshConfigReady"
+	CitadelReady         ConditionType = "CitadelReady"
+	GalleyReady          ConditionType = "GalleyReady"
+	PilotReady           ConditionType = "PilotReady"
+	GatewaysReady        ConditionType = "GatewaysReady"
+	MixerReady           ConditionType = "MixerReady"
+	CNIReady             ConditionType = "CNIReady"
+	SidecarInjectorReady ConditionType = "SidecarInjectorReady"
+	NodeAgentReady       ConditionType = "NodeAgentReady"
+	IstioCoreDNSReady    ConditionType = "IstioCoreDNSReady"
+	RevisionsReady       ConditionType = "RevisionsReady"
+
+	// WorkloadsReady reports whether the rollout of the managed deployments and daemonsets has converged
+	WorkloadsReady ConditionType = "WorkloadsReady"
+
+	// Remote cluster conditions report the outcome of the last health check of the remote cluster
+	ClusterReachable      ConditionType = "ClusterReachable"
+	RemoteConfigReady     ConditionType = "RemoteConfigReady"
+	RemoteComponentsReady ConditionType = "RemoteComponentsReady"
+	GatewayAddressKnown   ConditionType = "GatewayAddressKnown"
+)
+
+const (
+	ConditionReasonReconciled      = "Reconciled"
+	ConditionReasonReconcileFailed = "ReconcileFailed"
+	ConditionReasonPending         = "Pending"
+
+	ConditionReasonRolloutInProgress       = "RolloutInProgress"
+	ConditionReasonRolloutDeadlineExceeded = "RolloutDeadlineExceeded"
+)
+
+// CARotationStage is a stage of the rotation of the Citadel CA
+type CARotationStage string
+
+const (
+	// The combined trust bundle of the old and the new root is distributed, Citadel still signs with the old CA
+	CARotationDistributingTrustBundle CARotationStage = "DistributingTrustBundle"
+	// Citadel is restarted to sign with the new CA
+	CARotationSigningWithNewCA CARotationStage = "SigningWithNewCA"
+	// The workload certificates signed by the old CA are waited to be replaced
+	CARotationWaitingForWorkloadCerts CARotationStage = "WaitingForWorkloadCerts"
+	// The old root is removed from the trust bundle
+	CARotationDroppingOldRoot CARotationStage = "DroppingOldRoot"
+	// Only the new CA is used
+	CARotationCompleted CARotationStage = "Completed"
 )
+
+// Condition describes the observed state of one aspect of a resource at a certain point
+type Condition struct {
+	// Type of the condition, e.g. PilotReady
+	Type ConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown
+	Status corev1.ConditionStatus `json:"status"`
+	// The .metadata.generation the condition was set based upon
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Last time the condition transitioned from one status to another
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Machine readable, CamelCase reason for the condition's last transition
+	Reason string `json:"reason,omitempty"`
+	// Human readable message indicating details about the last transition
+	Message string `json:"message,omitempty"`
+}
+
+// SetCondition adds the condition to the list or replaces the one with the same type.
+// LastTransitionTime is only updated when the status of the condition changes,
+// unless it is explicitly set on the given condition.
+func SetCondition(conditions []Condition, condition Condition) []Condition {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	for i, c := range conditions {
+		if c.Type != condition.Type {
+			continue
+		}
+		if c.Status == condition.Status && !c.LastTransitionTime.IsZero() {
+			condition.LastTransitionTime = c.LastTransitionTime
+		}
+		conditions[i] = condition
+		return conditions
+	}
+
+	return append(conditions, condition)
+}
+
+// GetCondition returns the condition with the given type or nil if it is not present
+func GetCondition(conditions []Condition, conditionType ConditionType) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// v1beta1 is the storage version of the API and the version the operator works with,
+// the other versions are converted to and from it.
+
+// Hub marks Istio as the conversion hub
+func (*Istio) Hub() {}
+
+// Hub marks RemoteIstio as the conversion hub
+func (*RemoteIstio) Hub() {}
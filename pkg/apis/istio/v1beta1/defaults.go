@@ -29,15 +29,15 @@ import (
 const (
 	defaultImageHub                  = "docker.io/istio"
 	defaultImageVersion              = "1.2.5"
-	defaultPilotImage                = defaultImageHub + "/" + "pilot" + ":" + defaultImageVersion
-	defaultCitadelImage              = defaultImageHub + "/" + "citadel" + ":" + defaultImageVersion
-	defaultGalleyImage               = defaultImageHub + "/" + "galley" + ":" + defaultImageVersion
-	defaultMixerImage                = defaultImageHub + "/" + "mixer" + ":" + defaultImageVersion
-	defaultSidecarInjectorImage      = defaultImageHub + "/" + "sidecar_injector" + ":" + defaultImageVersion
-	defaultNodeAgentImage            = defaultImageHub + "/" + "node-agent-k8s" + ":" + defaultImageVersion
-	defaultSDSImage                  = defaultImageHub + "/" + "node-agent-k8s" + ":" + defaultImageVersion
-	defaultProxyImage                = defaultImageHub + "/" + "proxyv2" + ":" + defaultImageVersion
-	defaultProxyInitImage            = defaultImageHub + "/" + "proxy_init" + ":" + defaultImageVersion
+	pilotImageName                   = "pilot"
+	citadelImageName                 = "citadel"
+	galleyImageName                  = "galley"
+	mixerImageName                   = "mixer"
+	sidecarInjectorImageName         = "sidecar_injector"
+	nodeAgentImageName               = "node-agent-k8s"
+	sdsImageName                     = "node-agent-k8s"
+	proxyImageName                   = "proxyv2"
+	proxyInitImageName               = "proxy_init"
 	defaultInitCNIImage              = "gcr.io/istio-release/install-cni:master-latest-daily"
 	defaultCoreDNSImage              = "coredns/coredns:1.1.2"
 	defaultCoreDNSPluginImage        = defaultImageHub + "/coredns-plugin:0.2-istio-1.1"
@@ -56,6 +56,9 @@ const (
 	defaultImagePullPolicy           = "IfNotPresent"
 	defaultMeshExpansion             = false
 	ingress                          = "ingress"
+	defaultCertManagerIssuerKind     = "Issuer"
+	defaultVaultAuthPath             = "auth/kubernetes/login"
+	defaultVaultCACertKey            = "ca.crt"
 	egress                           = "egress"
 )
 
@@ -100,6 +103,11 @@ var defaultEgressGatewayPorts = []apiv1.ServicePort{
 	{Port: 15443, Protocol: apiv1.ProtocolTCP, TargetPort: intstr.FromInt(15443), Name: "tls"},
 }
 
+// defaultImage returns the default image of the given Istio component for the given version
+func defaultImage(name string, version IstioVersion) string {
+	return defaultImageHub + "/" + name + ":" + version.DefaultImageVersion()
+}
+
 func SetDefaults(config *Istio) {
 	if config.Spec.IncludeIPRanges == "" {
 		config.Spec.IncludeIPRanges = defaultIncludeIPRanges
@@ -119,7 +127,7 @@ func SetDefaults(config *Istio) {
 		config.Spec.Pilot.Enabled = util.BoolPointer(true)
 	}
 	if config.Spec.Pilot.Image == "" {
-		config.Spec.Pilot.Image = defaultPilotImage
+		config.Spec.Pilot.Image = defaultImage(pilotImageName, config.Spec.Version)
 	}
 	if config.Spec.Pilot.Sidecar == nil {
 		config.Spec.Pilot.Sidecar = util.BoolPointer(true)
@@ -141,14 +149,17 @@ func SetDefaults(config *Istio) {
 		config.Spec.Citadel.Enabled = util.BoolPointer(true)
 	}
 	if config.Spec.Citadel.Image == "" {
-		config.Spec.Citadel.Image = defaultCitadelImage
+		config.Spec.Citadel.Image = defaultImage(citadelImageName, config.Spec.Version)
+	}
+	if config.Spec.Citadel.CertManager != nil && config.Spec.Citadel.CertManager.IssuerRef.Kind == "" {
+		config.Spec.Citadel.CertManager.IssuerRef.Kind = defaultCertManagerIssuerKind
 	}
 	// Galley config
 	if config.Spec.Galley.Enabled == nil {
 		config.Spec.Galley.Enabled = util.BoolPointer(true)
 	}
 	if config.Spec.Galley.Image == "" {
-		config.Spec.Galley.Image = defaultGalleyImage
+		config.Spec.Galley.Image = defaultImage(galleyImageName, config.Spec.Version)
 	}
 	if config.Spec.Galley.ReplicaCount == 0 {
 		config.Spec.Galley.ReplicaCount = defaultReplicaCount
@@ -183,7 +194,12 @@ func SetDefaults(config *Istio) {
 			conf.SDS.Enabled = util.BoolPointer(false)
 		}
 		if conf.SDS.Image == "" {
-			conf.SDS.Image = defaultSDSImage
+			conf.SDS.Image = defaultImage(sdsImageName, config.Spec.Version)
+		}
+		for i := range conf.TLSHosts {
+			if conf.TLSHosts[i].IssuerRef.Kind == "" {
+				conf.TLSHosts[i].IssuerRef.Kind = defaultCertManagerIssuerKind
+			}
 		}
 		if conf.ServiceType == "" {
 			switch key {
@@ -207,7 +223,7 @@ func SetDefaults(config *Istio) {
 		config.Spec.Mixer.Enabled = util.BoolPointer(true)
 	}
 	if config.Spec.Mixer.Image == "" {
-		config.Spec.Mixer.Image = defaultMixerImage
+		config.Spec.Mixer.Image = defaultImage(mixerImageName, config.Spec.Version)
 	}
 	if config.Spec.Mixer.ReplicaCount == 0 {
 		config.Spec.Mixer.ReplicaCount = defaultReplicaCount
@@ -226,7 +242,7 @@ func SetDefaults(config *Istio) {
 		config.Spec.SidecarInjector.AutoInjectionPolicyEnabled = util.BoolPointer(true)
 	}
 	if config.Spec.SidecarInjector.Image == "" {
-		config.Spec.SidecarInjector.Image = defaultSidecarInjectorImage
+		config.Spec.SidecarInjector.Image = defaultImage(sidecarInjectorImageName, config.Spec.Version)
 	}
 	if config.Spec.SidecarInjector.ReplicaCount == 0 {
 		config.Spec.SidecarInjector.ReplicaCount = defaultReplicaCount
@@ -261,15 +277,26 @@ func SetDefaults(config *Istio) {
 		config.Spec.NodeAgent.Enabled = util.BoolPointer(false)
 	}
 	if config.Spec.NodeAgent.Image == "" {
-		config.Spec.NodeAgent.Image = defaultNodeAgentImage
+		config.Spec.NodeAgent.Image = defaultImage(nodeAgentImageName, config.Spec.Version)
+	}
+	if config.Spec.NodeAgent.CAProvider == "" {
+		config.Spec.NodeAgent.CAProvider = CAProviderCitadel
+	}
+	if vault := config.Spec.NodeAgent.Vault; vault != nil {
+		if vault.AuthPath == "" {
+			vault.AuthPath = defaultVaultAuthPath
+		}
+		if vault.CACertSecret.Key == "" {
+			vault.CACertSecret.Key = defaultVaultCACertKey
+		}
 	}
 	// Proxy config
 	if config.Spec.Proxy.Image == "" {
-		config.Spec.Proxy.Image = defaultProxyImage
+		config.Spec.Proxy.Image = defaultImage(proxyImageName, config.Spec.Version)
 	}
 	// Proxy Init config
 	if config.Spec.ProxyInit.Image == "" {
-		config.Spec.ProxyInit.Image = defaultProxyInitImage
+		config.Spec.ProxyInit.Image = defaultImage(proxyInitImageName, config.Spec.Version)
 	}
 	if config.Spec.Proxy.ComponentLogLevel == "" {
 		config.Spec.Proxy.ComponentLogLevel = "misc:error"
@@ -334,6 +361,33 @@ func SetDefaults(config *Istio) {
 	}
 }
 
+// ResetDefaultImages clears the images which are the defaults of the given version, so SetDefaults sets the
+// default images of the new version once the defaults are persisted and the version of the config is changed
+func ResetDefaultImages(config *Istio, version IstioVersion) {
+	for _, image := range []struct {
+		name  string
+		image *string
+	}{
+		{pilotImageName, &config.Spec.Pilot.Image},
+		{citadelImageName, &config.Spec.Citadel.Image},
+		{galleyImageName, &config.Spec.Galley.Image},
+		{mixerImageName, &config.Spec.Mixer.Image},
+		{sidecarInjectorImageName, &config.Spec.SidecarInjector.Image},
+		{nodeAgentImageName, &config.Spec.NodeAgent.Image},
+		{proxyImageName, &config.Spec.Proxy.Image},
+		{proxyInitImageName, &config.Spec.ProxyInit.Image},
+	} {
+		if *image.image == defaultImage(image.name, version) {
+			*image.image = ""
+		}
+	}
+	for _, conf := range config.Spec.Gateways.Configs {
+		if conf != nil && conf.SDS.Image == defaultImage(sdsImageName, version) {
+			conf.SDS.Image = ""
+		}
+	}
+}
+
 func SetRemoteIstioDefaults(remoteconfig *RemoteIstio) {
 	if remoteconfig.Spec.IncludeIPRanges == "" {
 		remoteconfig.Spec.IncludeIPRanges = defaultIncludeIPRanges
@@ -0,0 +1,73 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestResetDefaultImages(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := &Istio{
+		Spec: IstioSpec{
+			Version: "1.2.5",
+		},
+	}
+	SetDefaults(config)
+	g.Expect(config.Spec.Pilot.Image).To(gomega.Equal("docker.io/istio/pilot:1.2.5"))
+	g.Expect(config.Spec.Gateways.Configs["ingress"].SDS.Image).To(gomega.Equal("docker.io/istio/node-agent-k8s:1.2.5"))
+
+	// the images set by the user are kept
+	config.Spec.Citadel.Image = "registry.example.com/istio/citadel:1.2.5-patched"
+
+	ResetDefaultImages(config, "1.2.5")
+	g.Expect(config.Spec.Pilot.Image).To(gomega.BeEmpty())
+	g.Expect(config.Spec.Galley.Image).To(gomega.BeEmpty())
+	g.Expect(config.Spec.Mixer.Image).To(gomega.BeEmpty())
+	g.Expect(config.Spec.SidecarInjector.Image).To(gomega.BeEmpty())
+	g.Expect(config.Spec.NodeAgent.Image).To(gomega.BeEmpty())
+	g.Expect(config.Spec.Proxy.Image).To(gomega.BeEmpty())
+	g.Expect(config.Spec.ProxyInit.Image).To(gomega.BeEmpty())
+	g.Expect(config.Spec.Gateways.Configs["ingress"].SDS.Image).To(gomega.BeEmpty())
+	g.Expect(config.Spec.Citadel.Image).To(gomega.Equal("registry.example.com/istio/citadel:1.2.5-patched"))
+
+	config.Spec.Version = "1.3.5"
+	SetDefaults(config)
+	g.Expect(config.Spec.Pilot.Image).To(gomega.Equal("docker.io/istio/pilot:1.3.5"))
+	g.Expect(config.Spec.Proxy.Image).To(gomega.Equal("docker.io/istio/proxyv2:1.3.5"))
+	g.Expect(config.Spec.Gateways.Configs["ingress"].SDS.Image).To(gomega.Equal("docker.io/istio/node-agent-k8s:1.3.5"))
+	g.Expect(config.Spec.Citadel.Image).To(gomega.Equal("registry.example.com/istio/citadel:1.2.5-patched"))
+}
+
+func TestResetDefaultImagesOfOtherVersion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := &Istio{
+		Spec: IstioSpec{
+			Version: "1.3.5",
+		},
+	}
+	SetDefaults(config)
+
+	// only the defaults of the given version are reset
+	ResetDefaultImages(config, "1.2.5")
+	g.Expect(config.Spec.Pilot.Image).To(gomega.Equal("docker.io/istio/pilot:1.3.5"))
+	g.Expect(config.Spec.Proxy.Image).To(gomega.Equal("docker.io/istio/proxyv2:1.3.5"))
+}
@@ -20,14 +20,11 @@ import (
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
-	"regexp"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const supportedIstioMinorVersionRegex = "^1.2"
-
 // IstioVersion stores the intended Istio version
 type IstioVersion string
 
@@ -83,6 +80,33 @@ type CitadelConfiguration struct {
 	NodeSelector       map[string]string            `json:"nodeSelector,omitempty"`
 	Affinity           *corev1.Affinity             `json:"affinity,omitempty"`
 	Tolerations        []corev1.Toleration          `json:"tolerations,omitempty"`
+	// Request the CA certificate of Citadel from cert-manager instead of generating a self-signed one, it cannot be used together with caSecretName
+	CertManager *CitadelCertManagerConfiguration `json:"certManager,omitempty"`
+	// Rotate the CA of Citadel to a new one without breaking the mutual TLS connections of the workloads
+	CARotation *CitadelCARotation `json:"caRotation,omitempty"`
+}
+
+// CitadelCARotation defines the CA Citadel is rotated to
+type CitadelCARotation struct {
+	// Name of the secret holding the new CA in the layout of caSecretName
+	NewCASecretName string `json:"newCASecretName"`
+}
+
+// CertManagerIssuerReference references a cert-manager Issuer or ClusterIssuer
+type CertManagerIssuerReference struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Issuer,ClusterIssuer
+	Kind string `json:"kind,omitempty"`
+}
+
+// CitadelCertManagerConfiguration defines the intermediate CA certificate of Citadel issued by cert-manager
+type CitadelCertManagerConfiguration struct {
+	// The issuer signing the CA certificate, an Issuer has to be in the namespace of the control plane
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+	// Requested lifetime of the CA certificate, e.g. 8760h
+	Duration string `json:"duration,omitempty"`
+	// How long before its expiry the CA certificate is renewed, e.g. 720h
+	RenewBefore string `json:"renewBefore,omitempty"`
 }
 
 // GalleyConfiguration defines config options for Galley
@@ -127,6 +151,17 @@ type GatewayConfiguration struct {
 	NodeSelector         map[string]string            `json:"nodeSelector,omitempty"`
 	Affinity             *corev1.Affinity             `json:"affinity,omitempty"`
 	Tolerations          []corev1.Toleration          `json:"tolerations,omitempty"`
+	// TLSHosts are served over HTTPS by the gateway with certificates issued by cert-manager, the gateway
+	// reads them through SDS
+	TLSHosts []GatewayTLSHost `json:"tlsHosts,omitempty"`
+}
+
+// GatewayTLSHost is a host served over HTTPS by a gateway with a certificate issued by cert-manager
+type GatewayTLSHost struct {
+	// Host is the DNS name of the certificate and of the Gateway server, it may be a wildcard name
+	Host string `json:"host"`
+	// IssuerRef references the cert-manager Issuer or ClusterIssuer the certificate is issued by
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
 }
 
 type K8sIngressConfiguration struct {
@@ -200,6 +235,13 @@ type SidecarInjectorConfiguration struct {
 	Tolerations          []corev1.Toleration    `json:"tolerations,omitempty"`
 }
 
+type CAProviderType string
+
+const (
+	CAProviderCitadel CAProviderType = "Citadel"
+	CAProviderVault   CAProviderType = "Vault"
+)
+
 // NodeAgentConfiguration defines config options for NodeAgent
 type NodeAgentConfiguration struct {
 	Enabled      *bool                        `json:"enabled,omitempty"`
@@ -208,6 +250,39 @@ type NodeAgentConfiguration struct {
 	NodeSelector map[string]string            `json:"nodeSelector,omitempty"`
 	Affinity     *corev1.Affinity             `json:"affinity,omitempty"`
 	Tolerations  []corev1.Toleration          `json:"tolerations,omitempty"`
+	// CAProvider is the CA the node agent requests the workload certificates from, Citadel stops signing
+	// them when Vault is used
+	// +kubebuilder:validation:Enum=Citadel,Vault
+	CAProvider CAProviderType `json:"caProvider,omitempty"`
+	// Vault configuration options, required by the Vault CA provider
+	Vault *VaultCAConfiguration `json:"vault,omitempty"`
+}
+
+// VaultCAConfiguration defines the PKI secrets engine of Vault the workload certificates are signed by
+type VaultCAConfiguration struct {
+	// Address of the Vault server, e.g. https://vault.vault:8200
+	Address string `json:"address"`
+	// SignCSRPath is the sign path of the role of the PKI secrets engine, e.g. istio_ca/sign/istio-pki-role
+	SignCSRPath string `json:"signCSRPath"`
+	// AuthPath is the login path of the Kubernetes auth method, defaults to auth/kubernetes/login
+	AuthPath string `json:"authPath,omitempty"`
+	// Role of the Kubernetes auth method the node agent logs in with
+	Role string `json:"role"`
+	// CACertSecret selects the CA certificate the TLS certificate of the Vault server is verified with
+	CACertSecret VaultCACertSecretReference `json:"caCertSecret"`
+}
+
+// VaultCACertSecretReference selects the CA certificate of the Vault server
+type VaultCACertSecretReference struct {
+	// Name of the secret in the namespace of the Istio resource
+	Name string `json:"name"`
+	// Key of the PEM encoded CA certificate in the secret, defaults to ca.crt
+	Key string `json:"key,omitempty"`
+}
+
+// IsVaultCAProvider returns whether the workload certificates are signed by Vault instead of Citadel
+func (c NodeAgentConfiguration) IsVaultCAProvider() bool {
+	return c.CAProvider == CAProviderVault
 }
 
 // ProxyConfiguration defines config options for Proxy
@@ -355,7 +430,7 @@ type LocalityLBConfiguration struct {
 // IstioSpec defines the desired state of Istio
 type IstioSpec struct {
 	// Contains the intended Istio version
-	// +kubebuilder:validation:Pattern=^1.2
+	// +kubebuilder:validation:Pattern=^1\.(2|3|4)(\.|$)
 	Version IstioVersion `json:"version"`
 
 	// MTLS enables or disables global mTLS
@@ -447,8 +522,53 @@ type IstioSpec struct {
 	// Locality based load balancing distribution or failover settings.
 	LocalityLB *LocalityLBConfiguration `json:"localityLB,omitempty"`
 
+	// Control plane revisions running alongside the default control plane, e.g. for canary upgrades
+	Revisions []RevisionConfiguration `json:"revisions,omitempty"`
+
 	networkName  string
 	meshNetworks *MeshNetworks
+	revision     string
+	meshes       []string
+}
+
+// RevisionLabel is the label used to opt namespaces into a control plane revision and to mark the resources of a revision
+const RevisionLabel = "istio.io/rev"
+
+// MeshLabel is the label used to assign namespaces to the mesh whose control plane runs in the namespace given as its value
+const MeshLabel = "istio.banzaicloud.io/mesh"
+
+// CitadelNamespaceLabel is the label used to select the Citadel managing the service account secrets of a namespace
+// by the namespace Citadel runs in
+const CitadelNamespaceLabel = "ca.istio.io/env"
+
+// PlanAnnotation set to "true" on an Istio resource makes the operator publish the changes of the reconciliation
+// instead of applying them
+const PlanAnnotation = "istio.banzaicloud.io/plan"
+
+// DefaultsVersionAnnotation records the version of the operator which last persisted default values in the spec
+// of an Istio or RemoteIstio resource
+const DefaultsVersionAnnotation = "istio.banzaicloud.io/defaults-operator-version"
+
+// RevisionConfiguration defines a control plane revision which consists of pilot, galley and sidecar injector
+// instances running alongside the default ones. Namespaces labelled with istio.io/rev=<name> are injected by the
+// sidecar injector of the revision. To promote a revision, set its images on the default control plane and remove
+// the revision from the list, the resources of removed revisions are garbage collected.
+type RevisionConfiguration struct {
+	// Name of the revision, it is used as a suffix for the names of the resources of the revision
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+
+	// Contains the intended Istio version of the revision, defaults to the version of the default control plane.
+	// The images of the revision default to the ones of this version.
+	// +kubebuilder:validation:Pattern=^1\.(2|3|4)(\.|$)
+	Version IstioVersion `json:"version,omitempty"`
+
+	// Images of the revision, they default to the images of the default control plane
+	PilotImage           string `json:"pilotImage,omitempty"`
+	GalleyImage          string `json:"galleyImage,omitempty"`
+	SidecarInjectorImage string `json:"sidecarInjectorImage,omitempty"`
+	ProxyImage           string `json:"proxyImage,omitempty"`
+	ProxyInitImage       string `json:"proxyInitImage,omitempty"`
 }
 
 type MeshNetworkEndpoint struct {
@@ -500,6 +620,36 @@ func (s *IstioSpec) GetNetworkName() string {
 	return s.networkName
 }
 
+func (s *IstioSpec) SetRevision(revision string) *IstioSpec {
+	s.revision = revision
+	return s
+}
+
+// GetRevision returns the name of the control plane revision the spec belongs to, it is empty for the default control plane
+func (s *IstioSpec) GetRevision() string {
+	return s.revision
+}
+
+// SetMeshes sets the namespaces of the control planes running in the cluster, the first one is the default mesh
+func (s *IstioSpec) SetMeshes(namespaces []string) *IstioSpec {
+	s.meshes = namespaces
+	return s
+}
+
+// GetMeshes returns the namespaces of the control planes running in the cluster, the first one is the default mesh
+func (s *IstioSpec) GetMeshes() []string {
+	return s.meshes
+}
+
+func (s *IstioSpec) GetRevisionNames() []string {
+	names := make([]string, 0, len(s.Revisions))
+	for _, revision := range s.Revisions {
+		names = append(names, revision.Name)
+	}
+
+	return names
+}
+
 func (s IstioSpec) GetDefaultConfigVisibility() string {
 	if s.DefaultConfigVisibility == "" || s.DefaultConfigVisibility == "." {
 		return s.DefaultConfigVisibility
@@ -507,17 +657,27 @@ func (s IstioSpec) GetDefaultConfigVisibility() string {
 	return "*"
 }
 
-func (v IstioVersion) IsSupported() bool {
-	re, _ := regexp.Compile(supportedIstioMinorVersionRegex)
-
-	return re.Match([]byte(v))
-}
-
 // IstioStatus defines the observed state of Istio
 type IstioStatus struct {
 	Status         ConfigState
 	GatewayAddress []string
 	ErrorMessage   string
+	// Conditions hold the outcome of the last reconciliation of each component
+	Conditions []Condition `json:"conditions,omitempty"`
+	// CARotation shows the progress of the rotation of the Citadel CA
+	CARotation *CARotationStatus `json:"caRotation,omitempty"`
+}
+
+// CARotationStatus describes the progress of the rotation of the Citadel CA
+type CARotationStatus struct {
+	// Name of the secret holding the CA the rotation is done to
+	NewCASecretName string `json:"newCASecretName"`
+	// The active stage of the rotation
+	Stage CARotationStage `json:"stage"`
+	// When the active stage was started
+	StageStartTime metav1.Time `json:"stageStartTime,omitempty"`
+	// What the active stage is waiting for
+	Message string `json:"message,omitempty"`
 }
 
 // +genclient
@@ -538,6 +698,46 @@ type Istio struct {
 	Status IstioStatus `json:"status,omitempty"`
 }
 
+// IsDefaultMesh returns whether the control plane belongs to the default mesh, which also serves the namespaces
+// without a mesh label
+func (c *Istio) IsDefaultMesh() bool {
+	meshes := c.Spec.GetMeshes()
+	return len(meshes) == 0 || meshes[0] == c.Namespace
+}
+
+// ForRevision returns a copy of the config describing the control plane of the given revision
+func (c *Istio) ForRevision(revision RevisionConfiguration) *Istio {
+	config := c.DeepCopy()
+	config.Spec.SetRevision(revision.Name)
+	config.Spec.Revisions = nil
+
+	if revision.Version != "" {
+		config.Spec.Version = revision.Version
+		config.Spec.Pilot.Image = defaultImage(pilotImageName, revision.Version)
+		config.Spec.Galley.Image = defaultImage(galleyImageName, revision.Version)
+		config.Spec.SidecarInjector.Image = defaultImage(sidecarInjectorImageName, revision.Version)
+		config.Spec.Proxy.Image = defaultImage(proxyImageName, revision.Version)
+		config.Spec.ProxyInit.Image = defaultImage(proxyInitImageName, revision.Version)
+	}
+	if revision.PilotImage != "" {
+		config.Spec.Pilot.Image = revision.PilotImage
+	}
+	if revision.GalleyImage != "" {
+		config.Spec.Galley.Image = revision.GalleyImage
+	}
+	if revision.SidecarInjectorImage != "" {
+		config.Spec.SidecarInjector.Image = revision.SidecarInjectorImage
+	}
+	if revision.ProxyImage != "" {
+		config.Spec.Proxy.Image = revision.ProxyImage
+	}
+	if revision.ProxyInitImage != "" {
+		config.Spec.ProxyInit.Image = revision.ProxyInitImage
+	}
+
+	return config
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // IstioList contains a list of Istio
@@ -566,6 +766,40 @@ func (g GatewaysConfiguration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+// UnmarshalJSON reads the gateway configs from the keys of the json root which are not struct fields
+func (g *GatewaysConfiguration) UnmarshalJSON(data []byte) error {
+	type GatewaysConfiguration_ GatewaysConfiguration // prevent recursion
+	var gc GatewaysConfiguration_
+	err := json.Unmarshal(data, &gc)
+	if err != nil {
+		return err
+	}
+
+	var m map[string]json.RawMessage
+	err = json.Unmarshal(data, &m)
+	if err != nil {
+		return err
+	}
+
+	delete(m, "enabled")
+	delete(m, "k8singress")
+	for k, v := range m {
+		var conf GatewayConfiguration
+		err = json.Unmarshal(v, &conf)
+		if err != nil {
+			return err
+		}
+		if gc.Configs == nil {
+			gc.Configs = make(map[string]*GatewayConfiguration)
+		}
+		gc.Configs[k] = &conf
+	}
+
+	*g = GatewaysConfiguration(gc)
+
+	return nil
+}
+
 func init() {
 	SchemeBuilder.Register(&Istio{}, &IstioList{})
 }
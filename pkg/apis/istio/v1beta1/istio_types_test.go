@@ -17,10 +17,12 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/onsi/gomega"
 	"golang.org/x/net/context"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 )
@@ -60,3 +62,49 @@ func TestStorageIstio(t *testing.T) {
 	g.Expect(c.Delete(context.TODO(), fetched)).NotTo(gomega.HaveOccurred())
 	g.Expect(c.Get(context.TODO(), key, fetched)).To(gomega.HaveOccurred())
 }
+
+func TestGatewaysConfigurationJSON(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	enabled := true
+	disabled := false
+	gateways := GatewaysConfiguration{
+		Enabled: &enabled,
+		Configs: map[string]*GatewayConfiguration{
+			"ingress": {
+				Enabled:      &enabled,
+				ReplicaCount: 2,
+				ServiceType:  corev1.ServiceTypeLoadBalancer,
+				ServiceAnnotations: map[string]string{
+					"service.beta.kubernetes.io/aws-load-balancer-type": "nlb",
+				},
+			},
+			"egress": {
+				Enabled:     &disabled,
+				ServiceType: corev1.ServiceTypeClusterIP,
+			},
+		},
+		K8sIngress: K8sIngressConfiguration{
+			Enabled: &disabled,
+		},
+	}
+
+	data, err := json.Marshal(gateways)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	var raw map[string]json.RawMessage
+	g.Expect(json.Unmarshal(data, &raw)).NotTo(gomega.HaveOccurred())
+	g.Expect(raw).To(gomega.HaveKey("ingress"))
+	g.Expect(raw).To(gomega.HaveKey("egress"))
+	g.Expect(raw).To(gomega.HaveKey("k8singress"))
+
+	var decoded GatewaysConfiguration
+	g.Expect(json.Unmarshal(data, &decoded)).NotTo(gomega.HaveOccurred())
+	g.Expect(decoded).To(gomega.Equal(gateways))
+
+	// the gateway configs of a user written spec are kept as well
+	var spec IstioSpec
+	g.Expect(json.Unmarshal([]byte(`{"gateways": {"enabled": true, "ingress": {"replicaCount": 3}}}`), &spec)).NotTo(gomega.HaveOccurred())
+	g.Expect(spec.Gateways.Configs).To(gomega.HaveKey("ingress"))
+	g.Expect(spec.Gateways.Configs["ingress"].ReplicaCount).To(gomega.Equal(int32(3)))
+}
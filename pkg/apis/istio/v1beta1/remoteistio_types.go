@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 type SignCert struct {
@@ -29,7 +30,10 @@ type SignCert struct {
 }
 
 type IstioService struct {
-	Name          string               `json:"name"`
+	Name string `json:"name"`
+	// Namespace of the service, defaults to the namespace of the RemoteIstio resource. The service is
+	// replicated to the same namespace on remote side.
+	Namespace     string               `json:"namespace,omitempty"`
 	LabelSelector string               `json:"labelSelector,omitempty"`
 	IPs           []string             `json:"podIPs,omitempty"`
 	Ports         []corev1.ServicePort `json:"ports,omitempty"`
@@ -44,8 +48,44 @@ func (spec RemoteIstioSpec) GetSignCert() SignCert {
 	return spec.signCert
 }
 
+// IstioReference identifies the Istio resource of a control plane
+type IstioReference struct {
+	// Name of the Istio resource
+	Name string `json:"name"`
+
+	// Namespace of the Istio resource, it must be the namespace of the RemoteIstio resource
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ServiceExport selects the exported services by the labels of the services and of their namespaces
+type ServiceExport struct {
+	// NamespaceSelector selects the namespaces the services are exported from, defaults to the namespace
+	// of the RemoteIstio resource
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ServiceSelector selects the exported services
+	ServiceSelector *metav1.LabelSelector `json:"serviceSelector"`
+}
+
+// KubeconfigSecretReference selects the kubeconfig of the remote cluster
+type KubeconfigSecretReference struct {
+	// Name of the secret in the namespace of the RemoteIstio resource, defaults to the name of the RemoteIstio resource
+	Name string `json:"name,omitempty"`
+
+	// Key of the kubeconfig in the secret, it can be omitted if the secret has a single key or a kubeconfig key
+	Key string `json:"key,omitempty"`
+}
+
 // RemoteIstioSpec defines the desired state of RemoteIstio
 type RemoteIstioSpec struct {
+	// IstioRef references the Istio resource of the control plane the remote cluster is attached to,
+	// defaults to the only Istio resource in the namespace of the RemoteIstio resource
+	IstioRef *IstioReference `json:"istioRef,omitempty"`
+
+	// KubeconfigSecret selects the secret and the key holding the kubeconfig of the remote cluster, the
+	// clients of the remote cluster are rebuilt when it changes
+	KubeconfigSecret *KubeconfigSecretReference `json:"kubeconfigSecret,omitempty"`
+
 	// IncludeIPRanges the range where to capture egress traffic
 	IncludeIPRanges string `json:"includeIPRanges,omitempty"`
 
@@ -55,6 +95,9 @@ type RemoteIstioSpec struct {
 	// EnabledServices the Istio component services replicated to remote side
 	EnabledServices []IstioService `json:"enabledServices"`
 
+	// ServiceExport selects the services replicated to remote side by labels, in addition to the enabled services
+	ServiceExport *ServiceExport `json:"serviceExport,omitempty"`
+
 	// List of namespaces to label with sidecar auto injection enabled
 	AutoInjectionNamespaces []string `json:"autoInjectionNamespaces,omitempty"`
 
@@ -76,11 +119,105 @@ type RemoteIstioSpec struct {
 	signCert SignCert
 }
 
+// GetIstioKey returns the key of the Istio resource referenced by the RemoteIstio resource, the name is
+// empty when there is no reference
+func (c *RemoteIstio) GetIstioKey() types.NamespacedName {
+	if c.Spec.IstioRef == nil {
+		return types.NamespacedName{
+			Namespace: c.Namespace,
+		}
+	}
+
+	namespace := c.Spec.IstioRef.Namespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+
+	return types.NamespacedName{
+		Namespace: namespace,
+		Name:      c.Spec.IstioRef.Name,
+	}
+}
+
+// GetKubeconfigSecretName returns the name of the secret holding the kubeconfig of the remote cluster
+func (c *RemoteIstio) GetKubeconfigSecretName() string {
+	if c.Spec.KubeconfigSecret != nil && c.Spec.KubeconfigSecret.Name != "" {
+		return c.Spec.KubeconfigSecret.Name
+	}
+
+	return c.Name
+}
+
+// GetKubeconfigSecretKey returns the key of the kubeconfig in the secret, it is empty if not selected explicitly
+func (c *RemoteIstio) GetKubeconfigSecretKey() string {
+	if c.Spec.KubeconfigSecret != nil {
+		return c.Spec.KubeconfigSecret.Key
+	}
+
+	return ""
+}
+
+// GetServiceNamespace returns the namespace of the enabled service
+func (c *RemoteIstio) GetServiceNamespace(svc IstioService) string {
+	if svc.Namespace != "" {
+		return svc.Namespace
+	}
+
+	return c.Namespace
+}
+
+// GetOwnerLabels returns the labels the objects written into the remote cluster are marked with
+func (c *RemoteIstio) GetOwnerLabels() map[string]string {
+	return map[string]string{
+		RemoteIstioNameLabel:      c.Name,
+		RemoteIstioNamespaceLabel: c.Namespace,
+	}
+}
+
+// RemoteIstioNameLabel and RemoteIstioNamespaceLabel mark the objects the operator writes into a remote cluster
+// with the RemoteIstio resource they belong to
+const (
+	RemoteIstioNameLabel      = "istio.banzaicloud.io/remote-istio"
+	RemoteIstioNamespaceLabel = "istio.banzaicloud.io/remote-istio-namespace"
+)
+
 // RemoteIstioStatus defines the observed state of RemoteIstio
 type RemoteIstioStatus struct {
 	Status         ConfigState
 	GatewayAddress []string
 	ErrorMessage   string
+	// LastSuccessfulContact is the last time the API server of the remote cluster was reached
+	LastSuccessfulContact *metav1.Time `json:"lastSuccessfulContact,omitempty"`
+	// KubernetesVersion is the version of the remote cluster
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Conditions hold the outcome of the last health check of the remote cluster
+	Conditions []Condition `json:"conditions,omitempty"`
+	// CARotation shows the stage of the rotation of the Citadel CA the remote cluster is at
+	CARotation *RemoteCARotationStatus `json:"caRotation,omitempty"`
+	// IntermediateCA describes the CA the remote Citadel signs the workload certificates with
+	IntermediateCA *IntermediateCAStatus `json:"intermediateCA,omitempty"`
+}
+
+// RemoteCARotationStatus describes the rotation of the Citadel CA in a remote cluster
+type RemoteCARotationStatus struct {
+	// The stage of the rotation whose CA was last written into the remote cluster
+	Stage CARotationStage `json:"stage"`
+	// Number of workload certificates in the remote cluster not yet signed by the new CA
+	PendingWorkloadCerts int `json:"pendingWorkloadCerts,omitempty"`
+}
+
+// IntermediateCAStatus describes the intermediate CA issued for a remote cluster by the CA of the primary cluster
+type IntermediateCAStatus struct {
+	// Name of the secret in the primary cluster the intermediate CA is kept in
+	SecretName string `json:"secretName"`
+	// Serial number of the intermediate CA certificate
+	SerialNumber string `json:"serialNumber"`
+	// When the intermediate CA certificate was issued
+	NotBefore metav1.Time `json:"notBefore"`
+	// When the intermediate CA certificate expires
+	NotAfter metav1.Time `json:"notAfter"`
+	// When the intermediate CA certificate is going to be re-issued
+	RenewTime metav1.Time `json:"renewTime"`
 }
 
 // +genclient
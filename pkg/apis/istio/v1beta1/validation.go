@@ -0,0 +1,530 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const localityLBWeightsSum = 100
+
+// Validate checks the rules of the Istio spec which cannot be expressed in the CRD validation schema.
+// Unset fields are validated with their default values, so the errors reflect the configuration the
+// operator would reconcile.
+func (c *Istio) Validate() field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	spec := c.Spec
+
+	if !spec.Version.IsSupported() {
+		allErrs = append(allErrs, field.NotSupported(specPath.Child("version"), spec.Version, SupportedIstioVersions()))
+	}
+	allErrs = append(allErrs, validateRevisions(spec.Revisions, specPath.Child("revisions"))...)
+
+	allErrs = append(allErrs, validateIPRanges(spec.IncludeIPRanges, specPath.Child("includeIPRanges"))...)
+	allErrs = append(allErrs, validateIPRanges(spec.ExcludeIPRanges, specPath.Child("excludeIPRanges"))...)
+
+	allErrs = append(allErrs, validateReplicas(spec.Pilot.MinReplicas, spec.Pilot.MaxReplicas, specPath.Child("pilot"))...)
+	allErrs = append(allErrs, validateReplicas(spec.Mixer.MinReplicas, spec.Mixer.MaxReplicas, specPath.Child("mixer"))...)
+	gateways := make([]string, 0, len(spec.Gateways.Configs))
+	for name := range spec.Gateways.Configs {
+		gateways = append(gateways, name)
+	}
+	sort.Strings(gateways)
+	for _, name := range gateways {
+		if conf := spec.Gateways.Configs[name]; conf != nil {
+			allErrs = append(allErrs, validateReplicas(conf.MinReplicas, conf.MaxReplicas, specPath.Child("gateways", name))...)
+			allErrs = append(allErrs, validateGatewayTLSHosts(name, conf, specPath.Child("gateways", name))...)
+		}
+	}
+
+	allErrs = append(allErrs, validateLocalityLB(spec.LocalityLB, specPath.Child("localityLB"))...)
+	allErrs = append(allErrs, validateTracing(spec.Tracing, specPath.Child("tracing"))...)
+	allErrs = append(allErrs, validateCitadelCertManager(spec.Citadel, specPath.Child("citadel"))...)
+	allErrs = append(allErrs, validateCitadelCARotation(spec.Citadel, specPath.Child("citadel"))...)
+	allErrs = append(allErrs, validateVaultCAProvider(spec, specPath)...)
+
+	if enabledOrDefault(spec.UseMCP, true) && !enabledOrDefault(spec.Galley.Enabled, true) {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("useMCP"), true,
+			fmt.Sprintf("requires galley, enable %s or disable useMCP", specPath.Child("galley", "enabled"))))
+	}
+
+	for _, feature := range []struct {
+		name    string
+		enabled *bool
+	}{
+		{name: "meshExpansion", enabled: spec.MeshExpansion},
+		{name: "multiMesh", enabled: spec.MultiMesh},
+	} {
+		if !enabledOrDefault(feature.enabled, false) {
+			continue
+		}
+		if !spec.isIngressGatewayEnabled() {
+			allErrs = append(allErrs, field.Invalid(specPath.Child(feature.name), true,
+				fmt.Sprintf("requires the ingress gateway, enable %s and %s", specPath.Child("gateways", "enabled"), specPath.Child("gateways", ingress, "enabled"))))
+		}
+		if !enabledOrDefault(spec.Citadel.Enabled, true) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child(feature.name), true,
+				fmt.Sprintf("requires citadel, enable %s", specPath.Child("citadel", "enabled"))))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateUpdate checks the Istio spec and the changes of the spec which are only allowed in certain states
+// of the resource, the status of the old object is the state the operator has reconciled
+func (c *Istio) ValidateUpdate(old *Istio) field.ErrorList {
+	allErrs := c.Validate()
+	allErrs = append(allErrs, ValidateCARotationRemoval(c.Spec.Citadel, old.Status.CARotation, field.NewPath("spec", "citadel"))...)
+
+	return allErrs
+}
+
+// Validate checks the rules of the RemoteIstio spec which cannot be expressed in the CRD validation schema
+func (c *RemoteIstio) Validate() field.ErrorList {
+	allErrs := field.ErrorList{}
+	specPath := field.NewPath("spec")
+	spec := c.Spec
+
+	if ref := spec.IstioRef; ref != nil {
+		refPath := specPath.Child("istioRef")
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "name of the Istio resource is required"))
+		}
+		if ref.Namespace != "" && ref.Namespace != c.Namespace {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("namespace"), ref.Namespace, "must be the namespace of the RemoteIstio resource"))
+		}
+	}
+
+	if ref := spec.KubeconfigSecret; ref != nil && ref.Name != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("kubeconfigSecret", "name"), ref.Name, msg))
+		}
+	}
+
+	allErrs = append(allErrs, validateIPRanges(spec.IncludeIPRanges, specPath.Child("includeIPRanges"))...)
+	allErrs = append(allErrs, validateIPRanges(spec.ExcludeIPRanges, specPath.Child("excludeIPRanges"))...)
+
+	names := make(map[string]bool)
+	for i, svc := range spec.EnabledServices {
+		svcPath := specPath.Child("enabledServices").Index(i)
+
+		if svc.Name == "" {
+			allErrs = append(allErrs, field.Required(svcPath.Child("name"), "service name is required"))
+		} else {
+			for _, msg := range validation.IsDNS1035Label(svc.Name) {
+				allErrs = append(allErrs, field.Invalid(svcPath.Child("name"), svc.Name, msg))
+			}
+			key := c.GetServiceNamespace(svc) + "/" + svc.Name
+			if names[key] {
+				allErrs = append(allErrs, field.Duplicate(svcPath.Child("name"), svc.Name))
+			}
+			names[key] = true
+		}
+
+		if svc.Namespace != "" {
+			for _, msg := range validation.IsDNS1123Label(svc.Namespace) {
+				allErrs = append(allErrs, field.Invalid(svcPath.Child("namespace"), svc.Namespace, msg))
+			}
+		}
+
+		if svc.LabelSelector != "" {
+			if _, err := labels.Parse(svc.LabelSelector); err != nil {
+				allErrs = append(allErrs, field.Invalid(svcPath.Child("labelSelector"), svc.LabelSelector, err.Error()))
+			}
+		}
+
+		for j, ip := range svc.IPs {
+			if net.ParseIP(ip) == nil {
+				allErrs = append(allErrs, field.Invalid(svcPath.Child("podIPs").Index(j), ip, "must be a valid IP address"))
+			}
+		}
+
+		ports := make(map[string]bool)
+		for j, port := range svc.Ports {
+			portPath := svcPath.Child("ports").Index(j)
+			for _, msg := range validation.IsValidPortNum(int(port.Port)) {
+				allErrs = append(allErrs, field.Invalid(portPath.Child("port"), port.Port, msg))
+			}
+			if port.Name != "" {
+				if ports[port.Name] {
+					allErrs = append(allErrs, field.Duplicate(portPath.Child("name"), port.Name))
+				}
+				ports[port.Name] = true
+			}
+		}
+	}
+
+	if spec.ServiceExport != nil {
+		exportPath := specPath.Child("serviceExport")
+		if spec.ServiceExport.ServiceSelector == nil {
+			allErrs = append(allErrs, field.Required(exportPath.Child("serviceSelector"), "service selector is required"))
+		}
+		allErrs = append(allErrs, validateLabelSelector(spec.ServiceExport.ServiceSelector, exportPath.Child("serviceSelector"))...)
+		allErrs = append(allErrs, validateLabelSelector(spec.ServiceExport.NamespaceSelector, exportPath.Child("namespaceSelector"))...)
+	}
+
+	if spec.Citadel.CertManager != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("citadel", "certManager"), "the remote clusters use the CA of the Istio resource"))
+	}
+	if spec.Citadel.CARotation != nil {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("citadel", "caRotation"), "the remote clusters use the CA of the Istio resource"))
+	}
+
+	return allErrs
+}
+
+func (s IstioSpec) isIngressGatewayEnabled() bool {
+	if !enabledOrDefault(s.Gateways.Enabled, true) {
+		return false
+	}
+	conf := s.Gateways.Configs[ingress]
+	if conf == nil {
+		return true
+	}
+
+	return enabledOrDefault(conf.Enabled, true)
+}
+
+func validateRevisions(revisions []RevisionConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := make(map[string]bool)
+	for i, revision := range revisions {
+		if names[revision.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("name"), revision.Name))
+		}
+		names[revision.Name] = true
+
+		if revision.Version != "" && !revision.Version.IsSupported() {
+			allErrs = append(allErrs, field.NotSupported(path.Index(i).Child("version"), revision.Version, SupportedIstioVersions()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIPRanges checks a comma separated list of CIDRs, * stands for every address
+func validateIPRanges(ranges string, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if ranges == "" || ranges == "*" {
+		return allErrs
+	}
+
+	for _, cidr := range strings.Split(ranges, ",") {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, ranges, fmt.Sprintf("%q is not a valid CIDR", cidr)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateReplicas(minReplicas, maxReplicas int32, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if minReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minReplicas"), minReplicas, "must not be negative"))
+	}
+	if maxReplicas < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxReplicas"), maxReplicas, "must not be negative"))
+	}
+	if minReplicas == 0 {
+		minReplicas = defaultMinReplicas
+	}
+	if maxReplicas == 0 {
+		maxReplicas = defaultMaxReplicas
+	}
+	if minReplicas > maxReplicas {
+		allErrs = append(allErrs, field.Invalid(path.Child("minReplicas"), minReplicas,
+			fmt.Sprintf("must be less than or equal to maxReplicas (%d)", maxReplicas)))
+	}
+
+	return allErrs
+}
+
+func validateLocalityLB(config *LocalityLBConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config == nil {
+		return allErrs
+	}
+
+	if len(config.Distribute) > 0 && len(config.Failover) > 0 {
+		allErrs = append(allErrs, field.Forbidden(path.Child("failover"), "may not be set together with distribute"))
+	}
+
+	for i, distribute := range config.Distribute {
+		distributePath := path.Child("distribute").Index(i)
+		if distribute == nil {
+			allErrs = append(allErrs, field.Required(distributePath, "distribute setting must not be empty"))
+			continue
+		}
+		if distribute.From == "" {
+			allErrs = append(allErrs, field.Required(distributePath.Child("from"), "originating locality is required"))
+		}
+		var sum uint32
+		for _, weight := range distribute.To {
+			sum += weight
+		}
+		if sum != localityLBWeightsSum {
+			allErrs = append(allErrs, field.Invalid(distributePath.Child("to"), sum,
+				fmt.Sprintf("weights must sum up to %d", localityLBWeightsSum)))
+		}
+	}
+
+	for i, failover := range config.Failover {
+		failoverPath := path.Child("failover").Index(i)
+		if failover == nil {
+			allErrs = append(allErrs, field.Required(failoverPath, "failover setting must not be empty"))
+			continue
+		}
+		if failover.From == "" {
+			allErrs = append(allErrs, field.Required(failoverPath.Child("from"), "originating region is required"))
+		}
+		if failover.To == "" {
+			allErrs = append(allErrs, field.Required(failoverPath.Child("to"), "destination region is required"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateCitadelCertManager(config CitadelConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config.CertManager == nil {
+		return allErrs
+	}
+
+	certManagerPath := path.Child("certManager")
+	if config.CASecretName != "" {
+		allErrs = append(allErrs, field.Forbidden(certManagerPath, fmt.Sprintf("cannot be used together with %s", path.Child("caSecretName"))))
+	}
+	if config.CertManager.IssuerRef.Name == "" {
+		allErrs = append(allErrs, field.Required(certManagerPath.Child("issuerRef", "name"), "name of the issuer is required"))
+	}
+
+	var duration, renewBefore time.Duration
+	var err error
+	if config.CertManager.Duration != "" {
+		if duration, err = time.ParseDuration(config.CertManager.Duration); err != nil {
+			allErrs = append(allErrs, field.Invalid(certManagerPath.Child("duration"), config.CertManager.Duration, err.Error()))
+		}
+	}
+	if config.CertManager.RenewBefore != "" {
+		if renewBefore, err = time.ParseDuration(config.CertManager.RenewBefore); err != nil {
+			allErrs = append(allErrs, field.Invalid(certManagerPath.Child("renewBefore"), config.CertManager.RenewBefore, err.Error()))
+		}
+	}
+	if duration > 0 && renewBefore >= duration {
+		allErrs = append(allErrs, field.Invalid(certManagerPath.Child("renewBefore"), config.CertManager.RenewBefore, "must be shorter than the duration"))
+	}
+
+	return allErrs
+}
+
+func validateCitadelCARotation(config CitadelConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config.CARotation == nil {
+		return allErrs
+	}
+
+	namePath := path.Child("caRotation", "newCASecretName")
+	name := config.CARotation.NewCASecretName
+	switch {
+	case name == "":
+		allErrs = append(allErrs, field.Required(namePath, "name of the secret of the new CA is required"))
+	case name == config.CASecretName:
+		allErrs = append(allErrs, field.Invalid(namePath, name, fmt.Sprintf("must differ from %s", path.Child("caSecretName"))))
+	default:
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(namePath, name, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateCARotationRemoval checks that the CA rotation is only removed before Citadel signs with the new CA,
+// or once it is completed together with setting the new CA as the CA of Citadel. The workloads would either get
+// certificates of the old CA again, or lose the trust in the certificates they still hold otherwise.
+func ValidateCARotationRemoval(config CitadelConfiguration, status *CARotationStatus, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config.CARotation != nil || status == nil {
+		return allErrs
+	}
+
+	switch status.Stage {
+	case "", CARotationDistributingTrustBundle:
+		return allErrs
+	case CARotationCompleted:
+		if config.CertManager != nil || config.CASecretName != status.NewCASecretName {
+			allErrs = append(allErrs, field.Forbidden(path.Child("caRotation"),
+				fmt.Sprintf("cannot be removed in stage %s unless %s is set to %s", status.Stage, path.Child("caSecretName"), status.NewCASecretName)))
+		}
+	default:
+		allErrs = append(allErrs, field.Forbidden(path.Child("caRotation"),
+			fmt.Sprintf("cannot be removed in stage %s, wait for stage %s", status.Stage, CARotationCompleted)))
+	}
+
+	return allErrs
+}
+
+// validateGatewayTLSHosts checks that the certificates of the TLS hosts can be issued and read by the gateway
+func validateGatewayTLSHosts(name string, conf *GatewayConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(conf.TLSHosts) == 0 {
+		return allErrs
+	}
+
+	hostsPath := path.Child("tlsHosts")
+	if name == egress {
+		return append(allErrs, field.Forbidden(hostsPath, "the egress gateway does not serve TLS hosts"))
+	}
+	if !enabledOrDefault(conf.SDS.Enabled, false) {
+		allErrs = append(allErrs, field.Invalid(hostsPath, len(conf.TLSHosts), fmt.Sprintf("requires SDS, enable %s", path.Child("sds", "enabled"))))
+	}
+
+	hosts := make(map[string]bool)
+	for i, host := range conf.TLSHosts {
+		hostPath := hostsPath.Index(i)
+		var msgs []string
+		switch {
+		case host.Host == "":
+			allErrs = append(allErrs, field.Required(hostPath.Child("host"), "host is required"))
+		case hosts[host.Host]:
+			allErrs = append(allErrs, field.Duplicate(hostPath.Child("host"), host.Host))
+		case strings.HasPrefix(host.Host, "*"):
+			msgs = validation.IsWildcardDNS1123Subdomain(host.Host)
+		default:
+			msgs = validation.IsDNS1123Subdomain(host.Host)
+		}
+		for _, msg := range msgs {
+			allErrs = append(allErrs, field.Invalid(hostPath.Child("host"), host.Host, msg))
+		}
+		hosts[host.Host] = true
+		if host.IssuerRef.Name == "" {
+			allErrs = append(allErrs, field.Required(hostPath.Child("issuerRef", "name"), "name of the issuer is required"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateVaultCAProvider checks that the node agent is able to request the workload certificates from Vault
+// through SDS, Citadel does not sign them in that case
+func validateVaultCAProvider(spec IstioSpec, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	nodeAgentPath := path.Child("nodeAgent")
+	if !spec.NodeAgent.IsVaultCAProvider() {
+		if spec.NodeAgent.Vault != nil {
+			allErrs = append(allErrs, field.Forbidden(nodeAgentPath.Child("vault"), fmt.Sprintf("requires %s to be %s", nodeAgentPath.Child("caProvider"), CAProviderVault)))
+		}
+		return allErrs
+	}
+
+	providerPath := nodeAgentPath.Child("caProvider")
+	if !enabledOrDefault(spec.NodeAgent.Enabled, false) {
+		allErrs = append(allErrs, field.Invalid(providerPath, spec.NodeAgent.CAProvider, fmt.Sprintf("requires the node agent, enable %s", nodeAgentPath.Child("enabled"))))
+	}
+	if !enabledOrDefault(spec.SDS.Enabled, false) {
+		allErrs = append(allErrs, field.Invalid(providerPath, spec.NodeAgent.CAProvider, fmt.Sprintf("requires SDS, enable %s", path.Child("sds", "enabled"))))
+	}
+	citadelCAMessage := fmt.Sprintf("Citadel does not sign the workload certificates when %s is %s", providerPath, CAProviderVault)
+	if spec.Citadel.CertManager != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("citadel", "certManager"), citadelCAMessage))
+	}
+	if spec.Citadel.CARotation != nil {
+		allErrs = append(allErrs, field.Forbidden(path.Child("citadel", "caRotation"), citadelCAMessage))
+	}
+
+	vault := spec.NodeAgent.Vault
+	vaultPath := nodeAgentPath.Child("vault")
+	if vault == nil {
+		return append(allErrs, field.Required(vaultPath, "Vault configuration is required"))
+	}
+	if vault.Address == "" {
+		allErrs = append(allErrs, field.Required(vaultPath.Child("address"), "address of the Vault server is required"))
+	} else if u, err := url.Parse(vault.Address); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		allErrs = append(allErrs, field.Invalid(vaultPath.Child("address"), vault.Address, "must be an http or https URL"))
+	}
+	if vault.SignCSRPath == "" {
+		allErrs = append(allErrs, field.Required(vaultPath.Child("signCSRPath"), "sign path of the PKI role is required"))
+	}
+	if vault.Role == "" {
+		allErrs = append(allErrs, field.Required(vaultPath.Child("role"), "role of the Kubernetes auth method is required"))
+	}
+	if vault.CACertSecret.Name == "" {
+		allErrs = append(allErrs, field.Required(vaultPath.Child("caCertSecret", "name"), "name of the secret of the CA certificate is required"))
+	}
+
+	return allErrs
+}
+
+// validateTracing checks that the settings of the selected tracer are complete, the zipkin and datadog
+// addresses have default values
+func validateTracing(config TracingConfiguration, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !enabledOrDefault(config.Enabled, true) {
+		return allErrs
+	}
+
+	if config.Tracer == TracerTypeLightstep {
+		lightstepPath := path.Child(string(TracerTypeLightstep))
+		if config.Lightstep.Address == "" {
+			allErrs = append(allErrs, field.Required(lightstepPath.Child("address"), "the address of the satellite pool is required"))
+		}
+		if config.Lightstep.AccessToken == "" {
+			allErrs = append(allErrs, field.Required(lightstepPath.Child("accessToken"), "access token is required"))
+		}
+		if config.Lightstep.Secure && config.Lightstep.CacertPath == "" {
+			allErrs = append(allErrs, field.Required(lightstepPath.Child("cacertPath"), "required when secure is true"))
+		}
+	}
+
+	return allErrs
+}
+
+func enabledOrDefault(flag *bool, defaultValue bool) bool {
+	if flag == nil {
+		return defaultValue
+	}
+
+	return *flag
+}
+
+func validateLabelSelector(selector *metav1.LabelSelector, path *field.Path) field.ErrorList {
+	if selector == nil {
+		return nil
+	}
+
+	if _, err := metav1.LabelSelectorAsSelector(selector); err != nil {
+		return field.ErrorList{field.Invalid(path, selector, err.Error())}
+	}
+
+	return nil
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validationErrorFields(t *testing.T, spec string) []string {
+	config := &Istio{}
+	if err := json.Unmarshal([]byte(spec), &config.Spec); err != nil {
+		t.Fatal(err)
+	}
+
+	fields := make([]string, 0)
+	for _, err := range config.Validate() {
+		fields = append(fields, err.Field)
+	}
+
+	return fields
+}
+
+func TestValidateIstio(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tests := []struct {
+		name   string
+		spec   string
+		fields []string
+	}{
+		{
+			name:   "valid",
+			spec:   `{"version": "1.3.5", "localityLB": {"enabled": true, "distribute": [{"from": "us-west/*", "to": {"us-west/*": 80, "us-east/*": 20}}]}}`,
+			fields: []string{},
+		},
+		{
+			name:   "unsupported version",
+			spec:   `{"version": "1.1.0"}`,
+			fields: []string{"spec.version"},
+		},
+		{
+			name:   "distribute weights",
+			spec:   `{"version": "1.3.5", "localityLB": {"distribute": [{"from": "us-west/*", "to": {"us-west/*": 80}}]}}`,
+			fields: []string{"spec.localityLB.distribute[0].to"},
+		},
+		{
+			name:   "distribute and failover",
+			spec:   `{"version": "1.3.5", "localityLB": {"distribute": [{"from": "us-west/*", "to": {"us-west/*": 100}}], "failover": [{"from": "us-west", "to": "us-east"}]}}`,
+			fields: []string{"spec.localityLB.failover"},
+		},
+		{
+			name:   "replicas",
+			spec:   `{"version": "1.3.5", "pilot": {"minReplicas": 3, "maxReplicas": 2}, "mixer": {"minReplicas": 6}, "gateways": {"ingress": {"minReplicas": 2, "maxReplicas": 1}}}`,
+			fields: []string{"spec.pilot.minReplicas", "spec.mixer.minReplicas", "spec.gateways.ingress.minReplicas"},
+		},
+		{
+			name:   "tracer settings",
+			spec:   `{"version": "1.3.5", "tracing": {"tracer": "lightstep", "zipkin": {"address": "zipkin:9411"}, "lightstep": {"secure": true}}}`,
+			fields: []string{"spec.tracing.lightstep.address", "spec.tracing.lightstep.accessToken", "spec.tracing.lightstep.cacertPath"},
+		},
+		{
+			name:   "mcp without galley",
+			spec:   `{"version": "1.3.5", "galley": {"enabled": false}}`,
+			fields: []string{"spec.useMCP"},
+		},
+		{
+			name:   "mesh expansion without ingress gateway and citadel",
+			spec:   `{"version": "1.3.5", "meshExpansion": true, "citadel": {"enabled": false}, "gateways": {"ingress": {"enabled": false}}}`,
+			fields: []string{"spec.meshExpansion", "spec.meshExpansion"},
+		},
+		{
+			name:   "citadel cert-manager issuer",
+			spec:   `{"version": "1.3.5", "citadel": {"certManager": {"issuerRef": {"name": "ca-issuer", "kind": "ClusterIssuer"}, "duration": "8760h", "renewBefore": "720h"}}}`,
+			fields: []string{},
+		},
+		{
+			name:   "citadel cert-manager issuer with ca secret",
+			spec:   `{"version": "1.3.5", "citadel": {"caSecretName": "cacerts", "certManager": {"issuerRef": {}, "duration": "720h", "renewBefore": "8760h"}}}`,
+			fields: []string{"spec.citadel.certManager", "spec.citadel.certManager.issuerRef.name", "spec.citadel.certManager.renewBefore"},
+		},
+		{
+			name:   "citadel ca rotation",
+			spec:   `{"version": "1.3.5", "citadel": {"caSecretName": "cacerts", "caRotation": {"newCASecretName": "cacerts-2020"}}}`,
+			fields: []string{},
+		},
+		{
+			name:   "citadel ca rotation to the same secret",
+			spec:   `{"version": "1.3.5", "citadel": {"caSecretName": "cacerts", "caRotation": {"newCASecretName": "cacerts"}}}`,
+			fields: []string{"spec.citadel.caRotation.newCASecretName"},
+		},
+		{
+			name:   "vault ca provider",
+			spec:   `{"version": "1.3.5", "sds": {"enabled": true}, "nodeAgent": {"enabled": true, "caProvider": "Vault", "vault": {"address": "https://vault:8200", "signCSRPath": "istio_ca/sign/istio-pki-role", "role": "istio-cert", "caCertSecret": {"name": "vault-tls"}}}}`,
+			fields: []string{},
+		},
+		{
+			name:   "vault ca provider without sds and vault settings",
+			spec:   `{"version": "1.3.5", "citadel": {"certManager": {"issuerRef": {"name": "ca-issuer"}}}, "nodeAgent": {"caProvider": "Vault", "vault": {"address": "vault:8200"}}}`,
+			fields: []string{"spec.nodeAgent.caProvider", "spec.nodeAgent.caProvider", "spec.citadel.certManager", "spec.nodeAgent.vault.address", "spec.nodeAgent.vault.signCSRPath", "spec.nodeAgent.vault.role", "spec.nodeAgent.vault.caCertSecret.name"},
+		},
+		{
+			name:   "vault settings with citadel ca provider",
+			spec:   `{"version": "1.3.5", "nodeAgent": {"vault": {}}}`,
+			fields: []string{"spec.nodeAgent.vault"},
+		},
+		{
+			name:   "gateway tls hosts",
+			spec:   `{"version": "1.3.5", "gateways": {"ingress": {"sds": {"enabled": true}, "tlsHosts": [{"host": "*.example.com", "issuerRef": {"name": "letsencrypt", "kind": "ClusterIssuer"}}, {"host": "example.com", "issuerRef": {"name": "letsencrypt", "kind": "ClusterIssuer"}}]}}}`,
+			fields: []string{},
+		},
+		{
+			name:   "gateway tls hosts without sds",
+			spec:   `{"version": "1.3.5", "gateways": {"egress": {"tlsHosts": [{"host": "example.com"}]}, "ingress": {"tlsHosts": [{"host": "Example_com", "issuerRef": {"name": "ca"}}, {"host": "Example_com", "issuerRef": {"name": "ca"}}, {"issuerRef": {}}]}}}`,
+			fields: []string{"spec.gateways.egress.tlsHosts", "spec.gateways.ingress.tlsHosts", "spec.gateways.ingress.tlsHosts[0].host", "spec.gateways.ingress.tlsHosts[1].host", "spec.gateways.ingress.tlsHosts[2].host", "spec.gateways.ingress.tlsHosts[2].issuerRef.name"},
+		},
+	}
+
+	for _, test := range tests {
+		g.Expect(validationErrorFields(t, test.spec)).To(gomega.Equal(test.fields), test.name)
+	}
+}
+
+func TestValidateRemoteIstio(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := &RemoteIstio{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "istio-system",
+		},
+		Spec: RemoteIstioSpec{
+			IstioRef: &IstioReference{
+				Namespace: "other",
+			},
+			IncludeIPRanges: "10.0.0.0/8,invalid",
+			EnabledServices: []IstioService{
+				{Name: "istio-pilot", LabelSelector: "istio=pilot", IPs: []string{"10.1.1.1"}},
+				{Name: "istio-pilot", LabelSelector: "istio=(pilot"},
+				{Name: "istio-policy", IPs: []string{"10.1.1"}, Ports: []corev1.ServicePort{{Name: "grpc", Port: 0}}},
+				{Name: "istio-pilot", Namespace: "istio-system"},
+				{Name: "istio-pilot", Namespace: "apps"},
+				{Name: "productpage", Namespace: "Apps"},
+			},
+			ServiceExport: &ServiceExport{
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: metav1.LabelSelectorOpIn},
+					},
+				},
+			},
+			Citadel: CitadelConfiguration{
+				CertManager: &CitadelCertManagerConfiguration{},
+				CARotation:  &CitadelCARotation{},
+			},
+		},
+	}
+
+	fields := make([]string, 0)
+	for _, err := range config.Validate() {
+		fields = append(fields, err.Field)
+	}
+
+	g.Expect(fields).To(gomega.Equal([]string{
+		"spec.istioRef.name",
+		"spec.istioRef.namespace",
+		"spec.includeIPRanges",
+		"spec.enabledServices[1].name",
+		"spec.enabledServices[1].labelSelector",
+		"spec.enabledServices[2].podIPs[0]",
+		"spec.enabledServices[2].ports[0].port",
+		"spec.enabledServices[3].name",
+		"spec.enabledServices[5].namespace",
+		"spec.serviceExport.serviceSelector",
+		"spec.serviceExport.namespaceSelector",
+		"spec.citadel.certManager",
+		"spec.citadel.caRotation",
+	}))
+}
+
+func TestValidateCARotationRemoval(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	rotation := &CitadelCARotation{NewCASecretName: "cacerts-2020"}
+	status := func(stage CARotationStage) *CARotationStatus {
+		return &CARotationStatus{NewCASecretName: "cacerts-2020", Stage: stage}
+	}
+
+	tests := []struct {
+		name    string
+		config  CitadelConfiguration
+		status  *CARotationStatus
+		invalid bool
+	}{
+		{
+			name:   "no rotation",
+			config: CitadelConfiguration{CASecretName: "cacerts"},
+		},
+		{
+			name:   "rotation in progress",
+			config: CitadelConfiguration{CASecretName: "cacerts", CARotation: rotation},
+			status: status(CARotationWaitingForWorkloadCerts),
+		},
+		{
+			name:   "aborted while distributing the trust bundle",
+			config: CitadelConfiguration{CASecretName: "cacerts"},
+			status: status(CARotationDistributingTrustBundle),
+		},
+		{
+			name:    "removed after signing with the new CA",
+			config:  CitadelConfiguration{CASecretName: "cacerts"},
+			status:  status(CARotationSigningWithNewCA),
+			invalid: true,
+		},
+		{
+			name:    "removed when completed",
+			config:  CitadelConfiguration{},
+			status:  status(CARotationCompleted),
+			invalid: true,
+		},
+		{
+			name:    "removed together with enabling cert-manager",
+			config:  CitadelConfiguration{CertManager: &CitadelCertManagerConfiguration{}},
+			status:  status(CARotationDroppingOldRoot),
+			invalid: true,
+		},
+		{
+			name:   "finished with the new CA",
+			config: CitadelConfiguration{CASecretName: "cacerts-2020"},
+			status: status(CARotationCompleted),
+		},
+		{
+			name:    "switched to the new CA while signing with it",
+			config:  CitadelConfiguration{CASecretName: "cacerts-2020"},
+			status:  status(CARotationSigningWithNewCA),
+			invalid: true,
+		},
+		{
+			name:    "switched to the new CA while waiting for the workload certificates",
+			config:  CitadelConfiguration{CASecretName: "cacerts-2020"},
+			status:  status(CARotationWaitingForWorkloadCerts),
+			invalid: true,
+		},
+		{
+			name:    "switched to the new CA while dropping the old root",
+			config:  CitadelConfiguration{CASecretName: "cacerts-2020"},
+			status:  status(CARotationDroppingOldRoot),
+			invalid: true,
+		},
+	}
+
+	for _, test := range tests {
+		errs := ValidateCARotationRemoval(test.config, test.status, field.NewPath("spec", "citadel"))
+		if test.invalid {
+			g.Expect(errs).To(gomega.HaveLen(1), test.name)
+			g.Expect(errs[0].Field).To(gomega.Equal("spec.citadel.caRotation"), test.name)
+		} else {
+			g.Expect(errs).To(gomega.BeEmpty(), test.name)
+		}
+	}
+}
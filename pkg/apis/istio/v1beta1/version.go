@@ -0,0 +1,100 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// supportedIstioVersions is the compatibility matrix of the operator, it maps the supported
+// Istio minor versions to the image versions used by default for them
+var supportedIstioVersions = map[string]string{
+	"1.2": "1.2.5",
+	"1.3": "1.3.5",
+	"1.4": "1.4.2",
+}
+
+// SupportedIstioVersions returns the Istio minor versions supported by the operator
+func SupportedIstioVersions() []string {
+	versions := make([]string, 0, len(supportedIstioVersions))
+	for version := range supportedIstioVersions {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+
+	return versions
+}
+
+// IsSupported checks whether the minor version of v is in the compatibility matrix of the operator
+func (v IstioVersion) IsSupported() bool {
+	_, ok := supportedIstioVersions[v.MinorVersion()]
+
+	return ok
+}
+
+// MinorVersion returns the major.minor part of the version, e.g. 1.3 for 1.3.5
+func (v IstioVersion) MinorVersion() string {
+	parts := strings.SplitN(string(v), ".", 3)
+	if len(parts) < 2 {
+		return string(v)
+	}
+
+	return parts[0] + "." + parts[1]
+}
+
+// DefaultImageVersion returns the version of the Istio images used by default for the minor version of v
+func (v IstioVersion) DefaultImageVersion() string {
+	if imageVersion, ok := supportedIstioVersions[v.MinorVersion()]; ok {
+		return imageVersion
+	}
+
+	return defaultImageVersion
+}
+
+// IsAtLeast checks whether the minor version of v is the same or newer than the given minor version
+func (v IstioVersion) IsAtLeast(minorVersion string) bool {
+	major, minor, ok := parseMinorVersion(v.MinorVersion())
+	if !ok {
+		return false
+	}
+	otherMajor, otherMinor, ok := parseMinorVersion(minorVersion)
+	if !ok {
+		return false
+	}
+
+	return major > otherMajor || (major == otherMajor && minor >= otherMinor)
+}
+
+func parseMinorVersion(version string) (int, int, bool) {
+	parts := strings.SplitN(version, ".", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return major, minor, true
+}
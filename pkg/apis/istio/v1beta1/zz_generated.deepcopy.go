@@ -25,6 +25,72 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CARotationStatus) DeepCopyInto(out *CARotationStatus) {
+	*out = *in
+	in.StageStartTime.DeepCopyInto(&out.StageStartTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CARotationStatus.
+func (in *CARotationStatus) DeepCopy() *CARotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CARotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertManagerIssuerReference) DeepCopyInto(out *CertManagerIssuerReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerIssuerReference.
+func (in *CertManagerIssuerReference) DeepCopy() *CertManagerIssuerReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CertManagerIssuerReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CitadelCARotation) DeepCopyInto(out *CitadelCARotation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CitadelCARotation.
+func (in *CitadelCARotation) DeepCopy() *CitadelCARotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CitadelCARotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CitadelCertManagerConfiguration) DeepCopyInto(out *CitadelCertManagerConfiguration) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CitadelCertManagerConfiguration.
+func (in *CitadelCertManagerConfiguration) DeepCopy() *CitadelCertManagerConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(CitadelCertManagerConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CitadelConfiguration) DeepCopyInto(out *CitadelConfiguration) {
 	*out = *in
@@ -62,6 +128,16 @@ func (in *CitadelConfiguration) DeepCopyInto(out *CitadelConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CertManager != nil {
+		in, out := &in.CertManager, &out.CertManager
+		*out = new(CitadelCertManagerConfiguration)
+		**out = **in
+	}
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(CitadelCARotation)
+		**out = **in
+	}
 	return
 }
 
@@ -75,6 +151,23 @@ func (in *CitadelConfiguration) DeepCopy() *CitadelConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DatadogConfiugration) DeepCopyInto(out *DatadogConfiugration) {
 	*out = *in
@@ -188,6 +281,11 @@ func (in *GatewayConfiguration) DeepCopyInto(out *GatewayConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.TLSHosts != nil {
+		in, out := &in.TLSHosts, &out.TLSHosts
+		*out = make([]GatewayTLSHost, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -227,6 +325,23 @@ func (in *GatewaySDSConfiguration) DeepCopy() *GatewaySDSConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTLSHost) DeepCopyInto(out *GatewayTLSHost) {
+	*out = *in
+	out.IssuerRef = in.IssuerRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTLSHost.
+func (in *GatewayTLSHost) DeepCopy() *GatewayTLSHost {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTLSHost)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewaysConfiguration) DeepCopyInto(out *GatewaysConfiguration) {
 	*out = *in
@@ -295,6 +410,25 @@ func (in *InitCNIConfiguration) DeepCopy() *InitCNIConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntermediateCAStatus) DeepCopyInto(out *IntermediateCAStatus) {
+	*out = *in
+	in.NotBefore.DeepCopyInto(&out.NotBefore)
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	in.RenewTime.DeepCopyInto(&out.RenewTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntermediateCAStatus.
+func (in *IntermediateCAStatus) DeepCopy() *IntermediateCAStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntermediateCAStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Istio) DeepCopyInto(out *Istio) {
 	*out = *in
@@ -401,6 +535,22 @@ func (in *IstioList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioReference) DeepCopyInto(out *IstioReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioReference.
+func (in *IstioReference) DeepCopy() *IstioReference {
+	if in == nil {
+		return nil
+	}
+	out := new(IstioReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IstioService) DeepCopyInto(out *IstioService) {
 	*out = *in
@@ -474,11 +624,21 @@ func (in *IstioSpec) DeepCopyInto(out *IstioSpec) {
 		*out = new(LocalityLBConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = make([]RevisionConfiguration, len(*in))
+		copy(*out, *in)
+	}
 	if in.meshNetworks != nil {
 		in, out := &in.meshNetworks, &out.meshNetworks
 		*out = new(MeshNetworks)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.meshes != nil {
+		in, out := &in.meshes, &out.meshes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -500,6 +660,18 @@ func (in *IstioStatus) DeepCopyInto(out *IstioStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(CARotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -534,6 +706,22 @@ func (in *K8sIngressConfiguration) DeepCopy() *K8sIngressConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretReference.
+func (in *KubeconfigSecretReference) DeepCopy() *KubeconfigSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LightstepConfiguration) DeepCopyInto(out *LightstepConfiguration) {
 	*out = *in
@@ -795,6 +983,11 @@ func (in *NodeAgentConfiguration) DeepCopyInto(out *NodeAgentConfiguration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Vault != nil {
+		in, out := &in.Vault, &out.Vault
+		*out = new(VaultCAConfiguration)
+		**out = **in
+	}
 	return
 }
 
@@ -932,6 +1125,22 @@ func (in *ProxyInitConfiguration) DeepCopy() *ProxyInitConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCARotationStatus) DeepCopyInto(out *RemoteCARotationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCARotationStatus.
+func (in *RemoteCARotationStatus) DeepCopy() *RemoteCARotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCARotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteIstio) DeepCopyInto(out *RemoteIstio) {
 	*out = *in
@@ -996,6 +1205,16 @@ func (in *RemoteIstioList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteIstioSpec) DeepCopyInto(out *RemoteIstioSpec) {
 	*out = *in
+	if in.IstioRef != nil {
+		in, out := &in.IstioRef, &out.IstioRef
+		*out = new(IstioReference)
+		**out = **in
+	}
+	if in.KubeconfigSecret != nil {
+		in, out := &in.KubeconfigSecret, &out.KubeconfigSecret
+		*out = new(KubeconfigSecretReference)
+		**out = **in
+	}
 	if in.EnabledServices != nil {
 		in, out := &in.EnabledServices, &out.EnabledServices
 		*out = make([]IstioService, len(*in))
@@ -1003,6 +1222,11 @@ func (in *RemoteIstioSpec) DeepCopyInto(out *RemoteIstioSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ServiceExport != nil {
+		in, out := &in.ServiceExport, &out.ServiceExport
+		*out = new(ServiceExport)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AutoInjectionNamespaces != nil {
 		in, out := &in.AutoInjectionNamespaces, &out.AutoInjectionNamespaces
 		*out = make([]string, len(*in))
@@ -1039,6 +1263,27 @@ func (in *RemoteIstioStatus) DeepCopyInto(out *RemoteIstioStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastSuccessfulContact != nil {
+		in, out := &in.LastSuccessfulContact, &out.LastSuccessfulContact
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CARotation != nil {
+		in, out := &in.CARotation, &out.CARotation
+		*out = new(RemoteCARotationStatus)
+		**out = **in
+	}
+	if in.IntermediateCA != nil {
+		in, out := &in.IntermediateCA, &out.IntermediateCA
+		*out = new(IntermediateCAStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1052,6 +1297,22 @@ func (in *RemoteIstioStatus) DeepCopy() *RemoteIstioStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionConfiguration) DeepCopyInto(out *RevisionConfiguration) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionConfiguration.
+func (in *RevisionConfiguration) DeepCopy() *RevisionConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SDSConfiguration) DeepCopyInto(out *SDSConfiguration) {
 	*out = *in
@@ -1073,6 +1334,32 @@ func (in *SDSConfiguration) DeepCopy() *SDSConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceExport) DeepCopyInto(out *ServiceExport) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceSelector != nil {
+		in, out := &in.ServiceSelector, &out.ServiceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceExport.
+func (in *ServiceExport) DeepCopy() *ServiceExport {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SidecarInjectorConfiguration) DeepCopyInto(out *SidecarInjectorConfiguration) {
 	*out = *in
@@ -1225,6 +1512,39 @@ func (in *TracingConfiguration) DeepCopy() *TracingConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCACertSecretReference) DeepCopyInto(out *VaultCACertSecretReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCACertSecretReference.
+func (in *VaultCACertSecretReference) DeepCopy() *VaultCACertSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCACertSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCAConfiguration) DeepCopyInto(out *VaultCAConfiguration) {
+	*out = *in
+	out.CACertSecret = in.CACertSecret
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCAConfiguration.
+func (in *VaultCAConfiguration) DeepCopy() *VaultCAConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCAConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZipkinConfiguration) DeepCopyInto(out *ZipkinConfiguration) {
 	*out = *in
@@ -0,0 +1,195 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+// The fields which have the same shape in both versions are converted through their json representation,
+// only the differences are transformed explicitly:
+// - spec.mtls is a bool in v1beta1 and an object with a mode in v1beta2
+// - the gateway configs are inlined next to enabled and k8singress in v1beta1 and listed with their names in v1beta2
+// - the status fields are PascalCase in v1beta1 and camelCase in v1beta2
+
+var statusFields = map[string]string{
+	"Status":         "status",
+	"GatewayAddress": "gatewayAddress",
+	"ErrorMessage":   "errorMessage",
+}
+
+// ConvertTo converts the Istio resource to the hub version
+func (src *Istio) ConvertTo(hub runtime.Object) error {
+	dst, ok := hub.(*v1beta1.Istio)
+	if !ok {
+		return errors.Errorf("unexpected hub type %T", hub)
+	}
+
+	return convert(src, dst, v1beta1.SchemeGroupVersion, func(o map[string]interface{}) {
+		spec := childMap(o, "spec")
+		if mtls, ok := spec["mtls"].(map[string]interface{}); ok {
+			spec["mtls"] = mtls["mode"] == string(MTLSModeStrict)
+		}
+		if gateways, ok := spec["gateways"].(map[string]interface{}); ok {
+			if configs, ok := gateways["configs"].([]interface{}); ok {
+				for _, c := range configs {
+					if config, ok := c.(map[string]interface{}); ok {
+						name, _ := config["name"].(string)
+						delete(config, "name")
+						gateways[name] = config
+					}
+				}
+			}
+			delete(gateways, "configs")
+			renameField(gateways, "k8sIngress", "k8singress")
+		}
+
+		status := childMap(o, "status")
+		for hubField, field := range statusFields {
+			renameField(status, field, hubField)
+		}
+	})
+}
+
+// ConvertFrom converts the Istio resource from the hub version
+func (dst *Istio) ConvertFrom(hub runtime.Object) error {
+	src, ok := hub.(*v1beta1.Istio)
+	if !ok {
+		return errors.Errorf("unexpected hub type %T", hub)
+	}
+
+	return convert(src, dst, SchemeGroupVersion, func(o map[string]interface{}) {
+		spec := childMap(o, "spec")
+		mode := MTLSModePermissive
+		if src.Spec.MTLS {
+			mode = MTLSModeStrict
+		}
+		spec["mtls"] = map[string]interface{}{
+			"mode": string(mode),
+		}
+		if gateways, ok := spec["gateways"].(map[string]interface{}); ok {
+			names := make([]string, 0, len(src.Spec.Gateways.Configs))
+			for name := range src.Spec.Gateways.Configs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			configs := make([]interface{}, 0, len(names))
+			for _, name := range names {
+				if config, ok := gateways[name].(map[string]interface{}); ok {
+					config["name"] = name
+					configs = append(configs, config)
+				}
+				delete(gateways, name)
+			}
+			if len(configs) > 0 {
+				gateways["configs"] = configs
+			}
+			renameField(gateways, "k8singress", "k8sIngress")
+		}
+
+		status := childMap(o, "status")
+		for hubField, field := range statusFields {
+			renameField(status, hubField, field)
+		}
+	})
+}
+
+// ConvertTo converts the RemoteIstio resource to the hub version
+func (src *RemoteIstio) ConvertTo(hub runtime.Object) error {
+	dst, ok := hub.(*v1beta1.RemoteIstio)
+	if !ok {
+		return errors.Errorf("unexpected hub type %T", hub)
+	}
+
+	return convert(src, dst, v1beta1.SchemeGroupVersion, func(o map[string]interface{}) {
+		status := childMap(o, "status")
+		for hubField, field := range statusFields {
+			renameField(status, field, hubField)
+		}
+	})
+}
+
+// ConvertFrom converts the RemoteIstio resource from the hub version
+func (dst *RemoteIstio) ConvertFrom(hub runtime.Object) error {
+	src, ok := hub.(*v1beta1.RemoteIstio)
+	if !ok {
+		return errors.Errorf("unexpected hub type %T", hub)
+	}
+
+	return convert(src, dst, SchemeGroupVersion, func(o map[string]interface{}) {
+		status := childMap(o, "status")
+		for hubField, field := range statusFields {
+			renameField(status, hubField, field)
+		}
+	})
+}
+
+// convert transforms the json representation of src and decodes it into dst
+func convert(src, dst runtime.Object, gv schema.GroupVersion, transform func(map[string]interface{})) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return emperror.Wrap(err, "could not marshal object")
+	}
+
+	var o map[string]interface{}
+	err = json.Unmarshal(data, &o)
+	if err != nil {
+		return emperror.Wrap(err, "could not unmarshal object")
+	}
+
+	transform(o)
+	o["apiVersion"] = gv.String()
+
+	data, err = json.Marshal(o)
+	if err != nil {
+		return emperror.Wrap(err, "could not marshal converted object")
+	}
+
+	err = json.Unmarshal(data, dst)
+	if err != nil {
+		return emperror.WrapWith(err, "could not unmarshal converted object", "groupVersion", gv.String())
+	}
+
+	return nil
+}
+
+// childMap returns the object under the given key, it is created if it is missing
+func childMap(o map[string]interface{}, key string) map[string]interface{} {
+	child, ok := o[key].(map[string]interface{})
+	if !ok {
+		child = make(map[string]interface{})
+		o[key] = child
+	}
+
+	return child
+}
+
+func renameField(o map[string]interface{}, from, to string) {
+	if value, ok := o[from]; ok {
+		o[to] = value
+		delete(o, from)
+	}
+}
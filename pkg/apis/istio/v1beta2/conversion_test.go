@@ -0,0 +1,210 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func hubIstio(mtls bool) *v1beta1.Istio {
+	enabled := true
+	disabled := false
+
+	return &v1beta1.Istio{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       "Istio",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mesh",
+			Namespace: "istio-system",
+		},
+		Spec: v1beta1.IstioSpec{
+			Version: "1.3.5",
+			MTLS:    mtls,
+			Gateways: v1beta1.GatewaysConfiguration{
+				Enabled: &enabled,
+				Configs: map[string]*v1beta1.GatewayConfiguration{
+					"ingress": {
+						Enabled:      &enabled,
+						ReplicaCount: 2,
+						ServiceType:  corev1.ServiceTypeLoadBalancer,
+					},
+					"egress": {
+						Enabled: &disabled,
+					},
+				},
+				K8sIngress: v1beta1.K8sIngressConfiguration{
+					Enabled: &enabled,
+				},
+			},
+			Tracing: v1beta1.TracingConfiguration{
+				Enabled: &enabled,
+				Tracer:  v1beta1.TracerTypeDatadog,
+				Datadog: v1beta1.DatadogConfiugration{
+					Address: "$(HOST_IP):8126",
+				},
+			},
+		},
+		Status: v1beta1.IstioStatus{
+			Status:         v1beta1.Available,
+			GatewayAddress: []string{"10.1.1.1"},
+			ErrorMessage:   "",
+		},
+	}
+}
+
+func TestIstioConversionFromHub(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for _, mtls := range []bool{true, false} {
+		hub := hubIstio(mtls)
+
+		var istio Istio
+		g.Expect(istio.ConvertFrom(hub)).NotTo(gomega.HaveOccurred())
+
+		g.Expect(istio.APIVersion).To(gomega.Equal(SchemeGroupVersion.String()))
+		g.Expect(istio.Name).To(gomega.Equal("mesh"))
+		mode := MTLSModePermissive
+		if mtls {
+			mode = MTLSModeStrict
+		}
+		g.Expect(istio.Spec.MTLS.Mode).To(gomega.Equal(mode))
+		g.Expect(istio.Spec.Gateways.Configs).To(gomega.HaveLen(2))
+		g.Expect(istio.Spec.Gateways.Configs[0].Name).To(gomega.Equal("egress"))
+		g.Expect(istio.Spec.Gateways.Configs[1].Name).To(gomega.Equal("ingress"))
+		g.Expect(istio.Spec.Gateways.Configs[1].ReplicaCount).To(gomega.Equal(int32(2)))
+		g.Expect(*istio.Spec.Gateways.K8sIngress.Enabled).To(gomega.BeTrue())
+		g.Expect(istio.Spec.Tracing.Datadog).To(gomega.Equal(DatadogConfiguration{Address: "$(HOST_IP):8126"}))
+		g.Expect(istio.Status.Status).To(gomega.Equal(Available))
+		g.Expect(istio.Status.GatewayAddress).To(gomega.Equal([]string{"10.1.1.1"}))
+
+		var converted v1beta1.Istio
+		g.Expect(istio.ConvertTo(&converted)).NotTo(gomega.HaveOccurred())
+		g.Expect(&converted).To(gomega.Equal(hub))
+	}
+}
+
+func TestIstioConversionToHub(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	enabled := true
+	istio := &Istio{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       "Istio",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mesh",
+			Namespace: "istio-system",
+		},
+		Spec: IstioSpec{
+			Version: "1.4.0",
+			MTLS: MTLSConfiguration{
+				Mode: MTLSModeStrict,
+			},
+			Gateways: GatewaysConfiguration{
+				Enabled: &enabled,
+				Configs: []GatewayConfiguration{
+					{Name: "egress", ReplicaCount: 1},
+					{Name: "ingress", ReplicaCount: 3, ServiceType: corev1.ServiceTypeNodePort},
+				},
+				K8sIngress: K8sIngressConfiguration{
+					Enabled: &enabled,
+				},
+			},
+			Tracing: TracingConfiguration{
+				Tracer: TracerTypeDatadog,
+				Datadog: DatadogConfiguration{
+					Address: "datadog-agent:8126",
+				},
+			},
+		},
+		Status: IstioStatus{
+			Status:       ReconcileFailed,
+			ErrorMessage: "could not reconcile",
+		},
+	}
+
+	var hub v1beta1.Istio
+	g.Expect(istio.ConvertTo(&hub)).NotTo(gomega.HaveOccurred())
+
+	g.Expect(hub.APIVersion).To(gomega.Equal(v1beta1.SchemeGroupVersion.String()))
+	g.Expect(hub.Spec.MTLS).To(gomega.BeTrue())
+	g.Expect(hub.Spec.Gateways.Configs).To(gomega.HaveLen(2))
+	g.Expect(hub.Spec.Gateways.Configs).To(gomega.HaveKey("egress"))
+	g.Expect(hub.Spec.Gateways.Configs["ingress"].ReplicaCount).To(gomega.Equal(int32(3)))
+	g.Expect(hub.Spec.Gateways.Configs["ingress"].ServiceType).To(gomega.Equal(corev1.ServiceTypeNodePort))
+	g.Expect(*hub.Spec.Gateways.K8sIngress.Enabled).To(gomega.BeTrue())
+	g.Expect(hub.Spec.Tracing.Datadog).To(gomega.Equal(v1beta1.DatadogConfiugration{Address: "datadog-agent:8126"}))
+	g.Expect(hub.Status.Status).To(gomega.Equal(v1beta1.ReconcileFailed))
+	g.Expect(hub.Status.ErrorMessage).To(gomega.Equal("could not reconcile"))
+
+	var converted Istio
+	g.Expect(converted.ConvertFrom(&hub)).NotTo(gomega.HaveOccurred())
+	g.Expect(&converted).To(gomega.Equal(istio))
+}
+
+func TestRemoteIstioConversion(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	hub := &v1beta1.RemoteIstio{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       "RemoteIstio",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remote",
+			Namespace: "istio-system",
+		},
+		Spec: v1beta1.RemoteIstioSpec{
+			EnabledServices: []v1beta1.IstioService{
+				{Name: "istio-pilot", LabelSelector: "istio=pilot"},
+			},
+		},
+		Status: v1beta1.RemoteIstioStatus{
+			Status:         v1beta1.Available,
+			GatewayAddress: []string{"10.1.1.1"},
+		},
+	}
+
+	var remoteIstio RemoteIstio
+	g.Expect(remoteIstio.ConvertFrom(hub)).NotTo(gomega.HaveOccurred())
+	g.Expect(remoteIstio.APIVersion).To(gomega.Equal(SchemeGroupVersion.String()))
+	g.Expect(remoteIstio.Spec.EnabledServices).To(gomega.Equal([]IstioService{{Name: "istio-pilot", LabelSelector: "istio=pilot"}}))
+	g.Expect(remoteIstio.Status.Status).To(gomega.Equal(Available))
+	g.Expect(remoteIstio.Status.GatewayAddress).To(gomega.Equal([]string{"10.1.1.1"}))
+
+	var converted v1beta1.RemoteIstio
+	g.Expect(remoteIstio.ConvertTo(&converted)).NotTo(gomega.HaveOccurred())
+	g.Expect(&converted).To(gomega.Equal(hub))
+}
+
+func TestConversionRejectsOtherHubTypes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	g.Expect((&Istio{}).ConvertTo(&v1beta1.RemoteIstio{})).To(gomega.HaveOccurred())
+	g.Expect((&Istio{}).ConvertFrom(&v1beta1.RemoteIstio{})).To(gomega.HaveOccurred())
+	g.Expect((&RemoteIstio{}).ConvertTo(&v1beta1.Istio{})).To(gomega.HaveOccurred())
+	g.Expect((&RemoteIstio{}).ConvertFrom(&v1beta1.Istio{})).To(gomega.HaveOccurred())
+}
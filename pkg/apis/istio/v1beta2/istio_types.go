@@ -0,0 +1,615 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IstioVersion stores the intended Istio version
+type IstioVersion string
+
+// SDSConfiguration defines Secret Discovery Service config options
+type SDSConfiguration struct {
+	// If set to true, mTLS certificates for the sidecars will be
+	// distributed through the SecretDiscoveryService instead of using K8S secrets to mount the certificates.
+	Enabled *bool `json:"enabled,omitempty"`
+	// Unix Domain Socket through which envoy communicates with NodeAgent SDS to get
+	// key/cert for mTLS. Use secret-mount files instead of SDS if set to empty.
+	UdsPath string `json:"udsPath,omitempty"`
+	// If set to true, Istio will inject volumes mount for k8s service account JWT,
+	// so that K8s API server mounts k8s service account JWT to envoy container, which
+	// will be used to generate key/cert eventually.
+	// (prerequisite: https://kubernetes.io/docs/concepts/storage/volumes/#projected)
+	UseTrustworthyJwt bool `json:"useTrustworthyJwt,omitempty"`
+	// If set to true, envoy will fetch normal k8s service account JWT from '/var/run/secrets/kubernetes.io/serviceaccount/token'
+	// (https://kubernetes.io/docs/tasks/access-application-cluster/access-cluster/#accessing-the-api-from-a-pod)
+	// and pass to sds server, which will be used to request key/cert eventually
+	// this flag is ignored if UseTrustworthyJwt is set
+	UseNormalJwt bool `json:"useNormalJwt,omitempty"`
+
+	CustomTokenDirectory string `json:"customTokenDirectory,omitempty"`
+}
+
+// PilotConfiguration defines config options for Pilot
+type PilotConfiguration struct {
+	Enabled       *bool                        `json:"enabled,omitempty"`
+	Image         string                       `json:"image,omitempty"`
+	Sidecar       *bool                        `json:"sidecar,omitempty"`
+	ReplicaCount  int32                        `json:"replicaCount,omitempty"`
+	MinReplicas   int32                        `json:"minReplicas,omitempty"`
+	MaxReplicas   int32                        `json:"maxReplicas,omitempty"`
+	TraceSampling float32                      `json:"traceSampling,omitempty"`
+	Resources     *corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector  map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity      *corev1.Affinity             `json:"affinity,omitempty"`
+	Tolerations   []corev1.Toleration          `json:"tolerations,omitempty"`
+}
+
+// CitadelConfiguration defines config options for Citadel
+type CitadelConfiguration struct {
+	Enabled      *bool  `json:"enabled,omitempty"`
+	Image        string `json:"image,omitempty"`
+	CASecretName string `json:"caSecretName,omitempty"`
+	// Enable health checking on the Citadel CSR signing API. https://istio.io/docs/tasks/security/health-check/
+	HealthCheck *bool `json:"healthCheck,omitempty"`
+	// For the workloads running in Kubernetes, the lifetime of their Istio certificates is controlled by the workload-cert-ttl flag on Citadel. The default value is 90 days. This value should be no greater than max-workload-cert-ttl of Citadel.
+	WorkloadCertTTL string `json:"workloadCertTTL,omitempty"`
+	// Citadel uses a flag max-workload-cert-ttl to control the maximum lifetime for Istio certificates issued to workloads. The default value is 90 days. If workload-cert-ttl on Citadel or node agent is greater than max-workload-cert-ttl, Citadel will fail issuing the certificate.
+	MaxWorkloadCertTTL string                       `json:"maxWorkloadCertTTL,omitempty"`
+	Resources          *corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector       map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity           *corev1.Affinity             `json:"affinity,omitempty"`
+	Tolerations        []corev1.Toleration          `json:"tolerations,omitempty"`
+	// Request the CA certificate of Citadel from cert-manager instead of generating a self-signed one, it cannot be used together with caSecretName
+	CertManager *CitadelCertManagerConfiguration `json:"certManager,omitempty"`
+	// Rotate the CA of Citadel to a new one without breaking the mutual TLS connections of the workloads
+	CARotation *CitadelCARotation `json:"caRotation,omitempty"`
+}
+
+// CitadelCARotation defines the CA Citadel is rotated to
+type CitadelCARotation struct {
+	// Name of the secret holding the new CA in the layout of caSecretName
+	NewCASecretName string `json:"newCASecretName"`
+}
+
+// CertManagerIssuerReference references a cert-manager Issuer or ClusterIssuer
+type CertManagerIssuerReference struct {
+	Name string `json:"name"`
+	// +kubebuilder:validation:Enum=Issuer,ClusterIssuer
+	Kind string `json:"kind,omitempty"`
+}
+
+// CitadelCertManagerConfiguration defines the intermediate CA certificate of Citadel issued by cert-manager
+type CitadelCertManagerConfiguration struct {
+	// The issuer signing the CA certificate, an Issuer has to be in the namespace of the control plane
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+	// Requested lifetime of the CA certificate, e.g. 8760h
+	Duration string `json:"duration,omitempty"`
+	// How long before its expiry the CA certificate is renewed, e.g. 720h
+	RenewBefore string `json:"renewBefore,omitempty"`
+}
+
+// GalleyConfiguration defines config options for Galley
+type GalleyConfiguration struct {
+	Enabled      *bool                        `json:"enabled,omitempty"`
+	Image        string                       `json:"image,omitempty"`
+	ReplicaCount int32                        `json:"replicaCount,omitempty"`
+	Resources    *corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity     *corev1.Affinity             `json:"affinity,omitempty"`
+	Tolerations  []corev1.Toleration          `json:"tolerations,omitempty"`
+}
+
+// GatewaysConfiguration defines config options for Gateways
+type GatewaysConfiguration struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	// Configs of the gateways, the ingress and egress gateways are present by default
+	Configs    []GatewayConfiguration  `json:"configs,omitempty"`
+	K8sIngress K8sIngressConfiguration `json:"k8sIngress,omitempty"`
+}
+
+type GatewaySDSConfiguration struct {
+	Enabled   *bool                        `json:"enabled,omitempty"`
+	Image     string                       `json:"image,omitempty"`
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type GatewayConfiguration struct {
+	// Name of the gateway, e.g. ingress or egress
+	Name         string `json:"name"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+	ReplicaCount int32  `json:"replicaCount,omitempty"`
+	MinReplicas  int32  `json:"minReplicas,omitempty"`
+	MaxReplicas  int32  `json:"maxReplicas,omitempty"`
+	// +kubebuilder:validation:Enum=ClusterIP,NodePort,LoadBalancer
+	ServiceType          corev1.ServiceType           `json:"serviceType,omitempty"`
+	LoadBalancerIP       string                       `json:"loadBalancerIP,omitempty"`
+	ServiceAnnotations   map[string]string            `json:"serviceAnnotations,omitempty"`
+	ServiceLabels        map[string]string            `json:"serviceLabels,omitempty"`
+	SDS                  GatewaySDSConfiguration      `json:"sds,omitempty"`
+	Resources            *corev1.ResourceRequirements `json:"resources,omitempty"`
+	Ports                []corev1.ServicePort         `json:"ports,omitempty"`
+	ApplicationPorts     string                       `json:"applicationPorts,omitempty"`
+	RequestedNetworkView string                       `json:"requestedNetworkView,omitempty"`
+	NodeSelector         map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity             *corev1.Affinity             `json:"affinity,omitempty"`
+	Tolerations          []corev1.Toleration          `json:"tolerations,omitempty"`
+	// TLSHosts are served over HTTPS by the gateway with certificates issued by cert-manager, the gateway
+	// reads them through SDS
+	TLSHosts []GatewayTLSHost `json:"tlsHosts,omitempty"`
+}
+
+// GatewayTLSHost is a host served over HTTPS by a gateway with a certificate issued by cert-manager
+type GatewayTLSHost struct {
+	// Host is the DNS name of the certificate and of the Gateway server, it may be a wildcard name
+	Host string `json:"host"`
+	// IssuerRef references the cert-manager Issuer or ClusterIssuer the certificate is issued by
+	IssuerRef CertManagerIssuerReference `json:"issuerRef"`
+}
+
+type K8sIngressConfiguration struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// MixerConfiguration defines config options for Mixer
+type MixerConfiguration struct {
+	Enabled      *bool                        `json:"enabled,omitempty"`
+	Image        string                       `json:"image,omitempty"`
+	ReplicaCount int32                        `json:"replicaCount,omitempty"`
+	MinReplicas  int32                        `json:"minReplicas,omitempty"`
+	MaxReplicas  int32                        `json:"maxReplicas,omitempty"`
+	Resources    *corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity     *corev1.Affinity             `json:"affinity,omitempty"`
+	Tolerations  []corev1.Toleration          `json:"tolerations,omitempty"`
+	// Turn it on if you use mixer that supports multi cluster telemetry
+	MultiClusterSupport *bool `json:"multiClusterSupport,omitempty"`
+}
+
+// InitCNIConfiguration defines config for the sidecar proxy init CNI plugin
+type InitCNIConfiguration struct {
+	// If true, the privileged initContainer istio-init is not needed to perform the traffic redirect
+	// settings for the istio-proxy
+	Enabled *bool  `json:"enabled,omitempty"`
+	Image   string `json:"image,omitempty"`
+	// Must be the same as the environment’s --cni-bin-dir setting (kubelet parameter)
+	BinDir string `json:"binDir,omitempty"`
+	// Must be the same as the environment’s --cni-conf-dir setting (kubelet parameter)
+	ConfDir string `json:"confDir,omitempty"`
+	// List of namespaces to exclude from Istio pod check
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+	// Logging level for CNI binary
+	LogLevel string           `json:"logLevel,omitempty"`
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// SidecarInjectorInitConfiguration defines options for init containers in the sidecar
+type SidecarInjectorInitConfiguration struct {
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// SidecarInjectorConfiguration defines config options for SidecarInjector
+type SidecarInjectorConfiguration struct {
+	Enabled              *bool                            `json:"enabled,omitempty"`
+	Image                string                           `json:"image,omitempty"`
+	ReplicaCount         int32                            `json:"replicaCount,omitempty"`
+	Resources            *corev1.ResourceRequirements     `json:"resources,omitempty"`
+	Init                 SidecarInjectorInitConfiguration `json:"init,omitempty"`
+	InitCNIConfiguration InitCNIConfiguration             `json:"initCNIConfiguration,omitempty"`
+	// If true, sidecar injector will rewrite PodSpec for liveness
+	// health check to redirect request to sidecar. This makes liveness check work
+	// even when mTLS is enabled.
+	RewriteAppHTTPProbe bool `json:"rewriteAppHTTPProbe,omitempty"`
+	// This controls the 'policy' in the sidecar injector
+	AutoInjectionPolicyEnabled *bool `json:"autoInjectionPolicyEnabled,omitempty"`
+	// This controls whether the webhook looks for namespaces for injection enabled or disabled
+	EnableNamespacesByDefault *bool `json:"enableNamespacesByDefault,omitempty"`
+	// NeverInjectSelector: Refuses the injection on pods whose labels match this selector.
+	// It's an array of label selectors, that will be OR'ed, meaning we will iterate
+	// over it and stop at the first match
+	// Takes precedence over AlwaysInjectSelector.
+	NeverInjectSelector []metav1.LabelSelector `json:"neverInjectSelector,omitempty"`
+	// AlwaysInjectSelector: Forces the injection on pods whose labels match this selector.
+	// It's an array of label selectors, that will be OR'ed, meaning we will iterate
+	// over it and stop at the first match
+	AlwaysInjectSelector []metav1.LabelSelector `json:"alwaysInjectSelector,omitempty"`
+	NodeSelector         map[string]string      `json:"nodeSelector,omitempty"`
+	Affinity             *corev1.Affinity       `json:"affinity,omitempty"`
+	Tolerations          []corev1.Toleration    `json:"tolerations,omitempty"`
+}
+
+type CAProviderType string
+
+const (
+	CAProviderCitadel CAProviderType = "Citadel"
+	CAProviderVault   CAProviderType = "Vault"
+)
+
+// NodeAgentConfiguration defines config options for NodeAgent
+type NodeAgentConfiguration struct {
+	Enabled      *bool                        `json:"enabled,omitempty"`
+	Image        string                       `json:"image,omitempty"`
+	Resources    *corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity     *corev1.Affinity             `json:"affinity,omitempty"`
+	Tolerations  []corev1.Toleration          `json:"tolerations,omitempty"`
+	// CAProvider is the CA the node agent requests the workload certificates from, Citadel stops signing
+	// them when Vault is used
+	// +kubebuilder:validation:Enum=Citadel,Vault
+	CAProvider CAProviderType `json:"caProvider,omitempty"`
+	// Vault configuration options, required by the Vault CA provider
+	Vault *VaultCAConfiguration `json:"vault,omitempty"`
+}
+
+// VaultCAConfiguration defines the PKI secrets engine of Vault the workload certificates are signed by
+type VaultCAConfiguration struct {
+	// Address of the Vault server, e.g. https://vault.vault:8200
+	Address string `json:"address"`
+	// SignCSRPath is the sign path of the role of the PKI secrets engine, e.g. istio_ca/sign/istio-pki-role
+	SignCSRPath string `json:"signCSRPath"`
+	// AuthPath is the login path of the Kubernetes auth method, defaults to auth/kubernetes/login
+	AuthPath string `json:"authPath,omitempty"`
+	// Role of the Kubernetes auth method the node agent logs in with
+	Role string `json:"role"`
+	// CACertSecret selects the CA certificate the TLS certificate of the Vault server is verified with
+	CACertSecret VaultCACertSecretReference `json:"caCertSecret"`
+}
+
+// VaultCACertSecretReference selects the CA certificate of the Vault server
+type VaultCACertSecretReference struct {
+	// Name of the secret in the namespace of the Istio resource
+	Name string `json:"name"`
+	// Key of the PEM encoded CA certificate in the secret, defaults to ca.crt
+	Key string `json:"key,omitempty"`
+}
+
+// IsVaultCAProvider returns whether the workload certificates are signed by Vault instead of Citadel
+func (c NodeAgentConfiguration) IsVaultCAProvider() bool {
+	return c.CAProvider == CAProviderVault
+}
+
+// ProxyConfiguration defines config options for Proxy
+type ProxyConfiguration struct {
+	Image string `json:"image,omitempty"`
+	// If set to true, istio-proxy container will have privileged securityContext
+	Privileged bool `json:"privileged,omitempty"`
+	// If set, newly injected sidecars will have core dumps enabled.
+	EnableCoreDump bool `json:"enableCoreDump,omitempty"`
+	// Log level for proxy, applies to gateways and sidecars. If left empty, "warning" is used.
+	// Expected values are: trace|debug|info|warning|error|critical|off
+	// +kubebuilder:validation:Enum=trace,debug,info,warning,error,critical,off
+	LogLevel string `json:"logLevel,omitempty"`
+	// Per Component log level for proxy, applies to gateways and sidecars. If a component level is
+	// not set, then the "LogLevel" will be used. If left empty, "misc:error" is used.
+	ComponentLogLevel string `json:"componentLogLevel,omitempty"`
+	// Configure the DNS refresh rate for Envoy cluster of type STRICT_DNS
+	// This must be given it terms of seconds. For example, 300s is valid but 5m is invalid.
+	// +kubebuilder:validation:Pattern=^[0-9]{1,5}s$
+	DNSRefreshRate string `json:"dnsRefreshRate,omitempty"`
+
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ProxyInitConfiguration defines config options for Proxy Init containers
+type ProxyInitConfiguration struct {
+	Image string `json:"image,omitempty"`
+}
+
+// PDBConfiguration holds Pod Disruption Budget related config options
+type PDBConfiguration struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type OutboundTrafficPolicyConfiguration struct {
+	// +kubebuilder:validation:Enum=ALLOW_ANY,REGISTRY_ONLY
+	Mode string `json:"mode,omitempty"`
+}
+
+// Configuration for Envoy to send trace data to Zipkin/Jaeger.
+type ZipkinConfiguration struct {
+	// Host:Port for reporting trace data in zipkin format. If not specified, will default to zipkin service (port 9411) in the same namespace as the other istio components.
+	// +kubebuilder:validation:Pattern=^[^\:]+:[0-9]{1,5}$
+	Address string `json:"address,omitempty"`
+}
+
+// Configuration for Envoy to send trace data to Lightstep
+type LightstepConfiguration struct {
+	// the <host>:<port> of the satellite pool
+	// +kubebuilder:validation:Pattern=^[^\:]+:[0-9]{1,5}$
+	Address string `json:"address,omitempty"`
+	// required for sending data to the pool
+	AccessToken string `json:"accessToken,omitempty"`
+	// specifies whether data should be sent with TLS
+	Secure bool `json:"secure,omitempty"`
+	// the path to the file containing the cacert to use when verifying TLS. If secure is true, this is
+	// required. If a value is specified then a secret called "lightstep.cacert" must be created in the destination
+	// namespace with the key matching the base of the provided cacertPath and the value being the cacert itself.
+	CacertPath string `json:"cacertPath,omitempty"`
+}
+
+// Configuration for Envoy to send trace data to Datadog
+type DatadogConfiguration struct {
+	// Host:Port for submitting traces to the Datadog agent.
+	// +kubebuilder:validation:Pattern=^[^\:]+:[0-9]{1,5}$
+	Address string `json:"address,omitempty"`
+}
+
+type TracerType string
+
+const (
+	TracerTypeZipkin    TracerType = "zipkin"
+	TracerTypeLightstep TracerType = "lightstep"
+	TracerTypeDatadog   TracerType = "datadog"
+)
+
+type TracingConfiguration struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	// +kubebuilder:validation:Enum=zipkin,lightstep,datadog
+	Tracer    TracerType             `json:"tracer,omitempty"`
+	Zipkin    ZipkinConfiguration    `json:"zipkin,omitempty"`
+	Lightstep LightstepConfiguration `json:"lightstep,omitempty"`
+	Datadog   DatadogConfiguration   `json:"datadog,omitempty"`
+}
+
+type IstioCoreDNS struct {
+	Enabled      *bool                        `json:"enabled,omitempty"`
+	Image        string                       `json:"image,omitempty"`
+	PluginImage  string                       `json:"pluginImage,omitempty"`
+	ReplicaCount int32                        `json:"replicaCount,omitempty"`
+	Resources    *corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector map[string]string            `json:"nodeSelector,omitempty"`
+	Affinity     *corev1.Affinity             `json:"affinity,omitempty"`
+	Tolerations  []corev1.Toleration          `json:"tolerations,omitempty"`
+}
+
+// Describes how traffic originating in the 'from' zone is
+// distributed over a set of 'to' zones. Syntax for specifying a zone is
+// {region}/{zone} and terminal wildcards are allowed on any
+// segment of the specification. Examples:
+// * - matches all localities
+// us-west/* - all zones and sub-zones within the us-west region
+type LocalityLBDistributeConfiguration struct {
+	// Originating locality, '/' separated, e.g. 'region/zone'.
+	From string `json:"from,omitempty"`
+	// Map of upstream localities to traffic distribution weights. The sum of
+	// all weights should be == 100. Any locality not assigned a weight will
+	// receive no traffic.
+	To map[string]uint32 `json:"to,omitempty"`
+}
+
+// Specify the traffic failover policy across regions. Since zone
+// failover is supported by default this only needs to be specified for
+// regions when the operator needs to constrain traffic failover so that
+// the default behavior of failing over to any endpoint globally does not
+// apply. This is useful when failing over traffic across regions would not
+// improve service health or may need to be restricted for other reasons
+// like regulatory controls.
+type LocalityLBFailoverConfiguration struct {
+	// Originating region.
+	From string `json:"from,omitempty"`
+	// Destination region the traffic will fail over to when endpoints in
+	// the 'from' region becomes unhealthy.
+	To string `json:"to,omitempty"`
+}
+
+// Locality-weighted load balancing allows administrators to control the
+// distribution of traffic to endpoints based on the localities of where the
+// traffic originates and where it will terminate.
+type LocalityLBConfiguration struct {
+	// If set to true, locality based load balancing will be enabled
+	Enabled *bool `json:"enabled,omitempty"`
+	// Optional: only one of distribute or failover can be set.
+	// Explicitly specify loadbalancing weight across different zones and geographical locations.
+	// Refer to [Locality weighted load balancing](https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/load_balancing/locality_weight)
+	// If empty, the locality weight is set according to the endpoints number within it.
+	Distribute []*LocalityLBDistributeConfiguration `json:"distribute,omitempty"`
+	// Optional: only failover or distribute can be set.
+	// Explicitly specify the region traffic will land on when endpoints in local region becomes unhealthy.
+	// Should be used together with OutlierDetection to detect unhealthy endpoints.
+	// Note: if no OutlierDetection specified, this will not take effect.
+	Failover []*LocalityLBFailoverConfiguration `json:"failover,omitempty"`
+}
+
+type MTLSMode string
+
+const (
+	// MTLSModeStrict only accepts mutual TLS traffic
+	MTLSModeStrict MTLSMode = "STRICT"
+	// MTLSModePermissive accepts both plain text and mutual TLS traffic
+	MTLSModePermissive MTLSMode = "PERMISSIVE"
+)
+
+// MTLSConfiguration defines the mesh wide mutual TLS settings
+type MTLSConfiguration struct {
+	// Mode of the mesh policy, defaults to PERMISSIVE
+	// +kubebuilder:validation:Enum=STRICT,PERMISSIVE
+	Mode MTLSMode `json:"mode,omitempty"`
+}
+
+// IstioSpec defines the desired state of Istio
+type IstioSpec struct {
+	// Contains the intended Istio version
+	// +kubebuilder:validation:Pattern=^1\.(2|3|4)(\.|$)
+	Version IstioVersion `json:"version"`
+
+	// MTLS configures mutual TLS between the workloads of the mesh
+	MTLS MTLSConfiguration `json:"mtls,omitempty"`
+
+	// IncludeIPRanges the range where to capture egress traffic
+	IncludeIPRanges string `json:"includeIPRanges,omitempty"`
+
+	// ExcludeIPRanges the range where not to capture egress traffic
+	ExcludeIPRanges string `json:"excludeIPRanges,omitempty"`
+
+	// List of namespaces to label with sidecar auto injection enabled
+	AutoInjectionNamespaces []string `json:"autoInjectionNamespaces,omitempty"`
+
+	// ControlPlaneSecurityEnabled control plane services are communicating through mTLS
+	ControlPlaneSecurityEnabled bool `json:"controlPlaneSecurityEnabled,omitempty"`
+
+	// DefaultResources are applied for all Istio components by default, can be overridden for each component
+	DefaultResources *corev1.ResourceRequirements `json:"defaultResources,omitempty"`
+
+	// If SDS is configured, mTLS certificates for the sidecars will be distributed through the SecretDiscoveryService instead of using K8S secrets to mount the certificates
+	SDS SDSConfiguration `json:"sds,omitempty"`
+
+	// Pilot configuration options
+	Pilot PilotConfiguration `json:"pilot,omitempty"`
+
+	// Citadel configuration options
+	Citadel CitadelConfiguration `json:"citadel,omitempty"`
+
+	// Galley configuration options
+	Galley GalleyConfiguration `json:"galley,omitempty"`
+
+	// Gateways configuration options
+	Gateways GatewaysConfiguration `json:"gateways,omitempty"`
+
+	// Mixer configuration options
+	Mixer MixerConfiguration `json:"mixer,omitempty"`
+
+	// SidecarInjector configuration options
+	SidecarInjector SidecarInjectorConfiguration `json:"sidecarInjector,omitempty"`
+
+	// NodeAgent configuration options
+	NodeAgent NodeAgentConfiguration `json:"nodeAgent,omitempty"`
+
+	// Proxy configuration options
+	Proxy ProxyConfiguration `json:"proxy,omitempty"`
+
+	// Proxy Init configuration options
+	ProxyInit ProxyInitConfiguration `json:"proxyInit,omitempty"`
+
+	// Whether to restrict the applications namespace the controller manages
+	WatchOneNamespace bool `json:"watchOneNamespace,omitempty"`
+
+	// Use the Mesh Control Protocol (MCP) for configuring Mixer and Pilot. Requires galley.
+	UseMCP *bool `json:"useMCP,omitempty"`
+
+	// Set the default set of namespaces to which services, service entries, virtual services, destination rules should be exported to
+	DefaultConfigVisibility string `json:"defaultConfigVisibility,omitempty"`
+
+	// Whether or not to establish watches for adapter-specific CRDs
+	WatchAdapterCRDs bool `json:"watchAdapterCRDs,omitempty"`
+
+	// Enable pod disruption budget for the control plane, which is used to ensure Istio control plane components are gradually upgraded or recovered
+	DefaultPodDisruptionBudget PDBConfiguration `json:"defaultPodDisruptionBudget,omitempty"`
+
+	// Set the default behavior of the sidecar for handling outbound traffic from the application (ALLOW_ANY or REGISTRY_ONLY)
+	OutboundTrafficPolicy OutboundTrafficPolicyConfiguration `json:"outboundTrafficPolicy,omitempty"`
+
+	// Configuration for each of the supported tracers
+	Tracing TracingConfiguration `json:"tracing,omitempty"`
+
+	// ImagePullPolicy describes a policy for if/when to pull a container image
+	// +kubebuilder:validation:Enum=Always,Never,IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// If set to true, the pilot and citadel mtls will be exposed on the
+	// ingress gateway also the remote istios will be connected through gateways
+	MeshExpansion *bool `json:"meshExpansion,omitempty"`
+
+	// Set to true to connect two or more meshes via their respective
+	// ingressgateway services when workloads in each cluster cannot directly
+	// talk to one another. All meshes should be using Istio mTLS and must
+	// have a shared root CA for this model to work.
+	MultiMesh *bool `json:"multiMesh,omitempty"`
+
+	// Istio CoreDNS provides DNS resolution for services in multi mesh setups
+	IstioCoreDNS IstioCoreDNS `json:"istioCoreDNS,omitempty"`
+
+	// Locality based load balancing distribution or failover settings.
+	LocalityLB *LocalityLBConfiguration `json:"localityLB,omitempty"`
+
+	// Control plane revisions running alongside the default control plane, e.g. for canary upgrades
+	Revisions []RevisionConfiguration `json:"revisions,omitempty"`
+}
+
+// RevisionConfiguration defines a control plane revision which consists of pilot, galley and sidecar injector
+// instances running alongside the default ones
+type RevisionConfiguration struct {
+	// Name of the revision, it is used as a suffix for the names of the resources of the revision
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	Name string `json:"name"`
+
+	// Contains the intended Istio version of the revision, defaults to the version of the default control plane.
+	// The images of the revision default to the ones of this version.
+	// +kubebuilder:validation:Pattern=^1\.(2|3|4)(\.|$)
+	Version IstioVersion `json:"version,omitempty"`
+
+	// Images of the revision, they default to the images of the default control plane
+	PilotImage           string `json:"pilotImage,omitempty"`
+	GalleyImage          string `json:"galleyImage,omitempty"`
+	SidecarInjectorImage string `json:"sidecarInjectorImage,omitempty"`
+	ProxyImage           string `json:"proxyImage,omitempty"`
+	ProxyInitImage       string `json:"proxyInitImage,omitempty"`
+}
+
+// IstioStatus defines the observed state of Istio
+type IstioStatus struct {
+	Status         ConfigState `json:"status,omitempty"`
+	GatewayAddress []string    `json:"gatewayAddress,omitempty"`
+	ErrorMessage   string      `json:"errorMessage,omitempty"`
+	// Conditions hold the outcome of the last reconciliation of each component
+	Conditions []Condition `json:"conditions,omitempty"`
+	// CARotation shows the progress of the rotation of the Citadel CA
+	CARotation *CARotationStatus `json:"caRotation,omitempty"`
+}
+
+// CARotationStatus describes the progress of the rotation of the Citadel CA
+type CARotationStatus struct {
+	// Name of the secret holding the CA the rotation is done to
+	NewCASecretName string `json:"newCASecretName"`
+	// The active stage of the rotation
+	Stage CARotationStage `json:"stage"`
+	// When the active stage was started
+	StageStartTime metav1.Time `json:"stageStartTime,omitempty"`
+	// What the active stage is waiting for
+	Message string `json:"message,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Istio is the Schema for the istios API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="Status of the resource"
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=".status.errorMessage",description="Error message"
+// +kubebuilder:printcolumn:name="Gateways",type="string",JSONPath=".status.gatewayAddress",description="Ingress gateways of the resource"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type Istio struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IstioSpec   `json:"spec,omitempty"`
+	Status IstioStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// IstioList contains a list of Istio
+type IstioList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Istio `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Istio{}, &IstioList{})
+}
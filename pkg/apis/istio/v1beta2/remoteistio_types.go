@@ -0,0 +1,170 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type IstioService struct {
+	Name string `json:"name"`
+	// Namespace of the service, defaults to the namespace of the RemoteIstio resource. The service is
+	// replicated to the same namespace on remote side.
+	Namespace     string               `json:"namespace,omitempty"`
+	LabelSelector string               `json:"labelSelector,omitempty"`
+	IPs           []string             `json:"podIPs,omitempty"`
+	Ports         []corev1.ServicePort `json:"ports,omitempty"`
+}
+
+// IstioReference identifies the Istio resource of a control plane
+type IstioReference struct {
+	// Name of the Istio resource
+	Name string `json:"name"`
+
+	// Namespace of the Istio resource, it must be the namespace of the RemoteIstio resource
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ServiceExport selects the exported services by the labels of the services and of their namespaces
+type ServiceExport struct {
+	// NamespaceSelector selects the namespaces the services are exported from, defaults to the namespace
+	// of the RemoteIstio resource
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// ServiceSelector selects the exported services
+	ServiceSelector *metav1.LabelSelector `json:"serviceSelector"`
+}
+
+// KubeconfigSecretReference selects the kubeconfig of the remote cluster
+type KubeconfigSecretReference struct {
+	// Name of the secret in the namespace of the RemoteIstio resource, defaults to the name of the RemoteIstio resource
+	Name string `json:"name,omitempty"`
+
+	// Key of the kubeconfig in the secret, it can be omitted if the secret has a single key or a kubeconfig key
+	Key string `json:"key,omitempty"`
+}
+
+// RemoteIstioSpec defines the desired state of RemoteIstio
+type RemoteIstioSpec struct {
+	// IstioRef references the Istio resource of the control plane the remote cluster is attached to,
+	// defaults to the only Istio resource in the namespace of the RemoteIstio resource
+	IstioRef *IstioReference `json:"istioRef,omitempty"`
+
+	// KubeconfigSecret selects the secret and the key holding the kubeconfig of the remote cluster, the
+	// clients of the remote cluster are rebuilt when it changes
+	KubeconfigSecret *KubeconfigSecretReference `json:"kubeconfigSecret,omitempty"`
+
+	// IncludeIPRanges the range where to capture egress traffic
+	IncludeIPRanges string `json:"includeIPRanges,omitempty"`
+
+	// ExcludeIPRanges the range where not to capture egress traffic
+	ExcludeIPRanges string `json:"excludeIPRanges,omitempty"`
+
+	// EnabledServices the Istio component services replicated to remote side
+	EnabledServices []IstioService `json:"enabledServices"`
+
+	// ServiceExport selects the services replicated to remote side by labels, in addition to the enabled services
+	ServiceExport *ServiceExport `json:"serviceExport,omitempty"`
+
+	// List of namespaces to label with sidecar auto injection enabled
+	AutoInjectionNamespaces []string `json:"autoInjectionNamespaces,omitempty"`
+
+	// DefaultResources are applied for all Istio components by default, can be overridden for each component
+	DefaultResources *corev1.ResourceRequirements `json:"defaultResources,omitempty"`
+
+	// Citadel configuration options
+	Citadel CitadelConfiguration `json:"citadel,omitempty"`
+
+	// SidecarInjector configuration options
+	SidecarInjector SidecarInjectorConfiguration `json:"sidecarInjector,omitempty"`
+
+	// Proxy configuration options
+	Proxy ProxyConfiguration `json:"proxy,omitempty"`
+
+	// Proxy Init configuration options
+	ProxyInit ProxyInitConfiguration `json:"proxyInit,omitempty"`
+}
+
+// RemoteIstioStatus defines the observed state of RemoteIstio
+type RemoteIstioStatus struct {
+	Status         ConfigState `json:"status,omitempty"`
+	GatewayAddress []string    `json:"gatewayAddress,omitempty"`
+	ErrorMessage   string      `json:"errorMessage,omitempty"`
+	// LastSuccessfulContact is the last time the API server of the remote cluster was reached
+	LastSuccessfulContact *metav1.Time `json:"lastSuccessfulContact,omitempty"`
+	// KubernetesVersion is the version of the remote cluster
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Conditions hold the outcome of the last health check of the remote cluster
+	Conditions []Condition `json:"conditions,omitempty"`
+	// CARotation shows the stage of the rotation of the Citadel CA the remote cluster is at
+	CARotation *RemoteCARotationStatus `json:"caRotation,omitempty"`
+	// IntermediateCA describes the CA the remote Citadel signs the workload certificates with
+	IntermediateCA *IntermediateCAStatus `json:"intermediateCA,omitempty"`
+}
+
+// RemoteCARotationStatus describes the rotation of the Citadel CA in a remote cluster
+type RemoteCARotationStatus struct {
+	// The stage of the rotation whose CA was last written into the remote cluster
+	Stage CARotationStage `json:"stage"`
+	// Number of workload certificates in the remote cluster not yet signed by the new CA
+	PendingWorkloadCerts int `json:"pendingWorkloadCerts,omitempty"`
+}
+
+// IntermediateCAStatus describes the intermediate CA issued for a remote cluster by the CA of the primary cluster
+type IntermediateCAStatus struct {
+	// Name of the secret in the primary cluster the intermediate CA is kept in
+	SecretName string `json:"secretName"`
+	// Serial number of the intermediate CA certificate
+	SerialNumber string `json:"serialNumber"`
+	// When the intermediate CA certificate was issued
+	NotBefore metav1.Time `json:"notBefore"`
+	// When the intermediate CA certificate expires
+	NotAfter metav1.Time `json:"notAfter"`
+	// When the intermediate CA certificate is going to be re-issued
+	RenewTime metav1.Time `json:"renewTime"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemoteIstio is the Schema for the remoteistios API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.status",description="Status of the resource"
+// +kubebuilder:printcolumn:name="Error",type="string",JSONPath=".status.errorMessage",description="Error message"
+// +kubebuilder:printcolumn:name="Gateways",type="string",JSONPath=".status.gatewayAddress",description="Ingress gateways of the resource"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type RemoteIstio struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteIstioSpec   `json:"spec,omitempty"`
+	Status RemoteIstioStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RemoteIstioList contains a list of RemoteIstio
+type RemoteIstioList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteIstio `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemoteIstio{}, &RemoteIstioList{})
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	remoteistioCtrl "github.com/banzaicloud/istio-operator/pkg/controller/remoteistio"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+const (
+	// caRotationCheckInterval is the interval the progress of a CA rotation is checked at
+	caRotationCheckInterval = 30 * time.Second
+	// defaultWorkloadCertTTL is the default lifetime of the workload certificates issued by Citadel
+	defaultWorkloadCertTTL = 90 * 24 * time.Hour
+)
+
+// caRotationStages are the stages of the CA rotation in their order
+var caRotationStages = []istiov1beta1.CARotationStage{
+	istiov1beta1.CARotationDistributingTrustBundle,
+	istiov1beta1.CARotationSigningWithNewCA,
+	istiov1beta1.CARotationWaitingForWorkloadCerts,
+	istiov1beta1.CARotationDroppingOldRoot,
+	istiov1beta1.CARotationCompleted,
+}
+
+// reconcileCARotation starts the rotation of the Citadel CA and moves it to its next stage once the current
+// one is done in the cluster and in every remote cluster. The stages are applied by the Citadel reconciler.
+func (r *ReconcileConfig) reconcileCARotation(config *istiov1beta1.Istio, logger logr.Logger) error {
+	rotation := config.Spec.Citadel.CARotation
+	status := config.Status.CARotation
+	recorder := k8sutil.NewEventRecorder(r.recorder, config)
+
+	if errs := istiov1beta1.ValidateCARotationRemoval(config.Spec.Citadel, status, field.NewPath("spec", "citadel")); len(errs) > 0 {
+		recorder.Warning(k8sutil.EventReasonCARotation, "%s", errs.ToAggregate().Error())
+		return errs.ToAggregate()
+	}
+
+	if rotation == nil {
+		if status != nil && status.Stage != istiov1beta1.CARotationCompleted {
+			logger.Info("CA rotation aborted", "stage", status.Stage)
+			recorder.Warning(k8sutil.EventReasonCARotation, "CA rotation to %s aborted in stage %s", status.NewCASecretName, status.Stage)
+		}
+		config.Status.CARotation = nil
+		return nil
+	}
+
+	if status == nil || status.NewCASecretName != rotation.NewCASecretName {
+		config.Status.CARotation = &istiov1beta1.CARotationStatus{
+			NewCASecretName: rotation.NewCASecretName,
+		}
+		setCARotationStage(config, istiov1beta1.CARotationDistributingTrustBundle)
+		logger.Info("CA rotation started", "newCASecret", rotation.NewCASecretName)
+		recorder.Normal(k8sutil.EventReasonCARotation, "CA rotation to %s started", rotation.NewCASecretName)
+		return nil
+	}
+
+	if status.Stage == istiov1beta1.CARotationCompleted {
+		return nil
+	}
+
+	message, err := r.caRotationStagePending(config, status, logger)
+	if err != nil {
+		return emperror.Wrap(err, "could not check the progress of the CA rotation")
+	}
+	if message != "" {
+		status.Message = message
+		logger.Info("CA rotation in progress", "stage", status.Stage, "waitingFor", message)
+		return nil
+	}
+
+	for i, stage := range caRotationStages[:len(caRotationStages)-1] {
+		if stage == status.Stage {
+			setCARotationStage(config, caRotationStages[i+1])
+			break
+		}
+	}
+	logger.Info("CA rotation stage started", "stage", config.Status.CARotation.Stage)
+	recorder.Normal(k8sutil.EventReasonCARotation, "CA rotation to %s reached stage %s", status.NewCASecretName, config.Status.CARotation.Stage)
+
+	return nil
+}
+
+// isCARotationInProgress returns whether the CA rotation has to be checked again later
+func isCARotationInProgress(config *istiov1beta1.Istio) bool {
+	return config.Status.CARotation != nil && config.Status.CARotation.Stage != istiov1beta1.CARotationCompleted
+}
+
+func setCARotationStage(config *istiov1beta1.Istio, stage istiov1beta1.CARotationStage) {
+	config.Status.CARotation.Stage = stage
+	config.Status.CARotation.StageStartTime = metav1.Now()
+	config.Status.CARotation.Message = ""
+}
+
+// caRotationStagePending returns what the active stage of the CA rotation is waiting for, it is empty if the
+// stage is done
+func (r *ReconcileConfig) caRotationStagePending(config *istiov1beta1.Istio, status *istiov1beta1.CARotationStatus, logger logr.Logger) (string, error) {
+	var secret corev1.Secret
+	err := r.Get(context.TODO(), client.ObjectKey{
+		Namespace: config.Namespace,
+		Name:      citadel.CARotationSecretName,
+	}, &secret)
+	if k8serrors.IsNotFound(err) {
+		return "the CA of the stage to be written", nil
+	}
+	if err != nil {
+		return "", emperror.Wrap(err, "could not get CA rotation secret")
+	}
+	if secret.Annotations[citadel.CARotationStageAnnotation] != string(status.Stage) {
+		return "the CA of the stage to be written", nil
+	}
+
+	var deployment appsv1.Deployment
+	err = r.Get(context.TODO(), client.ObjectKey{
+		Namespace: config.Namespace,
+		Name:      citadel.GetDeploymentName(),
+	}, &deployment)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", emperror.Wrap(err, "could not get Citadel deployment")
+	}
+	if k8serrors.IsNotFound(err) || !citadel.IsDeploymentUsingCA(&deployment, &secret) {
+		return "Citadel to be restarted with the CA of the stage", nil
+	}
+	if rolledOut, message := k8sutil.IsDeploymentRolledOut(&deployment); !rolledOut {
+		return "Citadel to be restarted: " + message, nil
+	}
+
+	waitingForCerts := status.Stage == istiov1beta1.CARotationWaitingForWorkloadCerts
+	if waitingForCerts {
+		deadline := status.StageStartTime.Add(workloadCertTTL(config))
+		// the certificates signed by the old CA are expired after their lifetime, the remote clusters still
+		// have to reach the stage
+		waitingForCerts = time.Now().Before(deadline)
+		// the certificates delivered through SDS are not visible, their lifetime has to elapse
+		if waitingForCerts && util.PointerToBool(config.Spec.SDS.Enabled) {
+			return fmt.Sprintf("the workload certificates to expire at %s", deadline.Format(time.RFC3339)), nil
+		}
+
+		if waitingForCerts {
+			pending, err := citadel.PendingWorkloadCerts(context.TODO(), r.Client, secret.Data["ca-cert.pem"])
+			if err != nil {
+				return "", err
+			}
+			if pending > 0 {
+				return fmt.Sprintf("%d workload certificates to be signed by the new CA, at most until %s", pending, deadline.Format(time.RFC3339)), nil
+			}
+		}
+	}
+
+	pendingRemotes := make([]string, 0)
+	for _, remoteIstio := range remoteistioCtrl.GetRemoteIstiosByOwnerReference(r.mgr, config, logger) {
+		remoteStatus := remoteIstio.Status.CARotation
+		if remoteStatus == nil || remoteStatus.Stage != status.Stage || (waitingForCerts && remoteStatus.PendingWorkloadCerts > 0) {
+			pendingRemotes = append(pendingRemotes, remoteIstio.Name)
+		}
+	}
+	if len(pendingRemotes) > 0 {
+		sort.Strings(pendingRemotes)
+		return "remote clusters: " + strings.Join(pendingRemotes, ", "), nil
+	}
+
+	return "", nil
+}
+
+// workloadCertTTL returns the lifetime of the workload certificates issued by Citadel
+func workloadCertTTL(config *istiov1beta1.Istio) time.Duration {
+	if ttl, err := time.ParseDuration(config.Spec.Citadel.WorkloadCertTTL); err == nil && ttl > 0 {
+		return ttl
+	}
+
+	return defaultWorkloadCertTTL
+}
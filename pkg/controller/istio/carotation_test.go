@@ -0,0 +1,373 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+// testManager provides the client and the scheme of the manager to the reconciler
+type testManager struct {
+	manager.Manager
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func (m *testManager) GetClient() client.Client {
+	return m.client
+}
+
+func (m *testManager) GetScheme() *runtime.Scheme {
+	return m.scheme
+}
+
+// testCertificate returns a PEM encoded certificate signed by the parent or self-signed if the parent is nil
+func testCertificate(t *testing.T, commonName string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, key, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestReconcileCARotationStages(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	oldCA, oldKey, _ := testCertificate(t, "old", nil, nil)
+	_, _, newCAPEM := testCertificate(t, "new", nil, nil)
+	_, _, oldWorkloadCert := testCertificate(t, "workload", oldCA, oldKey)
+
+	remote := func(name string, stage istiov1beta1.CARotationStage, pendingWorkloadCerts int) *istiov1beta1.RemoteIstio {
+		remoteIstio := &istiov1beta1.RemoteIstio{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "istio-system"},
+			Spec: istiov1beta1.RemoteIstioSpec{
+				IstioRef: &istiov1beta1.IstioReference{Name: "mesh"},
+			},
+		}
+		if stage != "" {
+			remoteIstio.Status.CARotation = &istiov1beta1.RemoteCARotationStatus{
+				Stage:                stage,
+				PendingWorkloadCerts: pendingWorkloadCerts,
+			}
+		}
+		return remoteIstio
+	}
+
+	tests := []struct {
+		name string
+		// the stage of the status, the rotation is not started if it is empty
+		stage           istiov1beta1.CARotationStage
+		stageStarted    time.Duration
+		removed         bool
+		caSecretName    string
+		secretStage     istiov1beta1.CARotationStage
+		citadelPending  bool
+		sds             bool
+		workloadCertTTL string
+		workloadCerts   bool
+		remotes         []*istiov1beta1.RemoteIstio
+		wantErr         bool
+		wantStatusNil   bool
+		wantStage       istiov1beta1.CARotationStage
+		wantMessage     string
+	}{
+		{
+			name:      "rotation started",
+			wantStage: istiov1beta1.CARotationDistributingTrustBundle,
+		},
+		{
+			name:        "CA of the stage not written yet",
+			stage:       istiov1beta1.CARotationSigningWithNewCA,
+			secretStage: istiov1beta1.CARotationDistributingTrustBundle,
+			wantStage:   istiov1beta1.CARotationSigningWithNewCA,
+			wantMessage: "the CA of the stage to be written",
+		},
+		{
+			name:           "Citadel not restarted",
+			stage:          istiov1beta1.CARotationSigningWithNewCA,
+			citadelPending: true,
+			wantStage:      istiov1beta1.CARotationSigningWithNewCA,
+			wantMessage:    "Citadel to be restarted with the CA of the stage",
+		},
+		{
+			name:      "stage done",
+			stage:     istiov1beta1.CARotationDistributingTrustBundle,
+			wantStage: istiov1beta1.CARotationSigningWithNewCA,
+		},
+		{
+			name:  "stage done in the remote clusters",
+			stage: istiov1beta1.CARotationSigningWithNewCA,
+			remotes: []*istiov1beta1.RemoteIstio{
+				remote("remote-a", istiov1beta1.CARotationSigningWithNewCA, 0),
+				remote("remote-b", istiov1beta1.CARotationSigningWithNewCA, 0),
+			},
+			wantStage: istiov1beta1.CARotationWaitingForWorkloadCerts,
+		},
+		{
+			name:  "remote clusters behind",
+			stage: istiov1beta1.CARotationSigningWithNewCA,
+			remotes: []*istiov1beta1.RemoteIstio{
+				remote("remote-c", "", 0),
+				remote("remote-b", istiov1beta1.CARotationDistributingTrustBundle, 0),
+				remote("remote-a", istiov1beta1.CARotationSigningWithNewCA, 0),
+			},
+			wantStage:   istiov1beta1.CARotationSigningWithNewCA,
+			wantMessage: "remote clusters: remote-b, remote-c",
+		},
+		{
+			name:          "workload certificates pending",
+			stage:         istiov1beta1.CARotationWaitingForWorkloadCerts,
+			workloadCerts: true,
+			wantStage:     istiov1beta1.CARotationWaitingForWorkloadCerts,
+			wantMessage:   "1 workload certificates to be signed by the new CA",
+		},
+		{
+			name:  "workload certificates pending in a remote cluster",
+			stage: istiov1beta1.CARotationWaitingForWorkloadCerts,
+			remotes: []*istiov1beta1.RemoteIstio{
+				remote("remote-a", istiov1beta1.CARotationWaitingForWorkloadCerts, 2),
+			},
+			wantStage:   istiov1beta1.CARotationWaitingForWorkloadCerts,
+			wantMessage: "remote clusters: remote-a",
+		},
+		{
+			name:        "workload certificates delivered through SDS",
+			stage:       istiov1beta1.CARotationWaitingForWorkloadCerts,
+			sds:         true,
+			wantStage:   istiov1beta1.CARotationWaitingForWorkloadCerts,
+			wantMessage: "the workload certificates to expire at",
+		},
+		{
+			name:          "workload certificate lifetime elapsed",
+			stage:         istiov1beta1.CARotationWaitingForWorkloadCerts,
+			stageStarted:  defaultWorkloadCertTTL + time.Hour,
+			sds:           true,
+			workloadCerts: true,
+			remotes: []*istiov1beta1.RemoteIstio{
+				remote("remote-a", istiov1beta1.CARotationWaitingForWorkloadCerts, 2),
+			},
+			wantStage: istiov1beta1.CARotationDroppingOldRoot,
+		},
+		{
+			name:            "custom workload certificate lifetime elapsed",
+			stage:           istiov1beta1.CARotationWaitingForWorkloadCerts,
+			stageStarted:    2 * time.Hour,
+			workloadCertTTL: "1h",
+			workloadCerts:   true,
+			wantStage:       istiov1beta1.CARotationDroppingOldRoot,
+		},
+		{
+			name:          "workload certificate lifetime elapsed, remote cluster behind",
+			stage:         istiov1beta1.CARotationWaitingForWorkloadCerts,
+			stageStarted:  defaultWorkloadCertTTL + time.Hour,
+			workloadCerts: true,
+			remotes: []*istiov1beta1.RemoteIstio{
+				remote("remote-a", istiov1beta1.CARotationSigningWithNewCA, 0),
+			},
+			wantStage:   istiov1beta1.CARotationWaitingForWorkloadCerts,
+			wantMessage: "remote clusters: remote-a",
+		},
+		{
+			name:      "old root dropped",
+			stage:     istiov1beta1.CARotationDroppingOldRoot,
+			wantStage: istiov1beta1.CARotationCompleted,
+		},
+		{
+			name:      "completed",
+			stage:     istiov1beta1.CARotationCompleted,
+			wantStage: istiov1beta1.CARotationCompleted,
+		},
+		{
+			name:          "aborted while distributing the trust bundle",
+			stage:         istiov1beta1.CARotationDistributingTrustBundle,
+			removed:       true,
+			wantStatusNil: true,
+		},
+		{
+			name:          "finished with the new CA",
+			stage:         istiov1beta1.CARotationCompleted,
+			removed:       true,
+			caSecretName:  "cacerts-2020",
+			wantStatusNil: true,
+		},
+		{
+			name:      "removed after signing with the new CA",
+			stage:     istiov1beta1.CARotationSigningWithNewCA,
+			removed:   true,
+			wantErr:   true,
+			wantStage: istiov1beta1.CARotationSigningWithNewCA,
+		},
+		{
+			name:      "removed when completed",
+			stage:     istiov1beta1.CARotationCompleted,
+			removed:   true,
+			wantErr:   true,
+			wantStage: istiov1beta1.CARotationCompleted,
+		},
+	}
+
+	for _, test := range tests {
+		config := &istiov1beta1.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system", UID: "mesh-uid"},
+			Spec: istiov1beta1.IstioSpec{
+				Citadel: istiov1beta1.CitadelConfiguration{
+					Enabled:         util.BoolPointer(true),
+					CASecretName:    "cacerts",
+					WorkloadCertTTL: test.workloadCertTTL,
+				},
+				SDS: istiov1beta1.SDSConfiguration{
+					Enabled: util.BoolPointer(test.sds),
+				},
+			},
+		}
+		if test.caSecretName != "" {
+			config.Spec.Citadel.CASecretName = test.caSecretName
+		}
+		if !test.removed {
+			config.Spec.Citadel.CARotation = &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"}
+		}
+		if test.stage != "" {
+			config.Status.CARotation = &istiov1beta1.CARotationStatus{
+				NewCASecretName: "cacerts-2020",
+				Stage:           test.stage,
+				StageStartTime:  metav1.NewTime(time.Now().Add(-test.stageStarted)),
+			}
+		}
+
+		secretStage := test.secretStage
+		if secretStage == "" {
+			secretStage = test.stage
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        citadel.CARotationSecretName,
+				Namespace:   "istio-system",
+				Annotations: map[string]string{citadel.CARotationStageAnnotation: string(secretStage)},
+			},
+			Data: map[string][]byte{
+				"ca-cert.pem": newCAPEM,
+			},
+		}
+		hash := citadel.CASecretHash(secret)
+		if test.citadelPending {
+			hash = "previous"
+		}
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: citadel.GetDeploymentName(), Namespace: "istio-system"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{"istio.banzaicloud.io/ca-cert-hash": hash},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				Replicas:          1,
+				UpdatedReplicas:   1,
+				ReadyReplicas:     1,
+				AvailableReplicas: 1,
+			},
+		}
+		objects := []runtime.Object{config, secret, deployment}
+		if test.workloadCerts {
+			objects = append(objects, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "istio.default", Namespace: "default"},
+				Type:       "istio.io/key-and-cert",
+				Data: map[string][]byte{
+					"cert-chain.pem": oldWorkloadCert,
+				},
+			})
+		}
+		for _, remoteIstio := range test.remotes {
+			objects = append(objects, remoteIstio)
+		}
+
+		s := newTestScheme(t)
+		c := fake.NewFakeClientWithScheme(s, objects...)
+		r := &ReconcileConfig{
+			Client: c,
+			mgr: &testManager{
+				client: c,
+				scheme: s,
+			},
+		}
+
+		err := r.reconcileCARotation(config, logf.NullLogger{})
+		if test.wantErr {
+			g.Expect(err).To(gomega.HaveOccurred(), test.name)
+		} else {
+			g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+		}
+		if test.wantStatusNil {
+			g.Expect(config.Status.CARotation).To(gomega.BeNil(), test.name)
+			continue
+		}
+		g.Expect(config.Status.CARotation).NotTo(gomega.BeNil(), test.name)
+		g.Expect(config.Status.CARotation.NewCASecretName).To(gomega.Equal("cacerts-2020"), test.name)
+		g.Expect(config.Status.CARotation.Stage).To(gomega.Equal(test.wantStage), test.name)
+		if test.wantMessage == "" {
+			g.Expect(config.Status.CARotation.Message).To(gomega.BeEmpty(), test.name)
+		} else {
+			g.Expect(config.Status.CARotation.Message).To(gomega.HavePrefix(test.wantMessage), test.name)
+		}
+	}
+}
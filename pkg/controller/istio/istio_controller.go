@@ -19,6 +19,7 @@ package istio
 import (
 	"context"
 	"flag"
+	"sort"
 	"strings"
 	"time"
 
@@ -37,6 +38,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -51,6 +53,7 @@ import (
 	remoteistioCtrl "github.com/banzaicloud/istio-operator/pkg/controller/remoteistio"
 	"github.com/banzaicloud/istio-operator/pkg/crds"
 	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/metrics"
 	"github.com/banzaicloud/istio-operator/pkg/resources"
 	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
 	"github.com/banzaicloud/istio-operator/pkg/resources/cni"
@@ -61,19 +64,23 @@ import (
 	"github.com/banzaicloud/istio-operator/pkg/resources/mixer"
 	"github.com/banzaicloud/istio-operator/pkg/resources/nodeagent"
 	"github.com/banzaicloud/istio-operator/pkg/resources/pilot"
+	"github.com/banzaicloud/istio-operator/pkg/resources/revisions"
 	"github.com/banzaicloud/istio-operator/pkg/resources/sidecarinjector"
 	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
+const controllerName = "config-controller"
 const finalizerID = "istio-operator.finializer.banzaicloud.io"
 const istioSecretTypePrefix = "istio.io"
 const localNetworkName = "local-network"
 
 var log = logf.Log.WithName("controller")
 var watchCreatedResourcesEvents bool
+var rolloutDeadline time.Duration
 
 func init() {
 	flag.BoolVar(&watchCreatedResourcesEvents, "watch-created-resources-events", true, "Whether to watch created resources events")
+	flag.DurationVar(&rolloutDeadline, "rollout-deadline", 10*time.Minute, "Time to wait for the managed deployments and daemonsets to roll out before the reconciliation is marked as failed")
 }
 
 // Add creates a new Config Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -97,17 +104,18 @@ func newReconciler(mgr manager.Manager, d dynamic.Interface, crd *crds.CrdOperat
 		dynamic:     d,
 		crdOperator: crd,
 		mgr:         mgr,
+		recorder:    mgr.GetRecorder(controllerName),
 	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	// Create a new controller
-	c, err := controller.New("config-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{Reconciler: r})
 	if err != nil {
 		return err
 	}
-	err = initWatches(c, mgr.GetScheme(), watchCreatedResourcesEvents, log)
+	err = initWatches(c, mgr.GetClient(), mgr.GetScheme(), watchCreatedResourcesEvents, log)
 	if err != nil {
 		return err
 	}
@@ -122,13 +130,26 @@ type ReconcileConfig struct {
 	dynamic     dynamic.Interface
 	crdOperator *crds.CrdOperator
 	mgr         manager.Manager
+	recorder    record.EventRecorder
 }
 
 type ReconcileComponent func(log logr.Logger, istio *istiov1beta1.Istio) error
 
+// componentReconciler pairs a component reconciler with the status condition reporting its outcome
+type componentReconciler struct {
+	conditionType istiov1beta1.ConditionType
+	reconciler    resources.ComponentReconciler
+}
+
+// name returns the name of the component the metrics are labeled with, e.g. Pilot for PilotReady
+func (c componentReconciler) name() string {
+	return strings.TrimSuffix(string(c.conditionType), "Ready")
+}
+
 // +kubebuilder:rbac:groups="",resources=nodes;services;endpoints;pods;replicationcontrollers;services;endpoints;pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=serviceaccounts;configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="apps",resources=deployments;daemonsets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="apps",resources=deployments/status,verbs=get;update;patch
@@ -145,19 +166,25 @@ type ReconcileComponent func(log logr.Logger, istio *istiov1beta1.Istio) error
 // +kubebuilder:rbac:groups=istio.banzaicloud.io,resources=istios,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=istio.banzaicloud.io,resources=istios/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=authentication.istio.io;cloud.istio.io;config.istio.io;istio.istio.io;networking.istio.io;rbac.istio.io;scalingpolicy.istio.io,resources=*,verbs=*
+// +kubebuilder:rbac:groups=certmanager.k8s.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile reads that state of the cluster for a Config object and makes changes based on the state read
 // and what is in the Config.Spec
 // Automatically generate RBAC rules to allow the Controller to read and write Deployments
-func (r *ReconcileConfig) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileConfig) Reconcile(request reconcile.Request) (result reconcile.Result, err error) {
+	defer func(start time.Time) {
+		metrics.ObserveReconcile(controllerName, metrics.ResultOf(result.Requeue, err), start)
+	}(time.Now())
+
 	logger := log.WithValues("trigger", request.Namespace+"/"+request.Name, "correlationID", uuid.Must(uuid.NewV4()).String())
 	// Fetch the Config instance
 	config := &istiov1beta1.Istio{}
-	err := r.Get(context.TODO(), request.NamespacedName, config)
+	err = r.Get(context.TODO(), request.NamespacedName, config)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			metrics.DeleteConfigState("Istio", request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -166,9 +193,36 @@ func (r *ReconcileConfig) Reconcile(request reconcile.Request) (reconcile.Result
 
 	logger.Info("Reconciling Istio")
 
+	recorder := k8sutil.NewEventRecorder(r.recorder, config)
+
 	if !config.Spec.Version.IsSupported() {
 		err = errors.New("intended Istio version is unsupported by this version of the operator")
-		logger.Error(err, "", "version", config.Spec.Version)
+		logger.Error(err, "", "version", config.Spec.Version, "supportedVersions", istiov1beta1.SupportedIstioVersions())
+		recorder.Warning(k8sutil.EventReasonUnsupportedVersion, "Istio version %s is unsupported, supported versions: %s", config.Spec.Version, strings.Join(istiov1beta1.SupportedIstioVersions(), ", "))
+		return reconcile.Result{
+			Requeue: false,
+		}, nil
+	}
+	for _, revision := range config.Spec.Revisions {
+		if revision.Version != "" && !revision.Version.IsSupported() {
+			err = errors.New("intended Istio version of revision is unsupported by this version of the operator")
+			logger.Error(err, "", "revision", revision.Name, "version", revision.Version, "supportedVersions", istiov1beta1.SupportedIstioVersions())
+			recorder.Warning(k8sutil.EventReasonUnsupportedVersion, "Istio version %s of revision %s is unsupported, supported versions: %s", revision.Version, revision.Name, strings.Join(istiov1beta1.SupportedIstioVersions(), ", "))
+			return reconcile.Result{
+				Requeue: false,
+			}, nil
+		}
+	}
+
+	if errs := config.Validate(); len(errs) > 0 {
+		err = errs.ToAggregate()
+		logger.Error(err, "invalid Istio resource")
+		recorder.Warning(k8sutil.EventReasonInvalidConfig, "%s", err.Error())
+		updateErr := updateStatus(r.Client, config, istiov1beta1.ReconcileFailed, err.Error(), logger)
+		if updateErr != nil {
+			logger.Error(updateErr, "failed to update state")
+			return reconcile.Result{}, errors.WithStack(updateErr)
+		}
 		return reconcile.Result{
 			Requeue: false,
 		}, nil
@@ -176,8 +230,9 @@ func (r *ReconcileConfig) Reconcile(request reconcile.Request) (reconcile.Result
 
 	// Set default values where not set
 	istiov1beta1.SetDefaults(config)
-	result, err := r.reconcile(logger, config)
+	result, err = r.reconcile(logger, config)
 	if err != nil {
+		recorder.Warning(k8sutil.EventReasonReconcileFailed, "%s", err.Error())
 		updateErr := updateStatus(r.Client, config, istiov1beta1.ReconcileFailed, err.Error(), logger)
 		if updateErr != nil {
 			logger.Error(updateErr, "failed to update state")
@@ -211,7 +266,7 @@ func (r *ReconcileConfig) reconcile(logger logr.Logger, config *istiov1beta1.Ist
 	} else {
 		// Deletion timestamp set, config is marked for deletion
 		if util.ContainsString(config.ObjectMeta.Finalizers, finalizerID) {
-			if config.Status.Status == istiov1beta1.Reconciling && config.Status.ErrorMessage == "" {
+			if config.Status.Status == istiov1beta1.Reconciling && config.Status.ErrorMessage == "" && !isRolloutInProgress(config) {
 				logger.Info("cannot remove Istio while reconciling")
 				return reconcile.Result{}, nil
 			}
@@ -235,7 +290,22 @@ func (r *ReconcileConfig) reconcile(logger logr.Logger, config *istiov1beta1.Ist
 		return reconcile.Result{}, nil
 	}
 
-	if config.Status.Status == istiov1beta1.Reconciling {
+	meshes, err := r.getMeshes()
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	config.Spec.SetMeshes(meshes)
+
+	if k8sutil.IsPlanMode(config) {
+		return r.plan(config, logger)
+	}
+
+	err = r.removePlan(config, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if config.Status.Status == istiov1beta1.Reconciling && !isRolloutInProgress(config) {
 		logger.Info("cannot trigger reconcile while already reconciling")
 		return reconcile.Result{
 			Requeue:      true,
@@ -243,15 +313,18 @@ func (r *ReconcileConfig) reconcile(logger logr.Logger, config *istiov1beta1.Ist
 		}, nil
 	}
 
-	err := updateStatus(r.Client, config, istiov1beta1.Reconciling, "", logger)
+	err = updateStatus(r.Client, config, istiov1beta1.Reconciling, "", logger)
 	if err != nil {
 		return reconcile.Result{}, errors.WithStack(err)
 	}
 
 	logger.Info("reconciling CRDs")
-	err = r.crdOperator.Reconcile(config, logger)
+	err = r.crdOperator.Reconcile(logger)
 	if err != nil {
 		logger.Error(err, "unable to reconcile CRDs")
+		if apierrors.IsConflict(errors.Cause(err)) {
+			k8sutil.NewEventRecorder(r.recorder, config).Warning(k8sutil.EventReasonCRDUpdateConflict, "%s", err.Error())
+		}
 		return reconcile.Result{}, err
 	}
 
@@ -263,32 +336,45 @@ func (r *ReconcileConfig) reconcile(logger logr.Logger, config *istiov1beta1.Ist
 		config.Spec.SetMeshNetworks(meshNetworks)
 	}
 
-	reconcilers := []resources.ComponentReconciler{
-		common.New(r.Client, config, false),
-		citadel.New(citadel.Configuration{
-			DeployMeshPolicy: true,
-		}, r.Client, r.dynamic, config),
-		galley.New(r.Client, config),
-		pilot.New(r.Client, r.dynamic, config),
-		gateways.New(r.Client, r.dynamic, config),
-		mixer.New(r.Client, r.dynamic, config),
-		cni.New(r.Client, config),
-		sidecarinjector.New(r.Client, config),
-		nodeagent.New(r.Client, config),
-		istiocoredns.New(r.Client, config),
+	err = r.reconcileCARotation(config, logger)
+	if err != nil {
+		return reconcile.Result{}, err
 	}
 
-	for _, rec := range reconcilers {
-		err = rec.Reconcile(logger)
+	reconcilers := r.componentReconcilers(config)
+
+	for i, rec := range reconcilers {
+		start := time.Now()
+		err = rec.reconciler.Reconcile(logger)
+		metrics.ObserveComponentReconcile(controllerName, rec.name(), metrics.ResultOf(false, err), start)
 		if err != nil {
+			setComponentCondition(config, rec.conditionType, corev1.ConditionFalse, istiov1beta1.ConditionReasonReconcileFailed, err.Error())
+			// the remaining components were not reconciled in this round
+			for _, pending := range reconcilers[i+1:] {
+				setComponentCondition(config, pending.conditionType, corev1.ConditionUnknown, istiov1beta1.ConditionReasonPending, "waiting for "+string(rec.conditionType)+" to be reconciled")
+			}
 			return reconcile.Result{}, err
 		}
+		setComponentCondition(config, rec.conditionType, corev1.ConditionTrue, istiov1beta1.ConditionReasonReconciled, "")
+	}
+
+	err = r.removeLegacyClusterScopedResources(config, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	result, err := r.checkRollouts(config, logger)
+	if err != nil || result.Requeue {
+		return result, err
 	}
 
 	if util.PointerToBool(config.Spec.Gateways.Enabled) && util.PointerToBool(config.Spec.Gateways.Configs["ingress"].Enabled) {
 		ingressGatewayAddress, err := r.getIngressGatewayAddress(config, logger)
 		if err != nil {
 			log.Info(err.Error())
+			if _, ok := errors.Cause(err).(k8sutil.IngressSetupPendingError); ok {
+				k8sutil.NewEventRecorder(r.recorder, config).Warning(k8sutil.EventReasonIngressSetupPending, "%s", err.Error())
+			}
 			updateStatus(r.Client, config, istiov1beta1.ReconcileFailed, err.Error(), logger)
 			return reconcile.Result{
 				Requeue:      true,
@@ -303,9 +389,72 @@ func (r *ReconcileConfig) reconcile(logger logr.Logger, config *istiov1beta1.Ist
 		return reconcile.Result{}, errors.WithStack(err)
 	}
 	logger.Info("reconcile finished")
+
+	if isCARotationInProgress(config) {
+		return reconcile.Result{
+			RequeueAfter: caRotationCheckInterval,
+		}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// componentReconcilers returns the reconcilers of the components in the order they are reconciled
+func (r *ReconcileConfig) componentReconcilers(config *istiov1beta1.Istio) []componentReconciler {
+	reconcilers := []componentReconciler{
+		{istiov1beta1.MeshConfigReady, common.New(r.Client, config, false)},
+		{istiov1beta1.CitadelReady, citadel.New(citadel.Configuration{
+			// the mesh policy is cluster wide, it is managed by the default mesh
+			DeployMeshPolicy: config.IsDefaultMesh(),
+		}, r.Client, r.dynamic, config)},
+		{istiov1beta1.GalleyReady, galley.New(r.Client, config)},
+		{istiov1beta1.PilotReady, pilot.New(r.Client, r.dynamic, config)},
+		{istiov1beta1.GatewaysReady, gateways.New(r.Client, r.dynamic, config)},
+		{istiov1beta1.MixerReady, mixer.New(r.Client, r.dynamic, config)},
+		{istiov1beta1.CNIReady, cni.New(r.Client, config)},
+		{istiov1beta1.SidecarInjectorReady, sidecarinjector.New(r.Client, config)},
+		{istiov1beta1.NodeAgentReady, nodeagent.New(r.Client, config)},
+		{istiov1beta1.IstioCoreDNSReady, istiocoredns.New(r.Client, config)},
+		{istiov1beta1.RevisionsReady, revisions.New(r.Client, r.dynamic, config)},
+	}
+
+	recorder := k8sutil.NewEventRecorder(r.recorder, config)
+	for _, rec := range reconcilers {
+		resources.SetEventRecorder(recorder, rec.reconciler)
+	}
+
+	return reconcilers
+}
+
+// getMeshes returns the namespaces of the control planes in the cluster, the oldest one is the default mesh
+func (r *ReconcileConfig) getMeshes() ([]string, error) {
+	var configs istiov1beta1.IstioList
+	err := r.Client.List(context.TODO(), &client.ListOptions{}, &configs)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not list istio resources")
+	}
+
+	items := make([]istiov1beta1.Istio, 0, len(configs.Items))
+	for _, item := range configs.Items {
+		if item.DeletionTimestamp.IsZero() {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].CreationTimestamp.Equal(&items[j].CreationTimestamp) {
+			return items[i].CreationTimestamp.Before(&items[j].CreationTimestamp)
+		}
+		return items[i].Namespace < items[j].Namespace
+	})
+
+	meshes := make([]string, 0, len(items))
+	for _, item := range items {
+		meshes = append(meshes, item.Namespace)
+	}
+
+	return meshes, nil
+}
+
 func (r *ReconcileConfig) getIngressGatewayAddress(istio *istiov1beta1.Istio, logger logr.Logger) ([]string, error) {
 	var service corev1.Service
 
@@ -427,8 +576,75 @@ func (r *ReconcileConfig) deleteRemoteIstios(config *istiov1beta1.Istio, logger
 	}
 }
 
+// checkRollouts waits for the managed deployments and daemonsets to converge and fails the reconciliation
+// once they haven't done so within the rollout deadline
+func (r *ReconcileConfig) checkRollouts(config *istiov1beta1.Istio, logger logr.Logger) (reconcile.Result, error) {
+	pending, err := k8sutil.GetPendingRollouts(r.Client, config.UID)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if len(pending) == 0 {
+		setComponentCondition(config, istiov1beta1.WorkloadsReady, corev1.ConditionTrue, istiov1beta1.ConditionReasonReconciled, "")
+		return reconcile.Result{}, nil
+	}
+
+	workload := pending[0]
+	condition := istiov1beta1.Condition{
+		Type:               istiov1beta1.WorkloadsReady,
+		Status:             corev1.ConditionFalse,
+		ObservedGeneration: config.Generation,
+		Reason:             istiov1beta1.ConditionReasonRolloutInProgress,
+		Message:            workload.String(),
+	}
+
+	// the deadline is counted from the start of the rollout of the current generation
+	current := istiov1beta1.GetCondition(config.Status.Conditions, istiov1beta1.WorkloadsReady)
+	if current != nil && current.Status == corev1.ConditionFalse && current.ObservedGeneration == config.Generation {
+		condition.LastTransitionTime = current.LastTransitionTime
+	} else {
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	if time.Since(condition.LastTransitionTime.Time) > rolloutDeadline {
+		condition.Reason = istiov1beta1.ConditionReasonRolloutDeadlineExceeded
+		config.Status.Conditions = istiov1beta1.SetCondition(config.Status.Conditions, condition)
+		return reconcile.Result{}, errors.Errorf("rollout of %s %s/%s did not finish within %s: %s", workload.Kind, workload.Namespace, workload.Name, rolloutDeadline, workload.Message)
+	}
+
+	config.Status.Conditions = istiov1beta1.SetCondition(config.Status.Conditions, condition)
+	logger.Info("waiting for rollout to finish", "kind", workload.Kind, "name", workload.Name, "namespace", workload.Namespace, "status", workload.Message)
+
+	err = updateStatus(r.Client, config, istiov1beta1.Reconciling, "", logger)
+	if err != nil {
+		return reconcile.Result{}, errors.WithStack(err)
+	}
+
+	return reconcile.Result{
+		Requeue:      true,
+		RequeueAfter: time.Duration(10) * time.Second,
+	}, nil
+}
+
+func isRolloutInProgress(config *istiov1beta1.Istio) bool {
+	condition := istiov1beta1.GetCondition(config.Status.Conditions, istiov1beta1.WorkloadsReady)
+	return condition != nil && condition.Reason == istiov1beta1.ConditionReasonRolloutInProgress
+}
+
+func setComponentCondition(config *istiov1beta1.Istio, conditionType istiov1beta1.ConditionType, status corev1.ConditionStatus, reason, message string) {
+	config.Status.Conditions = istiov1beta1.SetCondition(config.Status.Conditions, istiov1beta1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: config.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
 func updateStatus(c client.Client, config *istiov1beta1.Istio, status istiov1beta1.ConfigState, errorMessage string, logger logr.Logger) error {
 	typeMeta := config.TypeMeta
+	conditions := config.Status.Conditions
+	caRotation := config.Status.CARotation
 	config.Status.Status = status
 	config.Status.ErrorMessage = errorMessage
 	err := c.Status().Update(context.Background(), config)
@@ -448,6 +664,8 @@ func updateStatus(c client.Client, config *istiov1beta1.Istio, status istiov1bet
 		}
 		config.Status.Status = status
 		config.Status.ErrorMessage = errorMessage
+		config.Status.Conditions = conditions
+		config.Status.CARotation = caRotation
 		err = c.Status().Update(context.Background(), config)
 		if k8serrors.IsNotFound(err) {
 			err = c.Update(context.Background(), config)
@@ -458,20 +676,75 @@ func updateStatus(c client.Client, config *istiov1beta1.Istio, status istiov1bet
 	}
 	// update loses the typeMeta of the config that's used later when setting ownerrefs
 	config.TypeMeta = typeMeta
+	metrics.SetConfigState("Istio", config.Namespace, config.Name, status)
 	logger.Info("Istio state updated", "status", status)
 	return nil
 }
 
-func initWatches(c controller.Controller, scheme *runtime.Scheme, watchCreatedResourcesEvents bool, logger logr.Logger) error {
+func initWatches(c controller.Controller, cl client.Client, scheme *runtime.Scheme, watchCreatedResourcesEvents bool, logger logr.Logger) error {
 	// Watch for changes to Config
 	err := c.Watch(&source.Kind{Type: &istiov1beta1.Istio{TypeMeta: metav1.TypeMeta{Kind: "Istio", APIVersion: "istio.banzaicloud.io/v1beta1"}}}, &handler.EnqueueRequestForObject{}, k8sutil.GetWatchPredicateForIstio())
 	if err != nil {
 		return err
 	}
 
+	// The control planes of the other meshes are reconciled when a mesh is added or removed, since the default
+	// mesh and the namespace selectors of the sidecar injectors depend on them
+	err = c.Watch(&source.Kind{Type: &istiov1beta1.Istio{TypeMeta: metav1.TypeMeta{Kind: "Istio", APIVersion: "istio.banzaicloud.io/v1beta1"}}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			var configs istiov1beta1.IstioList
+			err := cl.List(context.TODO(), &client.ListOptions{}, &configs)
+			if err != nil {
+				logger.Error(err, "could not list istio resources")
+				return nil
+			}
+
+			requests := make([]reconcile.Request, 0, len(configs.Items))
+			for _, config := range configs.Items {
+				if config.Namespace == object.Meta.GetNamespace() {
+					continue
+				}
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      config.Name,
+						Namespace: config.Namespace,
+					},
+				})
+			}
+
+			return requests
+		}),
+	}, predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// a deletion is started
+			return e.MetaOld.GetDeletionTimestamp().IsZero() && !e.MetaNew.GetDeletionTimestamp().IsZero()
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	})
+	if err != nil {
+		return err
+	}
+
 	// Watch for RemoteIstio changes to trigger reconciliation
 	err = c.Watch(&source.Kind{Type: &istiov1beta1.RemoteIstio{TypeMeta: metav1.TypeMeta{Kind: "RemoteIstio", APIVersion: "istio.banzaicloud.io/v1beta1"}}}, &handler.EnqueueRequestsFromMapFunc{
 		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			if remoteIstio, ok := object.Object.(*istiov1beta1.RemoteIstio); ok {
+				if key := remoteIstio.GetIstioKey(); key.Name != "" {
+					return []reconcile.Request{
+						{
+							NamespacedName: key,
+						},
+					}
+				}
+			}
 			own := object.Meta.GetOwnerReferences()
 			if len(own) < 1 {
 				return nil
@@ -499,6 +772,36 @@ func initWatches(c controller.Controller, scheme *runtime.Scheme, watchCreatedRe
 		return err
 	}
 
+	// Watch for the CA certificate issued by cert-manager, Citadel is restarted when it is renewed
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"}}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			var configs istiov1beta1.IstioList
+			err := cl.List(context.TODO(), &client.ListOptions{Namespace: object.Meta.GetNamespace()}, &configs)
+			if err != nil {
+				logger.Error(err, "could not list istio resources")
+				return nil
+			}
+
+			requests := make([]reconcile.Request, 0)
+			for _, config := range configs.Items {
+				if config.Spec.Citadel.CertManager == nil {
+					continue
+				}
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      config.Name,
+						Namespace: config.Namespace,
+					},
+				})
+			}
+
+			return requests
+		}),
+	}, k8sutil.GetWatchPredicateForSecretData(citadel.CertManagerIssuedSecretName))
+	if err != nil {
+		return err
+	}
+
 	if !watchCreatedResourcesEvents {
 		return nil
 	}
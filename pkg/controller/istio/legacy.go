@@ -0,0 +1,106 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+)
+
+// legacyGalleyWebhookName is the name of the validating webhook configuration galley maintained before the
+// cluster scoped resources were suffixed with the namespace of the control plane
+const legacyGalleyWebhookName = "istio-galley"
+
+// removeLegacyClusterScopedResources removes the cluster scoped resources of the control plane which were
+// created by earlier versions of the operator without the namespace suffix in their names
+func (r *ReconcileConfig) removeLegacyClusterScopedResources(config *istiov1beta1.Istio, logger logr.Logger) error {
+	objects, err := r.legacyClusterScopedResources(config)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range objects {
+		m, err := meta.Accessor(o)
+		if err != nil {
+			return emperror.Wrap(err, "could not access object metadata")
+		}
+
+		logger.Info("removing legacy resource", "kind", reflect.TypeOf(o).Elem().Name(), "name", m.GetName())
+		err = r.Client.Delete(context.TODO(), o)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return emperror.WrapWith(err, "could not remove legacy resource", "name", m.GetName())
+		}
+	}
+
+	return nil
+}
+
+// planLegacyClusterScopedResources returns the deletions of the legacy cluster scoped resources of the control plane
+func (r *ReconcileConfig) planLegacyClusterScopedResources(config *istiov1beta1.Istio) ([]k8sutil.PlannedAction, error) {
+	objects, err := r.legacyClusterScopedResources(config)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]k8sutil.PlannedAction, 0, len(objects))
+	for _, o := range objects {
+		action, err := k8sutil.PlanDelete(o)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, *action)
+	}
+
+	return actions, nil
+}
+
+// legacyClusterScopedResources returns the cluster scoped resources of the control plane which were created by
+// earlier versions of the operator without the namespace suffix in their names
+func (r *ReconcileConfig) legacyClusterScopedResources(config *istiov1beta1.Istio) ([]runtime.Object, error) {
+	objects, err := k8sutil.UnsuffixedClusterScopedObjects(context.TODO(), r.Client, config, "-"+config.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// the legacy validating webhook configuration is owned by the galley deployment instead of the Istio resource
+	var webhook admissionregistrationv1beta1.ValidatingWebhookConfiguration
+	err = r.Client.Get(context.TODO(), client.ObjectKey{Name: legacyGalleyWebhookName}, &webhook)
+	if apierrors.IsNotFound(err) {
+		return objects, nil
+	}
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not get legacy galley webhook configuration")
+	}
+	for _, wh := range webhook.Webhooks {
+		if wh.ClientConfig.Service == nil || wh.ClientConfig.Service.Namespace != config.Namespace {
+			return objects, nil
+		}
+	}
+
+	return append(objects, &webhook), nil
+}
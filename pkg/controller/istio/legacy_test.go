@@ -0,0 +1,194 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := istiov1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+func TestGetMeshes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	created := metav1.NewTime(time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC))
+	deleted := metav1.NewTime(created.Add(time.Hour))
+	istio := func(namespace string, creation metav1.Time, deletion *metav1.Time) runtime.Object {
+		return &istiov1beta1.Istio{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "mesh",
+				Namespace:         namespace,
+				CreationTimestamp: creation,
+				DeletionTimestamp: deletion,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		expected []string
+	}{
+		{
+			name:     "no control planes",
+			expected: []string{},
+		},
+		{
+			name: "oldest first",
+			objects: []runtime.Object{
+				istio("mesh-b", metav1.NewTime(created.Add(2*time.Hour)), nil),
+				istio("mesh-a", metav1.NewTime(created.Add(time.Hour)), nil),
+				istio("istio-system", created, nil),
+			},
+			expected: []string{"istio-system", "mesh-a", "mesh-b"},
+		},
+		{
+			name: "same creation time ordered by namespace",
+			objects: []runtime.Object{
+				istio("mesh-b", created, nil),
+				istio("mesh-a", created, nil),
+			},
+			expected: []string{"mesh-a", "mesh-b"},
+		},
+		{
+			name: "control planes being deleted are skipped",
+			objects: []runtime.Object{
+				istio("istio-system", created, &deleted),
+				istio("mesh-a", metav1.NewTime(created.Add(time.Hour)), nil),
+			},
+			expected: []string{"mesh-a"},
+		},
+	}
+
+	for _, test := range tests {
+		r := &ReconcileConfig{
+			Client: fake.NewFakeClientWithScheme(newTestScheme(t), test.objects...),
+		}
+		meshes, err := r.getMeshes()
+		g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+		g.Expect(meshes).To(gomega.Equal(test.expected), test.name)
+	}
+}
+
+var legacyTestConfig = &istiov1beta1.Istio{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "mesh",
+		Namespace: "istio-system",
+		UID:       "mesh-uid",
+	},
+}
+
+// newLegacyTestClient returns a client with the suffixed and the legacy cluster scoped resources of the
+// control plane and resources of others
+func newLegacyTestClient(t *testing.T) client.Client {
+	owned := func(uid string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: "Istio", Name: "mesh", UID: types.UID(uid)}}
+	}
+
+	return fake.NewFakeClientWithScheme(newTestScheme(t),
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "istio-pilot-istio-system", OwnerReferences: owned("mesh-uid")}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "istio-pilot", OwnerReferences: owned("mesh-uid")}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "istio-reader", OwnerReferences: owned("other-uid")}},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"}},
+		&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "istio-pilot", OwnerReferences: owned("mesh-uid")}},
+		&admissionregistrationv1beta1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector", OwnerReferences: owned("mesh-uid")}},
+		&admissionregistrationv1beta1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: legacyGalleyWebhookName},
+			Webhooks: []admissionregistrationv1beta1.Webhook{
+				{ClientConfig: admissionregistrationv1beta1.WebhookClientConfig{Service: &admissionregistrationv1beta1.ServiceReference{Namespace: "istio-system"}}},
+			},
+		},
+	)
+}
+
+func TestRemoveLegacyClusterScopedResources(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	c := newLegacyTestClient(t)
+	r := &ReconcileConfig{
+		Client: c,
+	}
+	g.Expect(r.removeLegacyClusterScopedResources(legacyTestConfig, logf.NullLogger{})).NotTo(gomega.HaveOccurred())
+
+	var clusterRoles rbacv1.ClusterRoleList
+	g.Expect(c.List(context.TODO(), &client.ListOptions{}, &clusterRoles)).NotTo(gomega.HaveOccurred())
+	var names []string
+	for _, item := range clusterRoles.Items {
+		names = append(names, item.Name)
+	}
+	g.Expect(names).To(gomega.ConsistOf("istio-pilot-istio-system", "istio-reader", "cluster-admin"))
+
+	var bindings rbacv1.ClusterRoleBindingList
+	g.Expect(c.List(context.TODO(), &client.ListOptions{}, &bindings)).NotTo(gomega.HaveOccurred())
+	g.Expect(bindings.Items).To(gomega.BeEmpty())
+
+	var mutatingWebhooks admissionregistrationv1beta1.MutatingWebhookConfigurationList
+	g.Expect(c.List(context.TODO(), &client.ListOptions{}, &mutatingWebhooks)).NotTo(gomega.HaveOccurred())
+	g.Expect(mutatingWebhooks.Items).To(gomega.BeEmpty())
+
+	var validatingWebhooks admissionregistrationv1beta1.ValidatingWebhookConfigurationList
+	g.Expect(c.List(context.TODO(), &client.ListOptions{}, &validatingWebhooks)).NotTo(gomega.HaveOccurred())
+	g.Expect(validatingWebhooks.Items).To(gomega.BeEmpty())
+}
+
+func TestPlanLegacyClusterScopedResources(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	c := newLegacyTestClient(t)
+	r := &ReconcileConfig{
+		Client: c,
+	}
+	actions, err := r.planLegacyClusterScopedResources(legacyTestConfig)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(actions).To(gomega.ConsistOf(
+		k8sutil.PlannedAction{Action: k8sutil.ActionDelete, APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole", Name: "istio-pilot"},
+		k8sutil.PlannedAction{Action: k8sutil.ActionDelete, APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding", Name: "istio-pilot"},
+		k8sutil.PlannedAction{Action: k8sutil.ActionDelete, APIVersion: "admissionregistration.k8s.io/v1beta1", Kind: "MutatingWebhookConfiguration", Name: "istio-sidecar-injector"},
+		k8sutil.PlannedAction{Action: k8sutil.ActionDelete, APIVersion: "admissionregistration.k8s.io/v1beta1", Kind: "ValidatingWebhookConfiguration", Name: legacyGalleyWebhookName},
+	))
+
+	// the planned resources are left in place
+	var clusterRoles rbacv1.ClusterRoleList
+	g.Expect(c.List(context.TODO(), &client.ListOptions{}, &clusterRoles)).NotTo(gomega.HaveOccurred())
+	g.Expect(clusterRoles.Items).To(gomega.HaveLen(4))
+}
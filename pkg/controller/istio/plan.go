@@ -0,0 +1,123 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package istio
+
+import (
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources"
+	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+const planConfigMapKey = "plan.yaml"
+
+func planConfigMapName(config *istiov1beta1.Istio) string {
+	return config.Name + "-plan"
+}
+
+// plan calculates the create, update and delete actions of the reconciliation without applying them
+// and publishes them in a config map next to the Istio resource
+func (r *ReconcileConfig) plan(config *istiov1beta1.Istio, logger logr.Logger) (reconcile.Result, error) {
+	logger.Info("calculating plan")
+
+	actions, err := r.crdOperator.Plan()
+	if err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not plan CRDs")
+	}
+
+	if util.PointerToBool(config.Spec.MeshExpansion) {
+		meshNetworks, err := r.getMeshNetworks(config, logger)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		config.Spec.SetMeshNetworks(meshNetworks)
+	}
+
+	// the CA rotation is started by the reconciliation before Citadel writes the CA of its first stage
+	if rotation := config.Spec.Citadel.CARotation; rotation != nil {
+		if status := config.Status.CARotation; status == nil || status.NewCASecretName != rotation.NewCASecretName {
+			config.Status.CARotation = &istiov1beta1.CARotationStatus{
+				NewCASecretName: rotation.NewCASecretName,
+				Stage:           istiov1beta1.CARotationDistributingTrustBundle,
+			}
+		}
+	}
+
+	for _, rec := range r.componentReconcilers(config) {
+		var componentActions []k8sutil.PlannedAction
+		switch c := rec.reconciler.(type) {
+		case resources.ComponentPlanner:
+			componentActions, err = c.Plan()
+		case resources.ComponentRenderer:
+			componentActions, err = resources.PlanResources(r.Client, r.dynamic, c)
+		default:
+			continue
+		}
+		if err != nil {
+			return reconcile.Result{}, emperror.WrapWith(err, "could not plan component", "component", rec.conditionType)
+		}
+		actions = append(actions, componentActions...)
+	}
+
+	legacyActions, err := r.planLegacyClusterScopedResources(config)
+	if err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not plan the removal of legacy resources")
+	}
+	actions = append(actions, legacyActions...)
+
+	plan, err := yaml.Marshal(actions)
+	if err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not marshal plan")
+	}
+
+	err = k8sutil.Reconcile(logger, r.Client, &corev1.ConfigMap{
+		ObjectMeta: templates.ObjectMetaWithAnnotations(planConfigMapName(config), nil, map[string]string{
+			"istio.banzaicloud.io/generation": strconv.FormatInt(config.Generation, 10),
+		}, config),
+		Data: map[string]string{
+			planConfigMapKey: string(plan),
+		},
+	}, k8sutil.DesiredStatePresent, nil)
+	if err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not publish plan")
+	}
+
+	logger.Info("plan published", "configmap", planConfigMapName(config), "actions", len(actions))
+
+	return reconcile.Result{}, nil
+}
+
+// removePlan removes the published plan once the changes are applied
+func (r *ReconcileConfig) removePlan(config *istiov1beta1.Istio, logger logr.Logger) error {
+	err := k8sutil.Reconcile(logger, r.Client, &corev1.ConfigMap{
+		ObjectMeta: templates.ObjectMeta(planConfigMapName(config), nil, config),
+	}, k8sutil.DesiredStateAbsent, nil)
+	if err != nil {
+		return emperror.Wrap(err, "could not remove plan")
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteistio
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/remoteclusters"
+)
+
+// reconcileCount returns the number of the reconciliations of the controller with the given result
+func reconcileCount(t *testing.T, result string) float64 {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != "istio_operator_reconcile_total" {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["controller"] == controllerName && labels["result"] == result {
+				return m.GetCounter().GetValue()
+			}
+		}
+	}
+
+	return 0
+}
+
+func TestReconcileBackoff(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	remoteIstio := func(name string) runtime.Object {
+		return &istiov1beta1.RemoteIstio{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  "istio-system",
+				Finalizers: []string{finalizerID},
+			},
+			Spec: istiov1beta1.RemoteIstioSpec{
+				IstioRef: &istiov1beta1.IstioReference{Name: "mesh"},
+			},
+		}
+	}
+	request := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "istio-system", Name: name}}
+	}
+
+	// the remote clusters fail without the CA of the primary Citadel
+	r := &ReconcileRemoteConfig{
+		Client: newExportTestClient(t,
+			&istiov1beta1.Istio{
+				ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system", UID: "mesh-uid"},
+				Spec:       istiov1beta1.IstioSpec{Version: "1.3.5"},
+			},
+			remoteIstio("remote-a"),
+			remoteIstio("remote-b"),
+		),
+		remoteClustersMgr: remoteclusters.NewManager(remoteclusters.Options{}),
+		backoff:           flowcontrol.NewBackOff(failureBackoffInitial, failureBackoffMax),
+	}
+
+	failed := reconcileCount(t, "error")
+
+	// the retries of the failing cluster are delayed more and more
+	for _, delay := range []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second} {
+		result, err := r.Reconcile(request("remote-a"))
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(result.RequeueAfter).To(gomega.Equal(delay))
+	}
+	g.Expect(reconcileCount(t, "error")).To(gomega.Equal(failed + 3))
+
+	// the other cluster is retried independently of it
+	result, err := r.Reconcile(request("remote-b"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.RequeueAfter).To(gomega.Equal(failureBackoffInitial))
+	g.Expect(reconcileCount(t, "error")).To(gomega.Equal(failed + 4))
+
+	// the clusters reconciled in parallel keep their own delays
+	var wg sync.WaitGroup
+	results := make(map[string]reconcile.Result)
+	var mu sync.Mutex
+	for _, name := range []string{"remote-a", "remote-b"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			result, err := r.Reconcile(request(name))
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			mu.Lock()
+			results[name] = result
+			mu.Unlock()
+		}(name)
+	}
+	wg.Wait()
+	g.Expect(results["remote-a"].RequeueAfter).To(gomega.Equal(40 * time.Second))
+	g.Expect(results["remote-b"].RequeueAfter).To(gomega.Equal(10 * time.Second))
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteistio
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+// populateExportedServices adds the services selected by the service export of the RemoteIstio resource to its
+// enabled services, their endpoints are populated the same way as the endpoints of the enabled services
+func (r *ReconcileRemoteConfig) populateExportedServices(remoteIstio *istiov1beta1.RemoteIstio, logger logr.Logger) (*istiov1beta1.RemoteIstio, error) {
+	services, err := getExportedServices(r.Client, remoteIstio)
+	if err != nil {
+		return remoteIstio, err
+	}
+
+	enabled := make(map[string]bool)
+	for _, svc := range remoteIstio.Spec.EnabledServices {
+		enabled[remoteIstio.GetServiceNamespace(svc)+"/"+svc.Name] = true
+	}
+
+	for _, service := range services {
+		if enabled[service.Namespace+"/"+service.Name] {
+			logger.V(1).Info("exported service is already enabled", "namespace", service.Namespace, "name", service.Name)
+			continue
+		}
+
+		remoteIstio.Spec.EnabledServices = append(remoteIstio.Spec.EnabledServices, istiov1beta1.IstioService{
+			Name:          service.Name,
+			Namespace:     service.Namespace,
+			LabelSelector: labels.Set(service.Spec.Selector).String(),
+			Ports:         service.Spec.Ports,
+		})
+	}
+
+	return remoteIstio, nil
+}
+
+// getExportedServices returns the services selected by the service export of the RemoteIstio resource sorted by
+// their namespace and name
+func getExportedServices(c client.Client, remoteIstio *istiov1beta1.RemoteIstio) ([]corev1.Service, error) {
+	export := remoteIstio.Spec.ServiceExport
+	if export == nil || export.ServiceSelector == nil {
+		return nil, nil
+	}
+
+	serviceSelector, err := metav1.LabelSelectorAsSelector(export.ServiceSelector)
+	if err != nil {
+		return nil, emperror.Wrap(err, "invalid service selector")
+	}
+
+	namespaces, err := getExportNamespaces(c, remoteIstio)
+	if err != nil {
+		return nil, err
+	}
+
+	services := make([]corev1.Service, 0)
+	for _, namespace := range namespaces {
+		var serviceList corev1.ServiceList
+		err = c.List(context.TODO(), &client.ListOptions{
+			Namespace:     namespace,
+			LabelSelector: serviceSelector,
+		}, &serviceList)
+		if err != nil {
+			return nil, emperror.WrapWith(err, "could not list services", "namespace", namespace)
+		}
+		for _, service := range serviceList.Items {
+			// services without selector have no pods to take the endpoints from
+			if len(service.Spec.Selector) == 0 {
+				continue
+			}
+			services = append(services, service)
+		}
+	}
+
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Namespace != services[j].Namespace {
+			return services[i].Namespace < services[j].Namespace
+		}
+		return services[i].Name < services[j].Name
+	})
+
+	return services, nil
+}
+
+// getExportNamespaces returns the namespaces selected by the service export of the RemoteIstio resource
+func getExportNamespaces(c client.Client, remoteIstio *istiov1beta1.RemoteIstio) ([]string, error) {
+	export := remoteIstio.Spec.ServiceExport
+	if export.NamespaceSelector == nil {
+		return []string{remoteIstio.Namespace}, nil
+	}
+
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(export.NamespaceSelector)
+	if err != nil {
+		return nil, emperror.Wrap(err, "invalid namespace selector")
+	}
+
+	var namespaceList corev1.NamespaceList
+	err = c.List(context.TODO(), &client.ListOptions{
+		LabelSelector: namespaceSelector,
+	}, &namespaceList)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not list namespaces")
+	}
+
+	namespaces := make([]string, 0)
+	for _, namespace := range namespaceList.Items {
+		namespaces = append(namespaces, namespace.Name)
+	}
+
+	return namespaces, nil
+}
+
+// triggerRemoteIstiosOfServices returns the RemoteIstio resources replicating the service, or the services
+// which select the pod, to the remote clusters
+func triggerRemoteIstiosOfServices(c client.Client, object handler.MapObject, logger logr.Logger) []reconcile.Request {
+	var remoteIstios istiov1beta1.RemoteIstioList
+	err := c.List(context.Background(), &client.ListOptions{}, &remoteIstios)
+	if err != nil {
+		logger.Error(err, "could not list remote istio resources")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, remoteIstio := range remoteIstios.Items {
+		var affected bool
+		if _, ok := object.Object.(*corev1.Service); ok {
+			affected, err = replicatesService(c, &remoteIstio, object.Meta)
+		} else {
+			affected, err = replicatesServiceOfPod(c, &remoteIstio, object.Meta)
+		}
+		if err != nil {
+			logger.Error(err, "could not get replicated services", "namespace", remoteIstio.Namespace, "name", remoteIstio.Name)
+			continue
+		}
+		if !affected {
+			continue
+		}
+
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Namespace: remoteIstio.Namespace,
+				Name:      remoteIstio.Name,
+			},
+		})
+	}
+
+	return requests
+}
+
+// replicatesService returns whether the service is enabled or could be exported by the RemoteIstio resource
+func replicatesService(c client.Client, remoteIstio *istiov1beta1.RemoteIstio, service metav1.Object) (bool, error) {
+	for _, svc := range remoteIstio.Spec.EnabledServices {
+		if svc.Name == service.GetName() && remoteIstio.GetServiceNamespace(svc) == service.GetNamespace() {
+			return true, nil
+		}
+	}
+
+	if remoteIstio.Spec.ServiceExport == nil {
+		return false, nil
+	}
+
+	// services which are not selected any more have to be removed from the remote cluster as well
+	return exportsNamespace(c, remoteIstio, service.GetNamespace())
+}
+
+// replicatesServiceOfPod returns whether the pod is selected by one of the services replicated by the
+// RemoteIstio resource
+func replicatesServiceOfPod(c client.Client, remoteIstio *istiov1beta1.RemoteIstio, pod metav1.Object) (bool, error) {
+	podLabels := labels.Set(pod.GetLabels())
+
+	for _, svc := range remoteIstio.Spec.EnabledServices {
+		if remoteIstio.GetServiceNamespace(svc) != pod.GetNamespace() {
+			continue
+		}
+
+		selector := svc.LabelSelector
+		if selector == "" {
+			var service corev1.Service
+			err := c.Get(context.TODO(), client.ObjectKey{Namespace: pod.GetNamespace(), Name: svc.Name}, &service)
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return false, err
+			}
+			selector = labels.Set(service.Spec.Selector).String()
+		}
+
+		s, err := labels.Parse(selector)
+		if err != nil {
+			return false, err
+		}
+		if !s.Empty() && s.Matches(podLabels) {
+			return true, nil
+		}
+	}
+
+	// only the exported services in the namespace of the pod can select it
+	export := remoteIstio.Spec.ServiceExport
+	if export == nil || export.ServiceSelector == nil {
+		return false, nil
+	}
+	exported, err := exportsNamespace(c, remoteIstio, pod.GetNamespace())
+	if err != nil || !exported {
+		return false, err
+	}
+
+	serviceSelector, err := metav1.LabelSelectorAsSelector(export.ServiceSelector)
+	if err != nil {
+		return false, emperror.Wrap(err, "invalid service selector")
+	}
+
+	var serviceList corev1.ServiceList
+	err = c.List(context.TODO(), &client.ListOptions{
+		Namespace:     pod.GetNamespace(),
+		LabelSelector: serviceSelector,
+	}, &serviceList)
+	if err != nil {
+		return false, emperror.WrapWith(err, "could not list services", "namespace", pod.GetNamespace())
+	}
+	for _, service := range serviceList.Items {
+		// services without selector have no pods to take the endpoints from
+		if len(service.Spec.Selector) > 0 && labels.SelectorFromSet(service.Spec.Selector).Matches(podLabels) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// exportsNamespace returns whether the namespace is selected by the service export of the RemoteIstio resource
+func exportsNamespace(c client.Client, remoteIstio *istiov1beta1.RemoteIstio, namespace string) (bool, error) {
+	export := remoteIstio.Spec.ServiceExport
+	if export.NamespaceSelector == nil {
+		return namespace == remoteIstio.Namespace, nil
+	}
+
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(export.NamespaceSelector)
+	if err != nil {
+		return false, emperror.Wrap(err, "invalid namespace selector")
+	}
+
+	var ns corev1.Namespace
+	err = c.Get(context.TODO(), client.ObjectKey{Name: namespace}, &ns)
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, emperror.WrapWith(err, "could not get namespace", "namespace", namespace)
+	}
+
+	return namespaceSelector.Matches(labels.Set(ns.Labels)), nil
+}
@@ -0,0 +1,318 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteistio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+// selectingClient filters the listed objects by the label selector, which is ignored by the fake client
+type selectingClient struct {
+	client.Client
+}
+
+func (c selectingClient) List(ctx context.Context, opts *client.ListOptions, list runtime.Object) error {
+	err := c.Client.List(ctx, opts, list)
+	if err != nil || opts.LabelSelector == nil {
+		return err
+	}
+
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	selected := make([]runtime.Object, 0, len(objects))
+	for _, o := range objects {
+		m, err := meta.Accessor(o)
+		if err != nil {
+			return err
+		}
+		if opts.LabelSelector.Matches(labels.Set(m.GetLabels())) {
+			selected = append(selected, o)
+		}
+	}
+
+	return meta.SetList(list, selected)
+}
+
+func newExportTestClient(t *testing.T, objects ...runtime.Object) client.Client {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := istiov1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	exported := map[string]string{"export": "true"}
+	objects = append(objects,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "istio-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps", Labels: exported}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "backends", Labels: exported}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "private"}},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "apps", Labels: exported},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "web"},
+				Ports:    []corev1.ServicePort{{Name: "http", Port: 80}},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "internal", Namespace: "apps"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "internal"}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "apps", Labels: exported},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "backends", Labels: exported},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "db"}},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "private", Labels: exported},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "secret"}},
+		},
+	)
+
+	return selectingClient{fake.NewFakeClientWithScheme(s, objects...)}
+}
+
+func exportingRemoteIstio(name string) *istiov1beta1.RemoteIstio {
+	exported := map[string]string{"export": "true"}
+
+	return &istiov1beta1.RemoteIstio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "istio-system",
+		},
+		Spec: istiov1beta1.RemoteIstioSpec{
+			EnabledServices: []istiov1beta1.IstioService{
+				{Name: "istio-pilot", LabelSelector: "istio=pilot"},
+			},
+			ServiceExport: &istiov1beta1.ServiceExport{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: exported},
+				ServiceSelector:   &metav1.LabelSelector{MatchLabels: exported},
+			},
+		},
+	}
+}
+
+func TestGetExportedServices(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	c := newExportTestClient(t)
+
+	services, err := getExportedServices(c, &istiov1beta1.RemoteIstio{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(services).To(gomega.BeEmpty())
+
+	services, err = getExportedServices(c, exportingRemoteIstio("remote"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	var names []string
+	for _, service := range services {
+		names = append(names, service.Namespace+"/"+service.Name)
+	}
+	g.Expect(names).To(gomega.Equal([]string{"apps/web", "backends/db"}))
+
+	// the services are exported from the namespace of the RemoteIstio resource by default
+	remoteIstio := exportingRemoteIstio("remote")
+	remoteIstio.Namespace = "apps"
+	remoteIstio.Spec.ServiceExport.NamespaceSelector = nil
+	services, err = getExportedServices(c, remoteIstio)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(services).To(gomega.HaveLen(1))
+	g.Expect(services[0].Name).To(gomega.Equal("web"))
+}
+
+func TestPopulateExportedServices(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	r := &ReconcileRemoteConfig{
+		Client: newExportTestClient(t),
+	}
+	remoteIstio := exportingRemoteIstio("remote")
+	remoteIstio.Spec.EnabledServices = append(remoteIstio.Spec.EnabledServices, istiov1beta1.IstioService{
+		Name:          "db",
+		Namespace:     "backends",
+		LabelSelector: "app=db,tier=primary",
+	})
+
+	remoteIstio, err := r.populateExportedServices(remoteIstio, logf.NullLogger{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(remoteIstio.Spec.EnabledServices).To(gomega.Equal([]istiov1beta1.IstioService{
+		{Name: "istio-pilot", LabelSelector: "istio=pilot"},
+		// the enabled services take precedence over the exported ones
+		{Name: "db", Namespace: "backends", LabelSelector: "app=db,tier=primary"},
+		{Name: "web", Namespace: "apps", LabelSelector: "app=web", Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}))
+}
+
+func TestReplicatesService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	c := newExportTestClient(t)
+	notExporting := exportingRemoteIstio("remote")
+	notExporting.Spec.ServiceExport = nil
+
+	tests := []struct {
+		name        string
+		remoteIstio *istiov1beta1.RemoteIstio
+		namespace   string
+		service     string
+		replicated  bool
+	}{
+		{
+			name:        "enabled service",
+			remoteIstio: notExporting,
+			namespace:   "istio-system",
+			service:     "istio-pilot",
+			replicated:  true,
+		},
+		{
+			name:        "enabled service in other namespace",
+			remoteIstio: notExporting,
+			namespace:   "apps",
+			service:     "istio-pilot",
+		},
+		{
+			name:        "service in exported namespace",
+			remoteIstio: exportingRemoteIstio("remote"),
+			namespace:   "apps",
+			service:     "internal",
+			replicated:  true,
+		},
+		{
+			name:        "service in other namespace",
+			remoteIstio: exportingRemoteIstio("remote"),
+			namespace:   "private",
+			service:     "secret",
+		},
+		{
+			name:        "service in removed namespace",
+			remoteIstio: exportingRemoteIstio("remote"),
+			namespace:   "removed",
+			service:     "web",
+		},
+	}
+
+	for _, test := range tests {
+		replicated, err := replicatesService(c, test.remoteIstio, &metav1.ObjectMeta{Namespace: test.namespace, Name: test.service})
+		g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+		g.Expect(replicated).To(gomega.Equal(test.replicated), test.name)
+	}
+}
+
+func TestReplicatesServiceOfPod(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	c := newExportTestClient(t,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio-policy", Namespace: "istio-system"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"istio": "mixer"}},
+		},
+	)
+	remoteIstio := exportingRemoteIstio("remote")
+	remoteIstio.Spec.EnabledServices = append(remoteIstio.Spec.EnabledServices, istiov1beta1.IstioService{Name: "istio-policy"})
+
+	tests := []struct {
+		name       string
+		namespace  string
+		labels     map[string]string
+		replicated bool
+	}{
+		{
+			name:       "pod of enabled service",
+			namespace:  "istio-system",
+			labels:     map[string]string{"istio": "pilot"},
+			replicated: true,
+		},
+		{
+			name:       "pod of enabled service without label selector",
+			namespace:  "istio-system",
+			labels:     map[string]string{"istio": "mixer"},
+			replicated: true,
+		},
+		{
+			name:      "pod of enabled service in other namespace",
+			namespace: "apps",
+			labels:    map[string]string{"istio": "pilot"},
+		},
+		{
+			name:       "pod of exported service",
+			namespace:  "backends",
+			labels:     map[string]string{"app": "db"},
+			replicated: true,
+		},
+		{
+			name:      "pod of service not selected for export",
+			namespace: "apps",
+			labels:    map[string]string{"app": "internal"},
+		},
+		{
+			name:      "pod of service in namespace not selected for export",
+			namespace: "private",
+			labels:    map[string]string{"app": "secret"},
+		},
+		{
+			name:      "pod without service",
+			namespace: "apps",
+			labels:    map[string]string{"app": "db"},
+		},
+	}
+
+	for _, test := range tests {
+		replicated, err := replicatesServiceOfPod(c, remoteIstio, &metav1.ObjectMeta{Namespace: test.namespace, Name: "pod", Labels: test.labels})
+		g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+		g.Expect(replicated).To(gomega.Equal(test.replicated), test.name)
+	}
+}
+
+func TestTriggerRemoteIstiosOfServices(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	notExporting := exportingRemoteIstio("not-exporting")
+	notExporting.Spec.ServiceExport = nil
+	c := newExportTestClient(t, exportingRemoteIstio("exporting"), notExporting)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "apps", Name: "web-1", Labels: map[string]string{"app": "web"}}}
+	requests := triggerRemoteIstiosOfServices(c, handler.MapObject{Meta: pod, Object: pod}, logf.NullLogger{})
+	g.Expect(requests).To(gomega.HaveLen(1))
+	g.Expect(requests[0].Name).To(gomega.Equal("exporting"))
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: "istio-pilot"}}
+	requests = triggerRemoteIstiosOfServices(c, handler.MapObject{Meta: service, Object: service}, logf.NullLogger{})
+	g.Expect(requests).To(gomega.HaveLen(2))
+
+	pod = &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "private", Name: "secret-1", Labels: map[string]string{"app": "secret"}}}
+	requests = triggerRemoteIstiosOfServices(c, handler.MapObject{Meta: pod, Object: pod}, logf.NullLogger{})
+	g.Expect(requests).To(gomega.BeEmpty())
+}
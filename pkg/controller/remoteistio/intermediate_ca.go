@@ -0,0 +1,126 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteistio
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
+)
+
+// intermediateCADuration is the lifetime of the intermediate CAs issued for the remote clusters
+const intermediateCADuration = 365 * 24 * time.Hour
+
+// intermediateCASecretName returns the name of the secret the intermediate CA of the remote cluster is kept in
+func intermediateCASecretName(remoteConfig *istiov1beta1.RemoteIstio) string {
+	return fmt.Sprintf("%s-istio-ca", remoteConfig.Name)
+}
+
+// intermediateCARenewTime returns when the intermediate CA is re-issued, after two thirds of its lifetime
+func intermediateCARenewTime(ca *citadel.IntermediateCA) time.Time {
+	lifetime := ca.Certificate.NotAfter.Sub(ca.Certificate.NotBefore)
+	return ca.Certificate.NotBefore.Add(lifetime * 2 / 3)
+}
+
+// reconcileIntermediateCA returns the intermediate CA of the remote cluster signed by the CA of the primary
+// Citadel, so the key of the primary CA does not leave the primary cluster. It is re-issued when it is about to
+// expire or when it is not signed by the CA of the primary Citadel anymore.
+func (r *ReconcileRemoteConfig) reconcileIntermediateCA(remoteConfig *istiov1beta1.RemoteIstio, signer *corev1.Secret, logger logr.Logger) (*citadel.IntermediateCA, error) {
+	name := intermediateCASecretName(remoteConfig)
+	recorder := k8sutil.NewEventRecorder(r.recorder, remoteConfig)
+
+	var current corev1.Secret
+	err := r.Get(context.TODO(), client.ObjectKey{
+		Namespace: remoteConfig.Namespace,
+		Name:      name,
+	}, &current)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, emperror.WrapWith(err, "could not get intermediate CA secret", "name", name)
+	}
+
+	var ca *citadel.IntermediateCA
+	reason := "the intermediate CA does not exist"
+	if err == nil {
+		ca, err = citadel.RefreshIntermediateCA(&current, signer)
+		switch {
+		case err != nil:
+			reason = err.Error()
+		case time.Now().After(intermediateCARenewTime(ca)):
+			reason = fmt.Sprintf("the intermediate CA expires at %s", ca.Certificate.NotAfter.Format(time.RFC3339))
+			ca = nil
+		}
+	}
+
+	if ca == nil {
+		ca, err = citadel.IssueIntermediateCA(signer, fmt.Sprintf("istio-ca.%s.%s", remoteConfig.Name, remoteConfig.Namespace), intermediateCADuration)
+		if err != nil {
+			return nil, emperror.Wrap(err, "could not issue intermediate CA")
+		}
+		logger.Info("intermediate CA issued", "reason", reason, "serialNumber", ca.Certificate.SerialNumber.String())
+		recorder.Normal(k8sutil.EventReasonIntermediateCAIssued, "intermediate CA %s issued: %s", ca.Certificate.SerialNumber.String(), reason)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: remoteConfig.Namespace,
+			Labels:    remoteConfig.GetOwnerLabels(),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(remoteConfig, istiov1beta1.SchemeGroupVersion.WithKind("RemoteIstio")),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: ca.Data,
+	}
+	err = k8sutil.Reconcile(logger, r.Client, secret, k8sutil.DesiredStatePresent, recorder)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not reconcile intermediate CA secret")
+	}
+
+	remoteConfig.Status.IntermediateCA = &istiov1beta1.IntermediateCAStatus{
+		SecretName:   name,
+		SerialNumber: ca.Certificate.SerialNumber.String(),
+		NotBefore:    metav1.NewTime(ca.Certificate.NotBefore),
+		NotAfter:     metav1.NewTime(ca.Certificate.NotAfter),
+		RenewTime:    metav1.NewTime(intermediateCARenewTime(ca)),
+	}
+
+	return ca, nil
+}
+
+// removeIntermediateCA removes the intermediate CA of a remote cluster which has a CA of its own
+func (r *ReconcileRemoteConfig) removeIntermediateCA(remoteConfig *istiov1beta1.RemoteIstio, logger logr.Logger) error {
+	remoteConfig.Status.IntermediateCA = nil
+
+	return k8sutil.Reconcile(logger, r.Client, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      intermediateCASecretName(remoteConfig),
+			Namespace: remoteConfig.Namespace,
+		},
+	}, k8sutil.DesiredStateAbsent, k8sutil.NewEventRecorder(r.recorder, remoteConfig))
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteistio
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func TestGetK8SConfigForCluster(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tests := []struct {
+		name     string
+		data     map[string][]byte
+		ref      *istiov1beta1.KubeconfigSecretReference
+		expected string
+		invalid  bool
+	}{
+		{
+			name:     "single key",
+			data:     map[string][]byte{"remote.yaml": []byte("single")},
+			expected: "single",
+		},
+		{
+			name:     "kubeconfig key next to others",
+			data:     map[string][]byte{"kubeconfig": []byte("conventional"), "ca.crt": []byte("ca")},
+			expected: "conventional",
+		},
+		{
+			name:    "several keys without a kubeconfig key",
+			data:    map[string][]byte{"remote.yaml": []byte("remote"), "ca.crt": []byte("ca")},
+			invalid: true,
+		},
+		{
+			name:     "selected key",
+			data:     map[string][]byte{"kubeconfig": []byte("conventional"), "remote.yaml": []byte("selected")},
+			ref:      &istiov1beta1.KubeconfigSecretReference{Key: "remote.yaml"},
+			expected: "selected",
+		},
+		{
+			name:    "missing selected key",
+			data:    map[string][]byte{"kubeconfig": []byte("conventional")},
+			ref:     &istiov1beta1.KubeconfigSecretReference{Key: "remote.yaml"},
+			invalid: true,
+		},
+		{
+			name:     "other secret",
+			data:     map[string][]byte{"kubeconfig": []byte("other")},
+			ref:      &istiov1beta1.KubeconfigSecretReference{Name: "other"},
+			expected: "other",
+		},
+	}
+
+	for _, test := range tests {
+		remoteConfig := &istiov1beta1.RemoteIstio{
+			ObjectMeta: metav1.ObjectMeta{Name: "remote", Namespace: "istio-system"},
+			Spec:       istiov1beta1.RemoteIstioSpec{KubeconfigSecret: test.ref},
+		}
+		r := &ReconcileRemoteConfig{
+			Client: newExportTestClient(t, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: remoteConfig.GetKubeconfigSecretName(), Namespace: "istio-system"},
+				Data:       test.data,
+			}),
+		}
+
+		config, err := r.getK8SConfigForCluster(remoteConfig)
+		if test.invalid {
+			g.Expect(err).To(gomega.HaveOccurred(), test.name)
+		} else {
+			g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+			g.Expect(string(config)).To(gomega.Equal(test.expected), test.name)
+		}
+	}
+}
+
+func TestTriggerRemoteIstiosOfSecret(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	remoteIstio := func(name, namespace string, ref *istiov1beta1.KubeconfigSecretReference) *istiov1beta1.RemoteIstio {
+		return &istiov1beta1.RemoteIstio{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       istiov1beta1.RemoteIstioSpec{KubeconfigSecret: ref},
+		}
+	}
+	request := func(name, namespace string) reconcile.Request {
+		return reconcile.Request{NamespacedName: client.ObjectKey{Namespace: namespace, Name: name}}
+	}
+
+	c := newExportTestClient(t,
+		remoteIstio("remote-a", "istio-system", nil),
+		remoteIstio("remote-b", "istio-system", &istiov1beta1.KubeconfigSecretReference{Name: "remote-a", Key: "kubeconfig"}),
+		remoteIstio("remote-c", "istio-system", &istiov1beta1.KubeconfigSecretReference{Name: "remote-c"}),
+		remoteIstio("remote-a", "mesh-b", nil),
+	)
+
+	secret := func(name, namespace string) metav1.Object {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	g.Expect(triggerRemoteIstiosOfSecret(c, secret("remote-a", "istio-system"), logf.NullLogger{})).To(gomega.ConsistOf(
+		request("remote-a", "istio-system"),
+		request("remote-b", "istio-system"),
+	))
+	g.Expect(triggerRemoteIstiosOfSecret(c, secret("remote-c", "istio-system"), logf.NullLogger{})).To(gomega.ConsistOf(
+		request("remote-c", "istio-system"),
+	))
+	g.Expect(triggerRemoteIstiosOfSecret(c, secret("remote-a", "mesh-b"), logf.NullLogger{})).To(gomega.ConsistOf(
+		request("remote-a", "mesh-b"),
+	))
+	g.Expect(triggerRemoteIstiosOfSecret(c, secret("unrelated", "istio-system"), logf.NullLogger{})).To(gomega.BeEmpty())
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteistio
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/remoteclusters"
+)
+
+func TestReconcilePlanMode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	key := client.ObjectKey{Namespace: "istio-system", Name: "remote"}
+	istio := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "mesh",
+			Namespace:   "istio-system",
+			UID:         "mesh-uid",
+			Annotations: map[string]string{istiov1beta1.PlanAnnotation: "true"},
+		},
+		Spec: istiov1beta1.IstioSpec{Version: "1.3.5"},
+	}
+	c := newExportTestClient(t,
+		istio,
+		&istiov1beta1.RemoteIstio{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       key.Name,
+				Namespace:  key.Namespace,
+				Finalizers: []string{finalizerID},
+			},
+			Spec: istiov1beta1.RemoteIstioSpec{
+				IstioRef: &istiov1beta1.IstioReference{Name: "mesh"},
+			},
+		},
+	)
+	r := &ReconcileRemoteConfig{
+		Client:            c,
+		remoteClustersMgr: remoteclusters.NewManager(remoteclusters.Options{}),
+		backoff:           flowcontrol.NewBackOff(failureBackoffInitial, failureBackoffMax),
+	}
+
+	// the remote cluster is left alone while the control plane is in plan mode
+	result, err := r.Reconcile(reconcile.Request{NamespacedName: key})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result).To(gomega.Equal(reconcile.Result{}))
+
+	var remoteIstio istiov1beta1.RemoteIstio
+	g.Expect(c.Get(context.TODO(), key, &remoteIstio)).To(gomega.Succeed())
+	g.Expect(remoteIstio.OwnerReferences).To(gomega.BeEmpty())
+	g.Expect(remoteIstio.Status.Status).To(gomega.BeEmpty())
+	_, err = r.remoteClustersMgr.Get(clusterKey(&remoteIstio))
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	// the remote cluster is reconciled once the plan mode is turned off, it fails without the CA of the
+	// primary Citadel
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Namespace: "istio-system", Name: "mesh"}, istio)).To(gomega.Succeed())
+	istio.Annotations = nil
+	g.Expect(c.Update(context.TODO(), istio)).To(gomega.Succeed())
+
+	result, err = r.Reconcile(reconcile.Request{NamespacedName: key})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(result.RequeueAfter).To(gomega.Equal(failureBackoffInitial))
+
+	g.Expect(c.Get(context.TODO(), key, &remoteIstio)).To(gomega.Succeed())
+	g.Expect(remoteIstio.OwnerReferences).To(gomega.HaveLen(1))
+	g.Expect(remoteIstio.Status.Status).To(gomega.Equal(istiov1beta1.ReconcileFailed))
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteistio
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/metrics"
+	"github.com/banzaicloud/istio-operator/pkg/remoteclusters"
+)
+
+var (
+	// ProbeInterval is the interval the remote clusters are health checked at
+	ProbeInterval = 30 * time.Second
+	// ProbeTimeout limits the health check of a remote cluster
+	ProbeTimeout = 10 * time.Second
+)
+
+// prober periodically checks the health of the remote clusters and reports it in the status of their
+// RemoteIstio resources, so unreachable clusters are marked degraded without waiting for a reconciliation
+type prober struct {
+	client            client.Client
+	remoteClustersMgr *remoteclusters.Manager
+	recorder          record.EventRecorder
+	logger            logr.Logger
+}
+
+var _ manager.Runnable = &prober{}
+
+func (p *prober) Start(stop <-chan struct{}) error {
+	wait.Until(p.probeAll, ProbeInterval, stop)
+
+	return nil
+}
+
+// probeAll probes the remote clusters concurrently, so an unreachable cluster does not delay the health
+// checks of the others
+func (p *prober) probeAll() {
+	var wg sync.WaitGroup
+	for name, cluster := range p.remoteClustersMgr.GetAll() {
+		// clusters are probed only after their first successful reconciliation
+		if cluster.GetRemoteConfig() == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, cluster *remoteclusters.Cluster) {
+			defer wg.Done()
+
+			err := p.probe(cluster)
+			if err != nil {
+				p.logger.Error(err, "could not update remote cluster health", "cluster", name)
+			}
+		}(name, cluster)
+	}
+	wg.Wait()
+}
+
+func (p *prober) probe(cluster *remoteclusters.Cluster) error {
+	result := cluster.Probe(ProbeTimeout)
+	remoteConfig := cluster.GetRemoteConfig()
+	logger := p.logger.WithValues("cluster", cluster.GetName())
+
+	reachable := istiov1beta1.GetCondition(result.Conditions, istiov1beta1.ClusterReachable)
+
+	var status istiov1beta1.ConfigState
+	var updated, becameUnreachable bool
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var current istiov1beta1.RemoteIstio
+		err := p.client.Get(context.TODO(), client.ObjectKey{
+			Namespace: remoteConfig.Namespace,
+			Name:      remoteConfig.Name,
+		}, &current)
+		if err != nil {
+			return err
+		}
+		// the RemoteIstio is being removed, its status is not relevant any more
+		if current.DeletionTimestamp != nil {
+			updated = false
+			return nil
+		}
+
+		becameUnreachable = applyProbeResult(&current, result)
+		status = current.Status.Status
+		updated = true
+
+		return p.client.Status().Update(context.TODO(), &current)
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return emperror.Wrap(err, "could not update remote Istio status")
+	}
+	if !updated {
+		return nil
+	}
+
+	metrics.SetConfigState("RemoteIstio", remoteConfig.Namespace, remoteConfig.Name, status)
+	if becameUnreachable {
+		logger.Info("remote cluster is unreachable", "reason", reachable.Message)
+		k8sutil.NewEventRecorder(p.recorder, remoteConfig).Warning(k8sutil.EventReasonClusterUnreachable, "remote cluster is unreachable: %s", reachable.Message)
+	}
+
+	return nil
+}
+
+// applyProbeResult sets the outcome of the health check in the status of the RemoteIstio resource, it returns
+// whether the remote cluster became unreachable
+func applyProbeResult(remoteConfig *istiov1beta1.RemoteIstio, result remoteclusters.ProbeResult) bool {
+	reachable := istiov1beta1.GetCondition(result.Conditions, istiov1beta1.ClusterReachable)
+	unreachable := reachable != nil && reachable.Status == corev1.ConditionFalse
+
+	previous := istiov1beta1.GetCondition(remoteConfig.Status.Conditions, istiov1beta1.ClusterReachable)
+	becameUnreachable := unreachable && (previous == nil || previous.Status != corev1.ConditionFalse)
+
+	if result.Reachable() {
+		remoteConfig.Status.LastSuccessfulContact = &result.Time
+		remoteConfig.Status.KubernetesVersion = result.KubernetesVersion
+	}
+	for _, condition := range result.Conditions {
+		condition.ObservedGeneration = remoteConfig.Generation
+		remoteConfig.Status.Conditions = istiov1beta1.SetCondition(remoteConfig.Status.Conditions, condition)
+	}
+
+	switch {
+	case unreachable && remoteConfig.Status.Status == istiov1beta1.Available:
+		remoteConfig.Status.Status = istiov1beta1.Degraded
+		remoteConfig.Status.ErrorMessage = "remote cluster is unreachable"
+	case result.Reachable() && remoteConfig.Status.Status == istiov1beta1.Degraded:
+		remoteConfig.Status.Status = istiov1beta1.Available
+		remoteConfig.Status.ErrorMessage = ""
+	}
+
+	return becameUnreachable
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteistio
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/remoteclusters"
+)
+
+func TestApplyProbeResult(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	now := metav1.Now()
+	reachable := remoteclusters.ProbeResult{
+		Time:              now,
+		KubernetesVersion: "v1.15.3",
+		Conditions: []istiov1beta1.Condition{
+			{Type: istiov1beta1.ClusterReachable, Status: corev1.ConditionTrue, Reason: remoteclusters.ProbeReasonSucceeded},
+			{Type: istiov1beta1.RemoteComponentsReady, Status: corev1.ConditionFalse, Reason: remoteclusters.ProbeReasonFailed, Message: "not ready: istio-nodeagent (2/3)"},
+		},
+	}
+	unreachable := remoteclusters.ProbeResult{
+		Time: now,
+		Conditions: []istiov1beta1.Condition{
+			{Type: istiov1beta1.ClusterReachable, Status: corev1.ConditionFalse, Reason: remoteclusters.ProbeReasonFailed, Message: "connection refused"},
+		},
+	}
+
+	tests := []struct {
+		name              string
+		status            istiov1beta1.RemoteIstioStatus
+		result            remoteclusters.ProbeResult
+		expectedStatus    istiov1beta1.ConfigState
+		expectedMessage   string
+		becameUnreachable bool
+	}{
+		{
+			name:              "available cluster becomes unreachable",
+			status:            istiov1beta1.RemoteIstioStatus{Status: istiov1beta1.Available},
+			result:            unreachable,
+			expectedStatus:    istiov1beta1.Degraded,
+			expectedMessage:   "remote cluster is unreachable",
+			becameUnreachable: true,
+		},
+		{
+			name: "cluster stays unreachable",
+			status: istiov1beta1.RemoteIstioStatus{
+				Status:       istiov1beta1.Degraded,
+				ErrorMessage: "remote cluster is unreachable",
+				Conditions: []istiov1beta1.Condition{
+					{Type: istiov1beta1.ClusterReachable, Status: corev1.ConditionFalse},
+				},
+			},
+			result:          unreachable,
+			expectedStatus:  istiov1beta1.Degraded,
+			expectedMessage: "remote cluster is unreachable",
+		},
+		{
+			name: "degraded cluster recovers",
+			status: istiov1beta1.RemoteIstioStatus{
+				Status:       istiov1beta1.Degraded,
+				ErrorMessage: "remote cluster is unreachable",
+				Conditions: []istiov1beta1.Condition{
+					{Type: istiov1beta1.ClusterReachable, Status: corev1.ConditionFalse},
+				},
+			},
+			result:         reachable,
+			expectedStatus: istiov1beta1.Available,
+		},
+		{
+			name:              "failed reconciliation is kept",
+			status:            istiov1beta1.RemoteIstioStatus{Status: istiov1beta1.ReconcileFailed, ErrorMessage: "could not reconcile"},
+			result:            unreachable,
+			expectedStatus:    istiov1beta1.ReconcileFailed,
+			expectedMessage:   "could not reconcile",
+			becameUnreachable: true,
+		},
+	}
+
+	for _, test := range tests {
+		remoteConfig := &istiov1beta1.RemoteIstio{
+			ObjectMeta: metav1.ObjectMeta{
+				Generation: 3,
+			},
+			Status: test.status,
+		}
+
+		g.Expect(applyProbeResult(remoteConfig, test.result)).To(gomega.Equal(test.becameUnreachable), test.name)
+		g.Expect(remoteConfig.Status.Status).To(gomega.Equal(test.expectedStatus), test.name)
+		g.Expect(remoteConfig.Status.ErrorMessage).To(gomega.Equal(test.expectedMessage), test.name)
+		for _, condition := range test.result.Conditions {
+			current := istiov1beta1.GetCondition(remoteConfig.Status.Conditions, condition.Type)
+			g.Expect(current).NotTo(gomega.BeNil(), test.name)
+			g.Expect(current.Status).To(gomega.Equal(condition.Status), test.name)
+			g.Expect(current.ObservedGeneration).To(gomega.Equal(int64(3)), test.name)
+		}
+		if test.result.Reachable() {
+			g.Expect(remoteConfig.Status.LastSuccessfulContact).To(gomega.Equal(&now), test.name)
+			g.Expect(remoteConfig.Status.KubernetesVersion).To(gomega.Equal("v1.15.3"), test.name)
+		} else {
+			g.Expect(remoteConfig.Status.LastSuccessfulContact).To(gomega.BeNil(), test.name)
+		}
+	}
+}
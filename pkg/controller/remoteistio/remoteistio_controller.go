@@ -18,6 +18,8 @@ package remoteistio
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -26,9 +28,13 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/flowcontrol"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -39,20 +45,43 @@ import (
 
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/metrics"
 	"github.com/banzaicloud/istio-operator/pkg/remoteclusters"
 	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
 	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
+const (
+	failureBackoffInitial = 5 * time.Second
+	failureBackoffMax     = 5 * time.Minute
+)
+
+// MaxConcurrentReconciles is the number of RemoteIstio resources reconciled in parallel
+var MaxConcurrentReconciles = 5
+
+const controllerName = "remoteconfig-controller"
 const finalizerID = "remote-istio-operator.finializer.banzaicloud.io"
 const istioSecretLabel = "istio/multiCluster"
 
+// defaultKubeconfigSecretKey is the key the kubeconfig is read from if the secret has several keys and none is selected
+const defaultKubeconfigSecretKey = "kubeconfig"
+
 var log = logf.Log.WithName("remote-istio-controller")
 
 // Add creates a new RemoteConfig Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager, cm *remoteclusters.Manager) error {
-	return add(mgr, newReconciler(mgr, cm))
+	err := add(mgr, newReconciler(mgr, cm), cm)
+	if err != nil {
+		return err
+	}
+
+	return mgr.Add(&prober{
+		client:            mgr.GetClient(),
+		remoteClustersMgr: cm,
+		recorder:          mgr.GetRecorder(controllerName),
+		logger:            log.WithName("prober"),
+	})
 }
 
 // newReconciler returns a new reconcile.Reconciler
@@ -61,13 +90,18 @@ func newReconciler(mgr manager.Manager, cm *remoteclusters.Manager) reconcile.Re
 		Client:            mgr.GetClient(),
 		scheme:            mgr.GetScheme(),
 		remoteClustersMgr: cm,
+		recorder:          mgr.GetRecorder(controllerName),
+		backoff:           flowcontrol.NewBackOff(failureBackoffInitial, failureBackoffMax),
 	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
-func add(mgr manager.Manager, r reconcile.Reconciler) error {
+func add(mgr manager.Manager, r reconcile.Reconciler, cm *remoteclusters.Manager) error {
 	// Create a new controller
-	c, err := controller.New("remoteconfig-controller", mgr, controller.Options{Reconciler: r})
+	c, err := controller.New(controllerName, mgr, controller.Options{
+		Reconciler:              r,
+		MaxConcurrentReconciles: MaxConcurrentReconciles,
+	})
 	if err != nil {
 		return err
 	}
@@ -88,6 +122,22 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to the kubeconfig secrets to rebuild the clients of the remote clusters
+	err = c.Watch(&source.Kind{Type: &corev1.Secret{TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"}}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+			return triggerRemoteIstiosOfSecret(mgr.GetClient(), object.Meta, log)
+		}),
+	}, k8sutil.GetWatchPredicateForKubeconfigSecret())
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes made in the remote clusters to the objects reconciled by the operator
+	err = c.Watch(&source.Channel{Source: cm.Events()}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		return err
+	}
+
 	// Watch for changes to Istio service pods
 	err = c.Watch(&source.Kind{Type: &corev1.Pod{TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"}}}, &handler.EnqueueRequestsFromMapFunc{
 		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
@@ -98,6 +148,21 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for changes to the enabled and exported services and their pods
+	for _, t := range []runtime.Object{
+		&corev1.Service{TypeMeta: metav1.TypeMeta{Kind: "Service", APIVersion: "v1"}},
+		&corev1.Pod{TypeMeta: metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"}},
+	} {
+		err = c.Watch(&source.Kind{Type: t}, &handler.EnqueueRequestsFromMapFunc{
+			ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
+				return triggerRemoteIstiosOfServices(mgr.GetClient(), object, log)
+			}),
+		}, k8sutil.GetWatchPredicateForReplicatedServices())
+		if err != nil {
+			return err
+		}
+	}
+
 	// Watch for changes to Ingress
 	err = c.Watch(&source.Kind{Type: &corev1.Service{TypeMeta: metav1.TypeMeta{Kind: "service", APIVersion: "v1"}}}, &handler.EnqueueRequestsFromMapFunc{
 		ToRequests: handler.ToRequestsFunc(func(object handler.MapObject) []reconcile.Request {
@@ -119,6 +184,9 @@ type ReconcileRemoteConfig struct {
 	scheme *runtime.Scheme
 
 	remoteClustersMgr *remoteclusters.Manager
+	recorder          record.EventRecorder
+	// backoff delays the retries of the failing remote clusters independently of each other
+	backoff *flowcontrol.Backoff
 }
 
 // Reconcile reads that state of the cluster for a RemoteConfig object and makes changes based on the state read
@@ -128,10 +196,19 @@ type ReconcileRemoteConfig struct {
 // +kubebuilder:rbac:groups=apps,resources=deployments/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=istio.banzaicloud.io,resources=remoteistios,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=istio.banzaicloud.io,resources=remoteistios/status,verbs=get;update;patch
-func (r *ReconcileRemoteConfig) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileRemoteConfig) Reconcile(request reconcile.Request) (result reconcile.Result, err error) {
+	// outcome is set when the error is not returned to retry the cluster with its own backoff
+	var outcome metrics.Result
+	defer func(start time.Time) {
+		if outcome == "" {
+			outcome = metrics.ResultOf(result.Requeue, err)
+		}
+		metrics.ObserveReconcile(controllerName, outcome, start)
+	}(time.Now())
+
 	logger := log.WithValues("trigger", request.Namespace+"/"+request.Name, "correlationID", uuid.Must(uuid.NewV4()).String())
 	remoteConfig := &istiov1beta1.RemoteIstio{}
-	err := r.Get(context.TODO(), request.NamespacedName, remoteConfig)
+	err = r.Get(context.TODO(), request.NamespacedName, remoteConfig)
 	if err != nil {
 		if !k8serrors.IsNotFound(err) {
 			// Error reading the object - requeue the request.
@@ -140,6 +217,7 @@ func (r *ReconcileRemoteConfig) Reconcile(request reconcile.Request) (reconcile.
 		}
 
 		// Handle delete with a finalizer
+		metrics.DeleteConfigState("RemoteIstio", request.Namespace, request.Name)
 		return reconcile.Result{}, nil
 	}
 
@@ -161,9 +239,9 @@ func (r *ReconcileRemoteConfig) Reconcile(request reconcile.Request) (reconcile.
 				return reconcile.Result{}, nil
 			}
 			logger.Info("removing remote istio")
-			cluster, err := r.remoteClustersMgr.Get(remoteConfig.Name)
+			cluster, err := r.remoteClustersMgr.Get(clusterKey(remoteConfig))
 			if err == nil {
-				err = cluster.RemoveConfig()
+				err = cluster.RemoveConfig(remoteConfig)
 				if err != nil {
 					return reconcile.Result{}, emperror.Wrap(err, "could not remove remote config to remote istio")
 				}
@@ -172,10 +250,11 @@ func (r *ReconcileRemoteConfig) Reconcile(request reconcile.Request) (reconcile.
 				if err != nil {
 					return reconcile.Result{}, emperror.Wrap(err, "could not remove cluster from manager")
 				}
+				r.backoff.DeleteEntry(clusterKey(remoteConfig))
 			}
 
 			err = r.labelSecret(client.ObjectKey{
-				Name:      remoteConfig.GetName(),
+				Name:      remoteConfig.GetKubeconfigSecretName(),
 				Namespace: remoteConfig.GetNamespace(),
 			}, istioSecretLabel, "")
 			if err != nil {
@@ -193,40 +272,92 @@ func (r *ReconcileRemoteConfig) Reconcile(request reconcile.Request) (reconcile.
 		return reconcile.Result{}, nil
 	}
 
-	istio, err := r.getIstio()
+	recorder := k8sutil.NewEventRecorder(r.recorder, remoteConfig)
+
+	if errs := remoteConfig.Validate(); len(errs) > 0 {
+		err = errs.ToAggregate()
+		logger.Error(err, "invalid RemoteIstio resource")
+		recorder.Warning(k8sutil.EventReasonInvalidConfig, "%s", err.Error())
+		updateErr := updateRemoteConfigStatus(r.Client, remoteConfig, istiov1beta1.ReconcileFailed, err.Error(), logger)
+		if updateErr != nil {
+			logger.Error(updateErr, "failed to update state")
+			return reconcile.Result{}, errors.WithStack(updateErr)
+		}
+		return reconcile.Result{
+			Requeue: false,
+		}, nil
+	}
+
+	istio, err := r.getIstio(remoteConfig)
 	if err != nil {
+		recorder.Warning(k8sutil.EventReasonReconcileFailed, "%s", err.Error())
+		updateErr := updateRemoteConfigStatus(r.Client, remoteConfig, istiov1beta1.ReconcileFailed, err.Error(), logger)
+		if updateErr != nil {
+			logger.Error(updateErr, "failed to update state")
+		}
 		return reconcile.Result{}, err
 	}
 
+	// the remote clusters are left alone until the changes of the control plane are applied, the
+	// RemoteIstio resource is reconciled once the plan mode of the Istio resource is turned off
+	if k8sutil.IsPlanMode(istio) {
+		logger.Info("Istio resource is in plan mode, remote cluster is not reconciled", "istio", istio.Name)
+		return reconcile.Result{}, nil
+	}
+
 	if !istio.Spec.Version.IsSupported() {
 		err = errors.New("intended Istio version is unsupported by this version of the operator")
-		logger.Error(err, "", "version", istio.Spec.Version)
+		logger.Error(err, "", "version", istio.Spec.Version, "supportedVersions", istiov1beta1.SupportedIstioVersions())
+		recorder.Warning(k8sutil.EventReasonUnsupportedVersion, "Istio version %s is unsupported, supported versions: %s", istio.Spec.Version, strings.Join(istiov1beta1.SupportedIstioVersions(), ", "))
 		return reconcile.Result{
 			Requeue: false,
 		}, nil
 	}
 
-	refs, err := k8sutil.SetOwnerReferenceToObject(remoteConfig, istio)
-	if err != nil {
-		return reconcile.Result{}, err
+	// the owner reference of the previous control plane is replaced when the RemoteIstio is moved to another one
+	currentRefs := remoteConfig.GetOwnerReferences()
+	refs := make([]metav1.OwnerReference, 0, len(currentRefs))
+	for _, ref := range currentRefs {
+		if ref.Kind == istio.Kind && ref.APIVersion == istio.APIVersion && ref.UID != istio.UID {
+			continue
+		}
+		refs = append(refs, ref)
 	}
 	remoteConfig.SetOwnerReferences(refs)
-	err = r.Update(context.TODO(), remoteConfig)
+	refs, err = k8sutil.SetOwnerReferenceToObject(remoteConfig, istio)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	if !reflect.DeepEqual(refs, currentRefs) {
+		remoteConfig.SetOwnerReferences(refs)
+		err = r.Update(context.TODO(), remoteConfig)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 
 	// Set default values where not set
 	istiov1beta1.SetRemoteIstioDefaults(remoteConfig)
-	result, err := r.reconcile(remoteConfig, istio, logger)
+	result, err = r.reconcile(remoteConfig, istio, logger)
 	if err != nil {
+		recorder.Warning(k8sutil.EventReasonReconcileFailed, "%s", err.Error())
 		updateErr := updateRemoteConfigStatus(r.Client, remoteConfig, istiov1beta1.ReconcileFailed, err.Error(), logger)
 		if updateErr != nil {
 			return result, errors.WithStack(err)
 		}
 
-		return result, emperror.Wrap(err, "could not reconcile remote istio")
+		// the failing cluster is retried with an increasing delay, without holding back the other clusters
+		key := clusterKey(remoteConfig)
+		r.backoff.Next(key, r.backoff.Clock.Now())
+		delay := r.backoff.Get(key)
+		logger.Error(err, "could not reconcile remote istio", "retryAfter", delay.String())
+		outcome = metrics.ResultError
+
+		return reconcile.Result{
+			RequeueAfter: delay,
+		}, nil
 	}
+	r.backoff.Reset(clusterKey(remoteConfig))
 
 	return result, nil
 }
@@ -258,6 +389,16 @@ func (r *ReconcileRemoteConfig) reconcile(remoteConfig *istiov1beta1.RemoteIstio
 
 	logger.Info("begin reconciling remote istio")
 
+	remoteConfig, err = r.populateExportedServices(remoteConfig, logger)
+	if err != nil {
+		err = emperror.Wrap(err, "could not populate exported services")
+		updateRemoteConfigStatus(r.Client, remoteConfig, istiov1beta1.ReconcileFailed, err.Error(), logger)
+		return reconcile.Result{
+			Requeue:      true,
+			RequeueAfter: time.Duration(30) * time.Second,
+		}, nil
+	}
+
 	remoteConfig, err = r.populateEnabledServiceEndpoints(remoteConfig, istio, logger)
 	if err != nil {
 		err = emperror.Wrap(err, "could not populate service endpoints")
@@ -268,28 +409,35 @@ func (r *ReconcileRemoteConfig) reconcile(remoteConfig *istiov1beta1.RemoteIstio
 		}, nil
 	}
 
-	caSecretName := citadel.SelfSignedCASecretName
-	if istio.Spec.Citadel.CASecretName != "" {
-		caSecretName = istio.Spec.Citadel.CASecretName
+	remoteConfig, caRotationStage, err := r.populateSignCerts(citadel.GetCASecretName(istio), remoteConfig, logger)
+	if err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not populate sign certs")
 	}
 
-	remoteConfig, err = r.populateSignCerts(caSecretName, remoteConfig)
+	k8sconfig, err := r.getK8SConfigForCluster(remoteConfig)
 	if err != nil {
-		return reconcile.Result{}, emperror.Wrap(err, "could not populate sign certs")
+		return reconcile.Result{}, emperror.Wrap(err, "could not get k8s config of remote cluster")
 	}
 
-	cluster, _ := r.remoteClustersMgr.Get(remoteConfig.Name)
+	cluster, _ := r.remoteClustersMgr.Get(clusterKey(remoteConfig))
 	if cluster == nil {
-		cluster, err = r.getRemoteCluster(remoteConfig, logger)
+		cluster, err = r.getRemoteCluster(remoteConfig, k8sconfig, logger)
+	} else {
+		// the kubeconfig or the credentials in it may have been rotated
+		err = cluster.SetConfig(k8sconfig)
 	}
 	if err != nil {
 		return reconcile.Result{}, emperror.Wrap(err, "could not get remote cluster")
 	}
 
+	recorder := k8sutil.NewEventRecorder(r.recorder, remoteConfig)
+	cluster.SetEventRecorder(recorder)
 	err = cluster.Reconcile(remoteConfig, istio)
 	if err != nil {
+		metrics.RemoteClusterReconcileError(remoteConfig.Namespace, remoteConfig.Name)
 		err = emperror.Wrap(err, "could not reconcile remote istio")
 		if _, ok := errors.Cause(err).(k8sutil.IngressSetupPendingError); ok {
+			recorder.Warning(k8sutil.EventReasonIngressSetupPending, "%s", errors.Cause(err).Error())
 			updateRemoteConfigStatus(r.Client, remoteConfig, istiov1beta1.ReconcileFailed, errors.Cause(err).Error(), logger)
 			return reconcile.Result{
 				Requeue:      true,
@@ -300,13 +448,18 @@ func (r *ReconcileRemoteConfig) reconcile(remoteConfig *istiov1beta1.RemoteIstio
 	}
 
 	err = r.labelSecret(client.ObjectKey{
-		Name:      remoteConfig.GetName(),
+		Name:      remoteConfig.GetKubeconfigSecretName(),
 		Namespace: remoteConfig.GetNamespace(),
 	}, istioSecretLabel, "true")
 	if err != nil {
 		return reconcile.Result{}, emperror.Wrap(err, "could not reconcile remote istio")
 	}
 
+	remoteConfig.Status.CARotation, err = r.caRotationStatus(cluster, remoteConfig, caRotationStage)
+	if err != nil {
+		return reconcile.Result{}, emperror.Wrap(err, "could not check the progress of the CA rotation")
+	}
+
 	err = updateRemoteConfigStatus(r.Client, remoteConfig, istiov1beta1.Available, "", logger)
 	if err != nil {
 		return reconcile.Result{}, errors.WithStack(err)
@@ -314,13 +467,47 @@ func (r *ReconcileRemoteConfig) reconcile(remoteConfig *istiov1beta1.RemoteIstio
 
 	logger.Info("remote istio reconciled")
 
+	// the remote Citadel is followed until it is restarted with the CA of the rotation stage
+	if caRotationStage != "" && caRotationStage != istiov1beta1.CARotationCompleted {
+		return reconcile.Result{
+			RequeueAfter: time.Duration(30) * time.Second,
+		}, nil
+	}
+
+	// the intermediate CA is re-issued before it expires
+	if remoteConfig.Status.IntermediateCA != nil {
+		return reconcile.Result{
+			RequeueAfter: time.Until(remoteConfig.Status.IntermediateCA.RenewTime.Time),
+		}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// caRotationStatus returns the stage of the CA rotation the remote cluster has completed, the previous one is
+// kept while the remote Citadel is restarted
+func (r *ReconcileRemoteConfig) caRotationStatus(cluster *remoteclusters.Cluster, remoteConfig *istiov1beta1.RemoteIstio, stage istiov1beta1.CARotationStage) (*istiov1beta1.RemoteCARotationStatus, error) {
+	if stage == "" {
+		return nil, nil
+	}
+
+	status, err := cluster.CARotationStatus(remoteConfig, stage)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return remoteConfig.Status.CARotation, nil
+	}
+
+	return status, nil
+}
+
 func updateRemoteConfigStatus(c client.Client, remoteConfig *istiov1beta1.RemoteIstio, status istiov1beta1.ConfigState, errorMessage string, logger logr.Logger) error {
 	typeMeta := remoteConfig.TypeMeta
 	remoteConfig.Status.Status = status
 	remoteConfig.Status.ErrorMessage = errorMessage
+	caRotation := remoteConfig.Status.CARotation
+	intermediateCA := remoteConfig.Status.IntermediateCA
 	err := c.Status().Update(context.Background(), remoteConfig)
 	if k8serrors.IsNotFound(err) {
 		err = c.Update(context.Background(), remoteConfig)
@@ -338,6 +525,8 @@ func updateRemoteConfigStatus(c client.Client, remoteConfig *istiov1beta1.Remote
 		}
 		remoteConfig.Status.Status = status
 		remoteConfig.Status.ErrorMessage = errorMessage
+		remoteConfig.Status.CARotation = caRotation
+		remoteConfig.Status.IntermediateCA = intermediateCA
 		err = c.Status().Update(context.Background(), remoteConfig)
 		if k8serrors.IsNotFound(err) {
 			err = c.Update(context.Background(), remoteConfig)
@@ -348,29 +537,43 @@ func updateRemoteConfigStatus(c client.Client, remoteConfig *istiov1beta1.Remote
 	}
 	// update loses the typeMeta of the config so we're resetting it from the
 	remoteConfig.TypeMeta = typeMeta
+	metrics.SetConfigState("RemoteIstio", remoteConfig.Namespace, remoteConfig.Name, status)
 	logger.Info("remoteconfig status updated", "status", status)
 
 	return nil
 }
 
-func (r *ReconcileRemoteConfig) populateSignCerts(caSecretName string, remoteConfig *istiov1beta1.RemoteIstio) (*istiov1beta1.RemoteIstio, error) {
+// populateSignCerts sets the intermediate CA issued for the remote cluster by the CA of the primary Citadel on
+// the remote config, the stage of the CA rotation it belongs to is returned if the CA is being rotated
+func (r *ReconcileRemoteConfig) populateSignCerts(caSecretName string, remoteConfig *istiov1beta1.RemoteIstio, logger logr.Logger) (*istiov1beta1.RemoteIstio, istiov1beta1.CARotationStage, error) {
 	var secret corev1.Secret
 	err := r.Get(context.TODO(), client.ObjectKey{
 		Namespace: remoteConfig.Namespace,
 		Name:      caSecretName,
 	}, &secret)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+	stage := istiov1beta1.CARotationStage(secret.Annotations[citadel.CARotationStageAnnotation])
+
+	// the remote cluster has a CA of its own
+	if remoteConfig.Spec.Citadel.CASecretName != "" {
+		return remoteConfig, stage, r.removeIntermediateCA(remoteConfig, logger)
+	}
+
+	ca, err := r.reconcileIntermediateCA(remoteConfig, &secret, logger)
+	if err != nil {
+		return nil, "", err
 	}
 
 	remoteConfig.Spec = remoteConfig.Spec.SetSignCert(istiov1beta1.SignCert{
-		CA:    secret.Data["ca-cert.pem"],
-		Root:  secret.Data["ca-cert.pem"],
-		Chain: []byte(""),
-		Key:   secret.Data["ca-key.pem"],
+		CA:    ca.Data["ca-cert.pem"],
+		Root:  ca.Data["root-cert.pem"],
+		Chain: ca.Data["cert-chain.pem"],
+		Key:   ca.Data["ca-key.pem"],
 	})
 
-	return remoteConfig, nil
+	return remoteConfig, stage, nil
 }
 
 func (r *ReconcileRemoteConfig) populateEnabledServiceEndpoints(remoteIstio *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio, logger logr.Logger) (*istiov1beta1.RemoteIstio, error) {
@@ -382,13 +585,14 @@ func (r *ReconcileRemoteConfig) populateEnabledServiceEndpoints(remoteIstio *ist
 }
 
 func (r *ReconcileRemoteConfig) populateEnabledServiceEndpointsFlat(remoteIstio *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio, logger logr.Logger) (*istiov1beta1.RemoteIstio, error) {
-	var pods corev1.PodList
-	var service corev1.Service
-
 	for i, svc := range remoteIstio.Spec.EnabledServices {
+		var pods corev1.PodList
+		var service corev1.Service
+
+		svc.Namespace = remoteIstio.GetServiceNamespace(svc)
 		err := r.Get(context.TODO(), client.ObjectKey{
 			Name:      svc.Name,
-			Namespace: remoteIstio.Namespace,
+			Namespace: svc.Namespace,
 		}, &service)
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return remoteIstio, err
@@ -401,7 +605,7 @@ func (r *ReconcileRemoteConfig) populateEnabledServiceEndpointsFlat(remoteIstio
 			svc.LabelSelector = labels.Set(service.Spec.Selector).String()
 		}
 
-		o := &client.ListOptions{}
+		o := &client.ListOptions{Namespace: svc.Namespace}
 		err = o.SetLabelSelector(svc.LabelSelector)
 		if err != nil {
 			return remoteIstio, err
@@ -429,16 +633,17 @@ func (r *ReconcileRemoteConfig) populateEnabledServiceEndpointsFlat(remoteIstio
 }
 
 func (r *ReconcileRemoteConfig) populateEnabledServiceEndpointsGateway(remoteIstio *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio, logger logr.Logger) (*istiov1beta1.RemoteIstio, error) {
-	var service corev1.Service
-
 	if istio.Status.GatewayAddress == nil {
 		return remoteIstio, errors.New("invalid Istio ingress gateway address")
 	}
 
 	for i, svc := range remoteIstio.Spec.EnabledServices {
+		var service corev1.Service
+
+		svc.Namespace = remoteIstio.GetServiceNamespace(svc)
 		err := r.Get(context.TODO(), client.ObjectKey{
 			Name:      svc.Name,
-			Namespace: remoteIstio.Namespace,
+			Namespace: svc.Namespace,
 		}, &service)
 		if k8serrors.IsNotFound(err) {
 			continue
@@ -457,15 +662,8 @@ func (r *ReconcileRemoteConfig) populateEnabledServiceEndpointsGateway(remoteIst
 	return remoteIstio, nil
 }
 
-func (r *ReconcileRemoteConfig) getRemoteCluster(remoteConfig *istiov1beta1.RemoteIstio, logger logr.Logger) (*remoteclusters.Cluster, error) {
-	k8sconfig, err := r.getK8SConfigForCluster(remoteConfig.ObjectMeta.Namespace, remoteConfig.Name)
-	if err != nil {
-		return nil, err
-	}
-
-	logger.Info("k8s config found")
-
-	cluster, err := remoteclusters.NewCluster(remoteConfig.Name, k8sconfig, logger)
+func (r *ReconcileRemoteConfig) getRemoteCluster(remoteConfig *istiov1beta1.RemoteIstio, k8sconfig []byte, logger logr.Logger) (*remoteclusters.Cluster, error) {
+	cluster, err := remoteclusters.NewCluster(clusterKey(remoteConfig), k8sconfig, r.remoteClustersMgr.GetOptions(), logger)
 	if err != nil {
 		return nil, err
 	}
@@ -477,21 +675,36 @@ func (r *ReconcileRemoteConfig) getRemoteCluster(remoteConfig *istiov1beta1.Remo
 	return cluster, nil
 }
 
-func (r *ReconcileRemoteConfig) getK8SConfigForCluster(namespace string, name string) ([]byte, error) {
+// getK8SConfigForCluster returns the kubeconfig of the remote cluster from the secret selected by the RemoteIstio resource
+func (r *ReconcileRemoteConfig) getK8SConfigForCluster(remoteConfig *istiov1beta1.RemoteIstio) ([]byte, error) {
 	var secret corev1.Secret
+	name := remoteConfig.GetKubeconfigSecretName()
 	err := r.Get(context.TODO(), client.ObjectKey{
-		Namespace: namespace,
+		Namespace: remoteConfig.Namespace,
 		Name:      name,
 	}, &secret)
 	if err != nil {
-		return nil, err
+		return nil, emperror.WrapWith(err, "could not get kubeconfig secret", "name", name)
 	}
 
-	for _, config := range secret.Data {
+	if key := remoteConfig.GetKubeconfigSecretKey(); key != "" {
+		config, ok := secret.Data[key]
+		if !ok {
+			return nil, errors.Errorf("key %s not found in kubeconfig secret %s", key, name)
+		}
+		return config, nil
+	}
+
+	if len(secret.Data) == 1 {
+		for _, config := range secret.Data {
+			return config, nil
+		}
+	}
+	if config, ok := secret.Data[defaultKubeconfigSecretKey]; ok {
 		return config, nil
 	}
 
-	return nil, errors.New("could not found k8s config")
+	return nil, errors.Errorf("kubeconfig secret %s must have a single key or a %s key, or spec.kubeconfigSecret.key must be set", name, defaultKubeconfigSecretKey)
 }
 
 func (r *ReconcileRemoteConfig) labelSecret(secretName client.ObjectKey, label, value string) error {
@@ -520,18 +733,39 @@ func (r *ReconcileRemoteConfig) labelSecret(secretName client.ObjectKey, label,
 	return nil
 }
 
-func (r *ReconcileRemoteConfig) getIstio() (*istiov1beta1.Istio, error) {
-	var istios istiov1beta1.IstioList
-	err := r.List(context.TODO(), &client.ListOptions{}, &istios)
-	if err != nil {
-		return nil, err
-	}
+// clusterKey identifies the remote cluster of the RemoteIstio resource, the RemoteIstio resources of
+// different meshes may have the same name
+func clusterKey(remoteConfig *istiov1beta1.RemoteIstio) string {
+	return client.ObjectKey{
+		Namespace: remoteConfig.Namespace,
+		Name:      remoteConfig.Name,
+	}.String()
+}
+
+// getIstio returns the Istio resource referenced by the RemoteIstio resource, or the only one in its namespace
+// if there is no reference
+func (r *ReconcileRemoteConfig) getIstio(remoteConfig *istiov1beta1.RemoteIstio) (*istiov1beta1.Istio, error) {
+	var config istiov1beta1.Istio
+	key := remoteConfig.GetIstioKey()
+	if key.Name != "" {
+		err := r.Get(context.TODO(), key, &config)
+		if err != nil {
+			return nil, emperror.WrapWith(err, "could not get referenced istio resource", "name", key.Name, "namespace", key.Namespace)
+		}
+	} else {
+		var istios istiov1beta1.IstioList
+		err := r.List(context.TODO(), &client.ListOptions{Namespace: key.Namespace}, &istios)
+		if err != nil {
+			return nil, err
+		}
 
-	if len(istios.Items) != 1 {
-		return nil, errors.New("istio resource not found")
+		if len(istios.Items) != 1 {
+			return nil, errors.New("istio resource not found, set spec.istioRef if there are multiple ones in the namespace")
+		}
+
+		config = istios.Items[0]
 	}
 
-	config := istios.Items[0]
 	gvk := config.GroupVersionKind()
 	gvk.Version = istiov1beta1.SchemeGroupVersion.Version
 	gvk.Group = istiov1beta1.SchemeGroupVersion.Group
@@ -575,6 +809,31 @@ func triggerRemoteIstios(mgr manager.Manager, object runtime.Object, logger logr
 	return requests
 }
 
+// triggerRemoteIstiosOfSecret returns the RemoteIstio resources whose kubeconfig is stored in the secret
+func triggerRemoteIstiosOfSecret(c client.Client, secret metav1.Object, logger logr.Logger) []reconcile.Request {
+	var remoteIstios istiov1beta1.RemoteIstioList
+	err := c.List(context.Background(), &client.ListOptions{Namespace: secret.GetNamespace()}, &remoteIstios)
+	if err != nil {
+		logger.Error(err, "could not list remote istio resources")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0)
+	for _, remoteIstio := range remoteIstios.Items {
+		if remoteIstio.GetKubeconfigSecretName() != secret.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKey{
+				Namespace: remoteIstio.Namespace,
+				Name:      remoteIstio.Name,
+			},
+		})
+	}
+
+	return requests
+}
+
 func RemoveFinalizers(c client.Client) error {
 	var remoteistios istiov1beta1.RemoteIstioList
 
@@ -601,8 +860,12 @@ func GetRemoteIstiosByOwnerReference(mgr manager.Manager, object runtime.Object,
 	remotes := make([]istiov1beta1.RemoteIstio, 0)
 
 	var ownerMatcher *k8sutil.OwnerReferenceMatcher
+	var ownerKey types.NamespacedName
 	if object != nil {
 		ownerMatcher = k8sutil.NewOwnerReferenceMatcher(object, true, mgr.GetScheme())
+		if m, err := meta.Accessor(object); err == nil {
+			ownerKey = types.NamespacedName{Namespace: m.GetNamespace(), Name: m.GetName()}
+		}
 	}
 
 	err := mgr.GetClient().List(context.Background(), &client.ListOptions{}, &remoteIstios)
@@ -611,7 +874,12 @@ func GetRemoteIstiosByOwnerReference(mgr manager.Manager, object runtime.Object,
 	}
 
 	for _, remoteIstio := range remoteIstios.Items {
-		if ownerMatcher != nil {
+		// the reference takes precedence over the owner reference, which is only updated on reconcile
+		if key := remoteIstio.GetIstioKey(); ownerMatcher != nil && key.Name != "" {
+			if key != ownerKey {
+				continue
+			}
+		} else if ownerMatcher != nil {
 			related, _, err := ownerMatcher.Match(&remoteIstio)
 			if err != nil {
 				logger.Error(err, "could not match owner reference for remote istio", "name", remoteIstio.Name)
@@ -52,8 +52,9 @@ func AATestReconcile(t *testing.T) {
 	g.Expect(err).NotTo(gomega.HaveOccurred())
 	c = mgr.GetClient()
 
-	recFn, requests := SetupTestReconcile(newReconciler(mgr, remoteclusters.NewManager()))
-	g.Expect(add(mgr, recFn)).NotTo(gomega.HaveOccurred())
+	cm := remoteclusters.NewManager(remoteclusters.Options{})
+	recFn, requests := SetupTestReconcile(newReconciler(mgr, cm))
+	g.Expect(add(mgr, recFn, cm)).NotTo(gomega.HaveOccurred())
 
 	stopMgr, mgrStopped := StartTestManager(mgr, g)
 
@@ -31,7 +31,8 @@ import (
 	patch "github.com/banzaicloud/k8s-objectmatcher/patch"
 
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
-	"github.com/banzaicloud/istio-operator/pkg/util"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/metrics"
 )
 
 const (
@@ -149,7 +150,9 @@ func crdL(kind string, plural string, config crdConfig, appLabel string, pckLabe
 	return crd
 }
 
-func (r *CrdOperator) Reconcile(config *istiov1beta1.Istio, log logr.Logger) error {
+// Reconcile creates and updates the CRDs, they are shared by the meshes in the cluster and are not owned by the
+// Istio resource of any of them, the owner references set by earlier versions of the operator are removed
+func (r *CrdOperator) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 	apiExtensions, err := apiextensionsclient.NewForConfig(r.config)
 	if err != nil {
@@ -160,34 +163,26 @@ func (r *CrdOperator) Reconcile(config *istiov1beta1.Istio, log logr.Logger) err
 		log := log.WithValues("kind", crd.Spec.Names.Kind)
 		current, err := crdClient.Get(crd.Name, metav1.GetOptions{})
 		if err != nil && !apierrors.IsNotFound(err) {
+			metrics.CRDReconcile(crd.Name, metrics.OperationFailed)
 			return emperror.WrapWith(err, "getting CRD failed", "kind", crd.Spec.Names.Kind)
 		}
 		if apierrors.IsNotFound(err) {
 			if err := patch.DefaultAnnotator.SetLastAppliedAnnotation(crd); err != nil {
 				log.Error(err, "Failed to set last applied annotation", "crd", crd)
 			}
-			if config.Name != "" {
-				crd.ObjectMeta.OwnerReferences = []metav1.OwnerReference{
-					{
-						Kind:               config.Kind,
-						APIVersion:         config.APIVersion,
-						Name:               config.Name,
-						UID:                config.GetUID(),
-						Controller:         util.BoolPointer(true),
-						BlockOwnerDeletion: util.BoolPointer(true),
-					},
-				}
-			}
 			if _, err := crdClient.Create(crd); err != nil {
+				metrics.CRDReconcile(crd.Name, metrics.OperationFailed)
 				return emperror.WrapWith(err, "creating CRD failed", "kind", crd.Spec.Names.Kind)
 			}
 			log.Info("CRD created")
+			metrics.CRDReconcile(crd.Name, metrics.OperationCreated)
 		} else {
 			patchResult, err := patch.DefaultPatchMaker.Calculate(current, crd)
 			if err != nil {
 				log.Error(err, "could not match objects", "kind", crd.Spec.Names.Kind)
-			} else if patchResult.IsEmpty() {
+			} else if patchResult.IsEmpty() && !ownedByIstio(current) {
 				log.V(1).Info("CRD is in sync")
+				metrics.CRDReconcile(crd.Name, metrics.OperationInSync)
 				continue
 			} else {
 				log.V(1).Info("resource diffs",
@@ -206,19 +201,25 @@ func (r *CrdOperator) Reconcile(config *istiov1beta1.Istio, log logr.Logger) err
 				if apierrors.IsConflict(err) || apierrors.IsInvalid(err) {
 					err := crdClient.Delete(crd.Name, &metav1.DeleteOptions{})
 					if err != nil {
+						metrics.CRDReconcile(crd.Name, metrics.OperationFailed)
 						return emperror.WrapWith(err, "could not delete CRD", "kind", crd.Spec.Names.Kind)
 					}
 					crd.ResourceVersion = ""
 					if _, err := crdClient.Create(crd); err != nil {
 						log.Info("resource needs to be re-created")
+						metrics.CRDReconcile(crd.Name, metrics.OperationFailed)
 						return emperror.WrapWith(err, "creating CRD failed", "kind", crd.Spec.Names.Kind)
 					}
 					log.Info("CRD created")
+					metrics.CRDReconcile(crd.Name, metrics.OperationRecreated)
+					continue
 				}
 
+				metrics.CRDReconcile(crd.Name, metrics.OperationFailed)
 				return emperror.WrapWith(err, "updating CRD failed", "kind", crd.Spec.Names.Kind)
 			}
 			log.Info("CRD updated")
+			metrics.CRDReconcile(crd.Name, metrics.OperationUpdated)
 		}
 	}
 
@@ -226,3 +227,58 @@ func (r *CrdOperator) Reconcile(config *istiov1beta1.Istio, log logr.Logger) err
 
 	return nil
 }
+
+// Plan calculates the changes Reconcile would make to the CRDs without applying them
+func (r *CrdOperator) Plan() ([]k8sutil.PlannedAction, error) {
+	apiExtensions, err := apiextensionsclient.NewForConfig(r.config)
+	if err != nil {
+		return nil, emperror.Wrap(err, "instantiating apiextensions client failed")
+	}
+	crdClient := apiExtensions.ApiextensionsV1beta1().CustomResourceDefinitions()
+
+	actions := make([]k8sutil.PlannedAction, 0)
+	for _, crd := range r.crds {
+		action := k8sutil.PlannedAction{
+			APIVersion: extensionsobj.SchemeGroupVersion.String(),
+			Kind:       "CustomResourceDefinition",
+			Name:       crd.Name,
+		}
+		current, err := crdClient.Get(crd.Name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, emperror.WrapWith(err, "getting CRD failed", "kind", crd.Spec.Names.Kind)
+		}
+		if apierrors.IsNotFound(err) {
+			action.Action = k8sutil.ActionCreate
+			actions = append(actions, action)
+			continue
+		}
+
+		patchResult, err := patch.DefaultPatchMaker.Calculate(current, crd.DeepCopy())
+		if err != nil {
+			return nil, emperror.WrapWith(err, "could not match objects", "kind", crd.Spec.Names.Kind)
+		}
+		if patchResult.IsEmpty() {
+			if !ownedByIstio(current) {
+				continue
+			}
+			patchResult.Patch = []byte(`{"metadata":{"ownerReferences":null}}`)
+		}
+		action.Action = k8sutil.ActionUpdate
+		action.Patch = string(patchResult.Patch)
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// ownedByIstio returns whether the CRD is owned by an Istio resource, deleting it would remove the CRD and the
+// config of every mesh in the cluster
+func ownedByIstio(crd *extensionsobj.CustomResourceDefinition) bool {
+	for _, ref := range crd.OwnerReferences {
+		if ref.Kind == "Istio" && strings.HasPrefix(ref.APIVersion, istiov1beta1.SchemeGroupVersion.Group+"/") {
+			return true
+		}
+	}
+
+	return false
+}
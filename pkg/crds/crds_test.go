@@ -0,0 +1,61 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crds
+
+import (
+	"testing"
+
+	extensionsobj "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOwnedByIstio(t *testing.T) {
+	tests := []struct {
+		name   string
+		owners []metav1.OwnerReference
+		owned  bool
+	}{
+		{
+			name: "not owned",
+		},
+		{
+			name:   "owned by an Istio resource",
+			owners: []metav1.OwnerReference{{APIVersion: "istio.banzaicloud.io/v1beta1", Kind: "Istio", Name: "mesh"}},
+			owned:  true,
+		},
+		{
+			name:   "owned by another Istio kind",
+			owners: []metav1.OwnerReference{{APIVersion: "install.istio.io/v1alpha1", Kind: "Istio", Name: "mesh"}},
+		},
+		{
+			name:   "owned by another kind",
+			owners: []metav1.OwnerReference{{APIVersion: "istio.banzaicloud.io/v1beta1", Kind: "RemoteIstio", Name: "remote"}},
+		},
+	}
+
+	for _, test := range tests {
+		crd := &extensionsobj.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "gateways.networking.istio.io",
+				OwnerReferences: test.owners,
+			},
+		}
+		if owned := ownedByIstio(crd); owned != test.owned {
+			t.Errorf("%s: expected owned to be %v, got %v", test.name, test.owned, owned)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	EventReasonCreated              = "Created"
+	EventReasonUpdated              = "Updated"
+	EventReasonRecreated            = "Recreated"
+	EventReasonDeleted              = "Deleted"
+	EventReasonReconcileFailed      = "ReconcileFailed"
+	EventReasonUnsupportedVersion   = "UnsupportedVersion"
+	EventReasonInvalidConfig        = "InvalidConfig"
+	EventReasonIngressSetupPending  = "IngressSetupPending"
+	EventReasonCRDUpdateConflict    = "CRDUpdateConflict"
+	EventReasonCRDReconcileFailed   = "CRDReconcileFailed"
+	EventReasonClusterUnreachable   = "ClusterUnreachable"
+	EventReasonCARotation           = "CARotation"
+	EventReasonIntermediateCAIssued = "IntermediateCAIssued"
+)
+
+// EventRecorder records events on the Istio or RemoteIstio resource the managed objects belong to,
+// a nil recorder records nothing, so objects can be reconciled without one
+type EventRecorder struct {
+	recorder record.EventRecorder
+	object   runtime.Object
+}
+
+func NewEventRecorder(recorder record.EventRecorder, object runtime.Object) *EventRecorder {
+	return &EventRecorder{
+		recorder: recorder,
+		object:   object,
+	}
+}
+
+// Normal records an event about a change made by the operator
+func (r *EventRecorder) Normal(reason, messageFmt string, args ...interface{}) {
+	if r == nil || r.recorder == nil {
+		return
+	}
+
+	r.recorder.Eventf(r.object, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
+
+// Warning records an event about a failure
+func (r *EventRecorder) Warning(reason, messageFmt string, args ...interface{}) {
+	if r == nil || r.recorder == nil {
+		return
+	}
+
+	r.recorder.Eventf(r.object, corev1.EventTypeWarning, reason, messageFmt, args...)
+}
+
+// resourceChanged records the change of a managed object
+func (r *EventRecorder) resourceChanged(reason, kind, namespace, name string) {
+	if namespace != "" {
+		name = namespace + "/" + name
+	}
+
+	r.Normal(reason, "%s %s %s", kind, name, eventVerbs[reason])
+}
+
+var eventVerbs = map[string]string{
+	EventReasonCreated:   "created",
+	EventReasonUpdated:   "updated",
+	EventReasonRecreated: "recreated",
+	EventReasonDeleted:   "deleted",
+}
@@ -25,7 +25,12 @@ import (
 )
 
 // GetHPAReplicaCountOrDefault get desired replica count from HPA if exists, returns the given default otherwise
+// the default is also returned without a client, e.g. when the objects are rendered offline
 func GetHPAReplicaCountOrDefault(client client.Client, name types.NamespacedName, defaultReplicaCount int32) int32 {
+	if client == nil {
+		return defaultReplicaCount
+	}
+
 	var hpa autoscalev2beta1.HorizontalPodAutoscaler
 	err := client.Get(context.Background(), name, &hpa)
 	if err != nil {
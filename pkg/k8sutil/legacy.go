@@ -0,0 +1,101 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RemoveUnsuffixedClusterScopedObjects removes the ClusterRoles, ClusterRoleBindings and
+// MutatingWebhookConfigurations owned by the given owner whose names do not end with the given suffix
+func RemoveUnsuffixedClusterScopedObjects(ctx context.Context, c client.Client, owner metav1.Object, suffix string, logger logr.Logger) error {
+	objects, err := UnsuffixedClusterScopedObjects(ctx, c, owner, suffix)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range objects {
+		m, err := meta.Accessor(o)
+		if err != nil {
+			return emperror.Wrap(err, "could not access object metadata")
+		}
+
+		logger.Info("removing legacy resource", "kind", kindOf(o), "name", m.GetName())
+		err = c.Delete(ctx, o)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return emperror.WrapWith(err, "could not remove legacy resource", "name", m.GetName())
+		}
+	}
+
+	return nil
+}
+
+// UnsuffixedClusterScopedObjects returns the ClusterRoles, ClusterRoleBindings and MutatingWebhookConfigurations
+// owned by the given owner whose names do not end with the given suffix
+func UnsuffixedClusterScopedObjects(ctx context.Context, c client.Client, owner metav1.Object, suffix string) ([]runtime.Object, error) {
+	unsuffixed := make([]runtime.Object, 0)
+	for _, list := range []runtime.Object{
+		&rbacv1.ClusterRoleList{},
+		&rbacv1.ClusterRoleBindingList{},
+		&admissionregistrationv1beta1.MutatingWebhookConfigurationList{},
+	} {
+		err := c.List(ctx, &client.ListOptions{}, list)
+		if err != nil {
+			return nil, emperror.Wrap(err, "could not list cluster scoped resources")
+		}
+
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, emperror.Wrap(err, "could not extract cluster scoped resources")
+		}
+
+		for _, o := range objects {
+			m, err := meta.Accessor(o)
+			if err != nil {
+				return nil, emperror.Wrap(err, "could not access object metadata")
+			}
+			if strings.HasSuffix(m.GetName(), suffix) || !IsOwnedBy(m, owner) {
+				continue
+			}
+			unsuffixed = append(unsuffixed, o)
+		}
+	}
+
+	return unsuffixed, nil
+}
+
+// IsOwnedBy returns whether the object has an owner reference to the given owner
+func IsOwnedBy(o metav1.Object, owner metav1.Object) bool {
+	for _, ref := range o.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"github.com/banzaicloud/k8s-objectmatcher/patch"
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+// PlanMode makes the operator publish the changes of the reconciliation of every Istio resource instead of
+// applying them
+var PlanMode bool
+
+// IsPlanMode returns whether the changes of the reconciliation of the Istio resource are only published, the
+// remote clusters of the control plane are left alone meanwhile
+func IsPlanMode(config *istiov1beta1.Istio) bool {
+	if PlanMode {
+		return true
+	}
+
+	enabled, _ := strconv.ParseBool(config.GetAnnotations()[istiov1beta1.PlanAnnotation])
+	return enabled
+}
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// PlannedAction describes a change the reconciliation would make to an object
+type PlannedAction struct {
+	Action     Action `json:"action"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	// Patch is the JSON patch calculated for the update of the object
+	Patch string `json:"patch,omitempty"`
+}
+
+// Plan calculates the action Reconcile would take for the desired object without changing it in the cluster,
+// it returns nil if the object is in sync
+func Plan(client runtimeClient.Client, desired runtime.Object, desiredState DesiredState) (*PlannedAction, error) {
+	if desiredState == "" {
+		desiredState = DesiredStatePresent
+	}
+
+	desiredType := reflect.TypeOf(desired)
+	var current = desired.DeepCopyObject()
+	key, err := runtimeClient.ObjectKeyFromObject(current)
+	if err != nil {
+		return nil, emperror.With(err, "kind", desiredType)
+	}
+
+	action := &PlannedAction{
+		Kind:      kindOf(desired),
+		Namespace: key.Namespace,
+		Name:      key.Name,
+	}
+	if gvk, err := apiutil.GVKForObject(desired, scheme.Scheme); err == nil {
+		action.APIVersion, action.Kind = gvk.ToAPIVersionAndKind()
+	}
+
+	err = client.Get(context.TODO(), key, current)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, emperror.WrapWith(err, "getting resource failed", "kind", desiredType, "name", key.Name)
+	}
+	if apierrors.IsNotFound(err) {
+		if desiredState == DesiredStatePresent {
+			action.Action = ActionCreate
+			return action, nil
+		}
+		return nil, nil
+	}
+
+	if desiredState == DesiredStateAbsent {
+		action.Action = ActionDelete
+		return action, nil
+	}
+
+	patchResult, err := patch.DefaultPatchMaker.Calculate(current, desired.DeepCopyObject())
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not match objects", "kind", desiredType, "name", key.Name)
+	}
+	if patchResult.IsEmpty() {
+		return nil, nil
+	}
+
+	action.Action = ActionUpdate
+	action.Patch = string(patchResult.Patch)
+
+	return action, nil
+}
+
+// PlanDelete returns the action of the deletion of an object which exists in the cluster
+func PlanDelete(o runtime.Object) (*PlannedAction, error) {
+	m, err := meta.Accessor(o)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not access object metadata")
+	}
+
+	action := &PlannedAction{
+		Action:    ActionDelete,
+		Kind:      kindOf(o),
+		Namespace: m.GetNamespace(),
+		Name:      m.GetName(),
+	}
+	if gvk, err := apiutil.GVKForObject(o, scheme.Scheme); err == nil {
+		action.APIVersion, action.Kind = gvk.ToAPIVersionAndKind()
+	}
+
+	return action, nil
+}
+
+// PlanNamespaceLabels calculates the action ReconcileNamespaceLabelsIgnoreNotFound would take for the namespace
+// without changing it in the cluster, it returns nil if the labels are in sync or the namespace is not found
+func PlanNamespaceLabels(client runtimeClient.Client, namespace string, labels map[string]string, labelsToRemove []string) (*PlannedAction, error) {
+	var ns = &corev1.Namespace{}
+	err := client.Get(context.TODO(), runtimeClient.ObjectKey{Name: namespace}, ns)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, emperror.WrapWith(err, "getting namespace failed", "namespace", namespace)
+	}
+
+	changed := setNamespaceLabels(ns, labels, labelsToRemove)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	labelsPatch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": changed,
+		},
+	})
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not marshal namespace labels", "namespace", namespace)
+	}
+
+	return &PlannedAction{
+		Action:     ActionUpdate,
+		APIVersion: "v1",
+		Kind:       "Namespace",
+		Name:       namespace,
+		Patch:      string(labelsPatch),
+	}, nil
+}
+
+// Plan calculates the action Reconcile would take for the object without changing it in the cluster,
+// it returns nil if the object is in sync
+func (d *DynamicObject) Plan(client dynamic.Interface, desiredState DesiredState) (*PlannedAction, error) {
+	if desiredState == "" {
+		desiredState = DesiredStatePresent
+	}
+
+	desired := d.Unstructured()
+	action := &PlannedAction{
+		APIVersion: d.Gvr.GroupVersion().String(),
+		Kind:       d.Kind,
+		Namespace:  d.Namespace,
+		Name:       d.Name,
+	}
+
+	current, err := client.Resource(d.Gvr).Namespace(d.Namespace).Get(d.Name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, emperror.WrapWith(err, "getting resource failed", "name", d.Name, "kind", d.Kind)
+	}
+	if apierrors.IsNotFound(err) {
+		if desiredState == DesiredStatePresent {
+			action.Action = ActionCreate
+			return action, nil
+		}
+		return nil, nil
+	}
+
+	if desiredState == DesiredStateAbsent {
+		action.Action = ActionDelete
+		return action, nil
+	}
+
+	patchResult, err := patch.DefaultPatchMaker.Calculate(current, desired)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not match objects", "name", d.Name, "kind", d.Kind)
+	}
+	if patchResult.IsEmpty() {
+		return nil, nil
+	}
+
+	action.Action = ActionUpdate
+	action.Patch = string(patchResult.Patch)
+
+	return action, nil
+}
@@ -19,6 +19,7 @@ package k8sutil
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
@@ -38,7 +39,10 @@ func GetWatchPredicateForIstio() predicate.Funcs {
 			new := e.ObjectNew.(*istiov1beta1.Istio)
 			if !reflect.DeepEqual(old.Spec, new.Spec) ||
 				old.GetDeletionTimestamp() != new.GetDeletionTimestamp() ||
-				old.GetGeneration() != new.GetGeneration() {
+				old.GetGeneration() != new.GetGeneration() ||
+				old.GetAnnotations()[istiov1beta1.PlanAnnotation] != new.GetAnnotations()[istiov1beta1.PlanAnnotation] ||
+				// the remote clusters follow the stages of the CA rotation
+				caRotationStage(old) != caRotationStage(new) {
 				return true
 			}
 			return false
@@ -46,6 +50,14 @@ func GetWatchPredicateForIstio() predicate.Funcs {
 	}
 }
 
+func caRotationStage(config *istiov1beta1.Istio) istiov1beta1.CARotationStage {
+	if config.Status.CARotation == nil {
+		return ""
+	}
+
+	return config.Status.CARotation.Stage
+}
+
 func GetWatchPredicateForRemoteIstio() predicate.Funcs {
 	return predicate.Funcs{
 		CreateFunc: func(e event.CreateEvent) bool {
@@ -116,3 +128,96 @@ func GetWatchPredicateForIstioService(name string) predicate.Funcs {
 func GetWatchPredicateForIstioIngressGateway() predicate.Funcs {
 	return GetWatchPredicateForIstioService("ingressgateway")
 }
+
+// GetWatchPredicateForKubeconfigSecret filters the secret events to the changes of their data
+func GetWatchPredicateForKubeconfigSecret() predicate.Funcs {
+	return predicate.Funcs{
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			old, ok := e.ObjectOld.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			new, ok := e.ObjectNew.(*corev1.Secret)
+			if !ok {
+				return false
+			}
+			return !reflect.DeepEqual(old.Data, new.Data)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	}
+}
+
+// GetWatchPredicateForSecretData filters the events of the named secret to the changes of its data
+func GetWatchPredicateForSecretData(name string) predicate.Funcs {
+	dataChanged := GetWatchPredicateForKubeconfigSecret()
+	return predicate.Funcs{
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return e.Meta.GetName() == name
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.MetaNew.GetName() == name && dataChanged.Update(e)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return e.Meta.GetName() == name
+		},
+	}
+}
+
+// GetWatchPredicateForReplicatedServices filters the service and pod events to the changes which can affect the
+// services replicated to the remote clusters: the labels, the ports and the selector of the services and the
+// readiness of the pods
+func GetWatchPredicateForReplicatedServices() predicate.Funcs {
+	return predicate.Funcs{
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			switch new := e.ObjectNew.(type) {
+			case *corev1.Service:
+				old, ok := e.ObjectOld.(*corev1.Service)
+				if !ok {
+					return false
+				}
+				return !reflect.DeepEqual(old.Labels, new.Labels) ||
+					!reflect.DeepEqual(old.Spec.Ports, new.Spec.Ports) ||
+					!reflect.DeepEqual(old.Spec.Selector, new.Spec.Selector)
+			case *corev1.Pod:
+				old, ok := e.ObjectOld.(*corev1.Pod)
+				if !ok {
+					return false
+				}
+				return old.Status.PodIP != new.Status.PodIP ||
+					podReady(old) != podReady(new) ||
+					!reflect.DeepEqual(old.Labels, new.Labels)
+			}
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+	}
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if !status.Ready {
+			return false
+		}
+	}
+
+	return len(pod.Status.ContainerStatuses) == len(pod.Spec.Containers)
+}
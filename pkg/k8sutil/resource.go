@@ -30,9 +30,13 @@ import (
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/banzaicloud/k8s-objectmatcher/patch"
+
+	"github.com/banzaicloud/istio-operator/pkg/metrics"
 )
 
-func Reconcile(log logr.Logger, client runtimeClient.Client, desired runtime.Object, desiredState DesiredState) error {
+// Reconcile creates, updates or deletes the desired object according to the desired state, the changes are
+// recorded as events by the given recorder
+func Reconcile(log logr.Logger, client runtimeClient.Client, desired runtime.Object, desiredState DesiredState, recorder *EventRecorder) error {
 	if desiredState == "" {
 		desiredState = DesiredStatePresent
 	}
@@ -59,6 +63,8 @@ func Reconcile(log logr.Logger, client runtimeClient.Client, desired runtime.Obj
 				return emperror.WrapWith(err, "creating resource failed", "kind", desiredType, "name", key.Name)
 			}
 			log.Info("resource created")
+			metrics.ResourceOperation(kindOf(desired), metrics.OperationCreated)
+			recorder.resourceChanged(EventReasonCreated, kindOf(desired), key.Namespace, key.Name)
 		}
 	} else {
 		if desiredState == DesiredStatePresent {
@@ -102,22 +108,38 @@ func Reconcile(log logr.Logger, client runtimeClient.Client, desired runtime.Obj
 						return emperror.WrapWith(err, "creating resource failed", "kind", desiredType, "name", key.Name)
 					}
 					log.Info("resource created")
+					metrics.ResourceOperation(kindOf(desired), metrics.OperationRecreated)
+					recorder.resourceChanged(EventReasonRecreated, kindOf(desired), key.Namespace, key.Name)
 					return nil
 				}
 
 				return emperror.WrapWith(err, "updating resource failed", "kind", desiredType, "name", key.Name)
 			}
 			log.Info("resource updated")
+			metrics.ResourceOperation(kindOf(desired), metrics.OperationUpdated)
+			recorder.resourceChanged(EventReasonUpdated, kindOf(desired), key.Namespace, key.Name)
 		} else if desiredState == DesiredStateAbsent {
 			if err := client.Delete(context.TODO(), current); err != nil {
 				return emperror.WrapWith(err, "deleting resource failed", "kind", desiredType, "name", key.Name)
 			}
 			log.Info("resource deleted")
+			metrics.ResourceOperation(kindOf(desired), metrics.OperationDeleted)
+			recorder.resourceChanged(EventReasonDeleted, kindOf(desired), key.Namespace, key.Name)
 		}
 	}
 	return nil
 }
 
+// kindOf returns the kind of a typed object, the objects built by the reconcilers have no type meta set
+func kindOf(o runtime.Object) string {
+	t := reflect.TypeOf(o)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Name()
+}
+
 func prepareResourceForUpdate(current, desired runtime.Object) {
 	switch desired.(type) {
 	case *corev1.Service:
@@ -169,28 +191,35 @@ func ReconcileNamespaceLabelsIgnoreNotFound(log logr.Logger, client runtimeClien
 		return emperror.WrapWith(err, "getting namespace failed", "namespace", namespace)
 	}
 
-	updateNeeded := false
+	if changed := setNamespaceLabels(ns, labels, labelsToRemove); len(changed) > 0 {
+		if err := client.Update(context.TODO(), ns); err != nil {
+			return emperror.WrapWith(err, "updating namespace failed", "namespace", namespace)
+		}
+		log.Info("namespace labels reconciled", "namespace", namespace, "labels", labels)
+	}
+
+	return nil
+}
+
+// setNamespaceLabels adds and removes the labels of the namespace, it returns the changed labels with a nil
+// value for the removed ones
+func setNamespaceLabels(ns *corev1.Namespace, labels map[string]string, labelsToRemove []string) map[string]interface{} {
+	changed := make(map[string]interface{})
 	for dlk, dlv := range labels {
 		if ns.Labels == nil {
 			ns.Labels = make(map[string]string)
 		}
 		if clv, ok := ns.Labels[dlk]; !ok || clv != dlv {
 			ns.Labels[dlk] = dlv
-			updateNeeded = true
+			changed[dlk] = dlv
 		}
 	}
 	for _, labelKey := range labelsToRemove {
 		if _, ok := ns.Labels[labelKey]; ok {
 			delete(ns.Labels, labelKey)
-			updateNeeded = true
-		}
-	}
-	if updateNeeded {
-		if err := client.Update(context.TODO(), ns); err != nil {
-			return emperror.WrapWith(err, "updating namespace failed", "namespace", namespace)
+			changed[labelKey] = nil
 		}
-		log.Info("namespace labels reconciled", "namespace", namespace, "labels", labels)
 	}
 
-	return nil
+	return changed
 }
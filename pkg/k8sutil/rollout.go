@@ -0,0 +1,135 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/goph/emperror"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WorkloadRolloutStatus describes a workload whose rollout has not converged yet
+type WorkloadRolloutStatus struct {
+	Kind      string
+	Name      string
+	Namespace string
+	Message   string
+}
+
+func (s WorkloadRolloutStatus) String() string {
+	return fmt.Sprintf("%s %s/%s: %s", s.Kind, s.Namespace, s.Name, s.Message)
+}
+
+// GetPendingRollouts returns the deployments and daemonsets controlled by the given owner whose rollout has not converged yet
+func GetPendingRollouts(c client.Client, ownerUID types.UID) ([]WorkloadRolloutStatus, error) {
+	pending := make([]WorkloadRolloutStatus, 0)
+
+	var deployments appsv1.DeploymentList
+	err := c.List(context.TODO(), &client.ListOptions{}, &deployments)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not list deployments")
+	}
+	for _, deployment := range deployments.Items {
+		if !isControlledBy(deployment.ObjectMeta, ownerUID) {
+			continue
+		}
+		if ready, message := IsDeploymentRolledOut(&deployment); !ready {
+			pending = append(pending, WorkloadRolloutStatus{
+				Kind:      "Deployment",
+				Name:      deployment.Name,
+				Namespace: deployment.Namespace,
+				Message:   message,
+			})
+		}
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	err = c.List(context.TODO(), &client.ListOptions{}, &daemonSets)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not list daemonsets")
+	}
+	for _, daemonSet := range daemonSets.Items {
+		if !isControlledBy(daemonSet.ObjectMeta, ownerUID) {
+			continue
+		}
+		if ready, message := IsDaemonSetRolledOut(&daemonSet); !ready {
+			pending = append(pending, WorkloadRolloutStatus{
+				Kind:      "DaemonSet",
+				Name:      daemonSet.Name,
+				Namespace: daemonSet.Namespace,
+				Message:   message,
+			})
+		}
+	}
+
+	return pending, nil
+}
+
+// IsDeploymentRolledOut checks whether every desired replica of the deployment is updated, ready and available
+func IsDeploymentRolledOut(deployment *appsv1.Deployment) (bool, string) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return false, "waiting for the deployment spec update to be observed"
+	}
+
+	var desired int32 = 1
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+
+	switch {
+	case deployment.Status.UpdatedReplicas < desired:
+		return false, fmt.Sprintf("%d out of %d new replicas have been updated", deployment.Status.UpdatedReplicas, desired)
+	case deployment.Status.Replicas > deployment.Status.UpdatedReplicas:
+		return false, fmt.Sprintf("%d old replicas are pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	case deployment.Status.ReadyReplicas < desired:
+		return false, fmt.Sprintf("%d of %d updated replicas are ready", deployment.Status.ReadyReplicas, desired)
+	case deployment.Status.AvailableReplicas < desired:
+		return false, fmt.Sprintf("%d of %d updated replicas are available", deployment.Status.AvailableReplicas, desired)
+	}
+
+	return true, ""
+}
+
+// IsDaemonSetRolledOut checks whether the daemonset pods are updated, ready and available on every desired node
+func IsDaemonSetRolledOut(daemonSet *appsv1.DaemonSet) (bool, string) {
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return false, "waiting for the daemonset spec update to be observed"
+	}
+
+	desired := daemonSet.Status.DesiredNumberScheduled
+
+	switch {
+	case daemonSet.Status.UpdatedNumberScheduled < desired:
+		return false, fmt.Sprintf("%d out of %d new pods have been updated", daemonSet.Status.UpdatedNumberScheduled, desired)
+	case daemonSet.Status.NumberReady < desired:
+		return false, fmt.Sprintf("%d of %d updated pods are ready", daemonSet.Status.NumberReady, desired)
+	case daemonSet.Status.NumberAvailable < desired:
+		return false, fmt.Sprintf("%d of %d updated pods are available", daemonSet.Status.NumberAvailable, desired)
+	}
+
+	return true, ""
+}
+
+func isControlledBy(object metav1.ObjectMeta, ownerUID types.UID) bool {
+	ref := metav1.GetControllerOf(&object)
+	return ref != nil && ref.UID == ownerUID
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package k8sutil
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestIsDeploymentRolledOut(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	var three int32 = 3
+	tests := []struct {
+		name       string
+		generation int64
+		replicas   *int32
+		status     appsv1.DeploymentStatus
+		rolledOut  bool
+		message    string
+	}{
+		{
+			name:       "rolled out",
+			generation: 2,
+			replicas:   &three,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3},
+			rolledOut:  true,
+		},
+		{
+			name:       "one replica by default",
+			generation: 1,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1},
+			rolledOut:  true,
+		},
+		{
+			name:       "spec update not observed",
+			generation: 3,
+			replicas:   &three,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3},
+			message:    "waiting for the deployment spec update to be observed",
+		},
+		{
+			name:       "replicas not updated",
+			generation: 2,
+			replicas:   &three,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, ReadyReplicas: 3, AvailableReplicas: 3},
+			message:    "1 out of 3 new replicas have been updated",
+		},
+		{
+			name:       "old replicas not terminated",
+			generation: 2,
+			replicas:   &three,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 4, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3},
+			message:    "1 old replicas are pending termination",
+		},
+		{
+			name:       "replicas not ready",
+			generation: 2,
+			replicas:   &three,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 2, AvailableReplicas: 2},
+			message:    "2 of 3 updated replicas are ready",
+		},
+		{
+			name:       "replicas unavailable",
+			generation: 2,
+			replicas:   &three,
+			status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 1, UnavailableReplicas: 2},
+			message:    "1 of 3 updated replicas are available",
+		},
+	}
+
+	for _, test := range tests {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: test.generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: test.replicas},
+			Status:     test.status,
+		}
+		rolledOut, message := IsDeploymentRolledOut(deployment)
+		g.Expect(rolledOut).To(gomega.Equal(test.rolledOut), test.name)
+		g.Expect(message).To(gomega.Equal(test.message), test.name)
+	}
+}
+
+func TestIsDaemonSetRolledOut(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tests := []struct {
+		name       string
+		generation int64
+		status     appsv1.DaemonSetStatus
+		rolledOut  bool
+		message    string
+	}{
+		{
+			name:       "rolled out",
+			generation: 2,
+			status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3, NumberAvailable: 3},
+			rolledOut:  true,
+		},
+		{
+			name:       "no nodes",
+			generation: 1,
+			status:     appsv1.DaemonSetStatus{ObservedGeneration: 1},
+			rolledOut:  true,
+		},
+		{
+			name:       "spec update not observed",
+			generation: 3,
+			status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3, NumberAvailable: 3},
+			message:    "waiting for the daemonset spec update to be observed",
+		},
+		{
+			name:       "pods not updated",
+			generation: 2,
+			status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 2, NumberReady: 3, NumberAvailable: 3},
+			message:    "2 out of 3 new pods have been updated",
+		},
+		{
+			name:       "pods not ready",
+			generation: 2,
+			status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 1, NumberAvailable: 1},
+			message:    "1 of 3 updated pods are ready",
+		},
+		{
+			name:       "pods unavailable",
+			generation: 2,
+			status:     appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3, NumberAvailable: 2, NumberUnavailable: 1},
+			message:    "2 of 3 updated pods are available",
+		},
+	}
+
+	for _, test := range tests {
+		daemonSet := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Generation: test.generation},
+			Status:     test.status,
+		}
+		rolledOut, message := IsDaemonSetRolledOut(daemonSet)
+		g.Expect(rolledOut).To(gomega.Equal(test.rolledOut), test.name)
+		g.Expect(message).To(gomega.Equal(test.message), test.name)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/client-go/dynamic"
 
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/metrics"
 	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
@@ -49,11 +50,13 @@ type DynamicObject struct {
 	Owner     *istiov1beta1.Istio
 }
 
-func (d *DynamicObject) Reconcile(log logr.Logger, client dynamic.Interface, desiredState DesiredState) error {
+// Reconcile creates, updates or deletes the object according to the desired state, the changes are
+// recorded as events by the given recorder
+func (d *DynamicObject) Reconcile(log logr.Logger, client dynamic.Interface, desiredState DesiredState, recorder *EventRecorder) error {
 	if desiredState == "" {
 		desiredState = DesiredStatePresent
 	}
-	desired := d.unstructured()
+	desired := d.Unstructured()
 	desiredType := reflect.TypeOf(desired)
 	log = log.WithValues("type", reflect.TypeOf(d), "name", d.Name)
 	current, err := client.Resource(d.Gvr).Namespace(d.Namespace).Get(d.Name, metav1.GetOptions{})
@@ -69,6 +72,8 @@ func (d *DynamicObject) Reconcile(log logr.Logger, client dynamic.Interface, des
 				return emperror.WrapWith(err, "creating resource failed", "name", d.Name, "kind", desiredType)
 			}
 			log.Info("resource created", "kind", d.Gvr.Resource)
+			metrics.ResourceOperation(d.Kind, metrics.OperationCreated)
+			recorder.resourceChanged(EventReasonCreated, d.Kind, d.Namespace, d.Name)
 		}
 	} else {
 		if desiredState == DesiredStatePresent {
@@ -94,17 +99,22 @@ func (d *DynamicObject) Reconcile(log logr.Logger, client dynamic.Interface, des
 				return emperror.WrapWith(err, "updating resource failed", "name", d.Name, "kind", desiredType)
 			}
 			log.Info("resource updated", "kind", d.Gvr.Resource)
+			metrics.ResourceOperation(d.Kind, metrics.OperationUpdated)
+			recorder.resourceChanged(EventReasonUpdated, d.Kind, d.Namespace, d.Name)
 		} else if desiredState == DesiredStateAbsent {
 			if err := client.Resource(d.Gvr).Namespace(d.Namespace).Delete(d.Name, &metav1.DeleteOptions{}); err != nil {
 				return emperror.WrapWith(err, "deleting resource failed", "name", d.Name, "kind", desiredType)
 			}
 			log.Info("resource deleted", "kind", d.Gvr.Resource)
+			metrics.ResourceOperation(d.Kind, metrics.OperationDeleted)
+			recorder.resourceChanged(EventReasonDeleted, d.Kind, d.Namespace, d.Name)
 		}
 	}
 	return nil
 }
 
-func (d *DynamicObject) unstructured() *unstructured.Unstructured {
+// Unstructured returns the object in the form it is sent to the API server
+func (d *DynamicObject) Unstructured() *unstructured.Unstructured {
 	u := &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"spec": d.Spec,
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics contains the Prometheus metrics of the operator, they are registered in the registry
+// of the controller manager and served on its metrics address
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+const namespace = "istio_operator"
+
+type Result string
+
+const (
+	ResultSuccess Result = "success"
+	ResultError   Result = "error"
+	ResultRequeue Result = "requeue"
+)
+
+type Operation string
+
+const (
+	OperationCreated   Operation = "created"
+	OperationUpdated   Operation = "updated"
+	OperationDeleted   Operation = "deleted"
+	OperationRecreated Operation = "recreated"
+	OperationInSync    Operation = "in_sync"
+	OperationFailed    Operation = "failed"
+)
+
+var configStates = []istiov1beta1.ConfigState{
+	istiov1beta1.Created,
+	istiov1beta1.ReconcileFailed,
+	istiov1beta1.Reconciling,
+	istiov1beta1.Available,
+	istiov1beta1.Unmanaged,
+	istiov1beta1.Degraded,
+}
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_total",
+		Help:      "Number of reconciliations per controller and result",
+	}, []string{"controller", "result"})
+
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of the reconciliations per controller",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 12),
+	}, []string{"controller"})
+
+	componentReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "component_reconcile_total",
+		Help:      "Number of component reconciliations per controller, component and result",
+	}, []string{"controller", "component", "result"})
+
+	componentReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "component_reconcile_duration_seconds",
+		Help:      "Duration of the component reconciliations per controller and component",
+		Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+	}, []string{"controller", "component"})
+
+	resourceOperationsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "resource_operations_total",
+		Help:      "Number of created, updated, deleted and recreated managed objects per kind",
+	}, []string{"kind", "operation"})
+
+	remoteClusterReconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "remote_cluster_reconcile_errors_total",
+		Help:      "Number of failed remote cluster reconciliations per RemoteIstio",
+	}, []string{"namespace", "name"})
+
+	crdReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "crd_reconcile_total",
+		Help:      "Number of CRD reconciliations per CRD and result",
+	}, []string{"crd", "result"})
+
+	configState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "config_state",
+		Help:      "Current state of the Istio and RemoteIstio resources, 1 for the current state and 0 for the others",
+	}, []string{"kind", "namespace", "name", "state"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileTotal,
+		reconcileDuration,
+		componentReconcileTotal,
+		componentReconcileDuration,
+		resourceOperationsTotal,
+		remoteClusterReconcileErrorsTotal,
+		crdReconcileTotal,
+		configState,
+	)
+}
+
+// ResultOf returns the result label of a reconciliation from its outcome
+func ResultOf(requeue bool, err error) Result {
+	switch {
+	case err != nil:
+		return ResultError
+	case requeue:
+		return ResultRequeue
+	default:
+		return ResultSuccess
+	}
+}
+
+// ObserveReconcile records the outcome and the duration of a reconciliation started at start
+func ObserveReconcile(controller string, result Result, start time.Time) {
+	reconcileTotal.WithLabelValues(controller, string(result)).Inc()
+	reconcileDuration.WithLabelValues(controller).Observe(time.Since(start).Seconds())
+}
+
+// ObserveComponentReconcile records the outcome and the duration of a component reconciliation started at start
+func ObserveComponentReconcile(controller, component string, result Result, start time.Time) {
+	componentReconcileTotal.WithLabelValues(controller, component, string(result)).Inc()
+	componentReconcileDuration.WithLabelValues(controller, component).Observe(time.Since(start).Seconds())
+}
+
+// ResourceOperation counts a change made to a managed object
+func ResourceOperation(kind string, operation Operation) {
+	resourceOperationsTotal.WithLabelValues(kind, string(operation)).Inc()
+}
+
+// RemoteClusterReconcileError counts a failed reconciliation of the cluster of a RemoteIstio
+func RemoteClusterReconcileError(namespace, name string) {
+	remoteClusterReconcileErrorsTotal.WithLabelValues(namespace, name).Inc()
+}
+
+// CRDReconcile counts the result of the reconciliation of a CRD
+func CRDReconcile(crd string, operation Operation) {
+	crdReconcileTotal.WithLabelValues(crd, string(operation)).Inc()
+}
+
+// SetConfigState sets the gauges of the states of an Istio or RemoteIstio resource
+func SetConfigState(kind, namespace, name string, state istiov1beta1.ConfigState) {
+	for _, s := range configStates {
+		value := 0.0
+		if s == state {
+			value = 1
+		}
+		configState.WithLabelValues(kind, namespace, name, string(s)).Set(value)
+	}
+}
+
+// DeleteConfigState removes the gauges of the states of a removed Istio or RemoteIstio resource
+func DeleteConfigState(kind, namespace, name string) {
+	for _, s := range configStates {
+		configState.DeleteLabelValues(kind, namespace, name, string(s))
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func TestSetConfigState(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tests := []struct {
+		name  string
+		state istiov1beta1.ConfigState
+	}{
+		{
+			name:  "available",
+			state: istiov1beta1.Available,
+		},
+		{
+			name:  "degraded",
+			state: istiov1beta1.Degraded,
+		},
+		{
+			name:  "failed",
+			state: istiov1beta1.ReconcileFailed,
+		},
+	}
+
+	for _, test := range tests {
+		SetConfigState("RemoteIstio", "istio-system", "remote", test.state)
+
+		for _, s := range configStates {
+			expected := 0.0
+			if s == test.state {
+				expected = 1
+			}
+			value := testutil.ToFloat64(configState.WithLabelValues("RemoteIstio", "istio-system", "remote", string(s)))
+			g.Expect(value).To(gomega.Equal(expected), test.name+": "+string(s))
+		}
+	}
+
+	DeleteConfigState("RemoteIstio", "istio-system", "remote")
+}
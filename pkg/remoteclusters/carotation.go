@@ -0,0 +1,81 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"github.com/goph/emperror"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
+)
+
+// CARotationStatus returns the progress of the given stage of the CA rotation in the remote cluster. It is nil
+// until the remote Citadel is restarted with the CA of the stage.
+func (c *Cluster) CARotationStatus(remoteConfig *istiov1beta1.RemoteIstio, stage istiov1beta1.CARotationStage) (*istiov1beta1.RemoteCARotationStatus, error) {
+	status := &istiov1beta1.RemoteCARotationStatus{
+		Stage: stage,
+	}
+
+	// the remote cluster has its own CA, it is not affected by the rotation
+	if remoteConfig.Spec.Citadel.CASecretName != "" {
+		return status, nil
+	}
+
+	ctx, cancel := c.newContext()
+	defer cancel()
+
+	var secret corev1.Secret
+	err := c.ctrlRuntimeClient.Get(ctx, client.ObjectKey{
+		Namespace: remoteConfig.Namespace,
+		Name:      CASecretName,
+	}, &secret)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not get CA secret of remote cluster")
+	}
+
+	var deployment appsv1.Deployment
+	err = c.ctrlRuntimeClient.Get(ctx, client.ObjectKey{
+		Namespace: remoteConfig.Namespace,
+		Name:      citadel.GetDeploymentName(),
+	}, &deployment)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not get Citadel deployment of remote cluster")
+	}
+	if !citadel.IsDeploymentUsingCA(&deployment, &secret) {
+		return nil, nil
+	}
+	if rolledOut, _ := k8sutil.IsDeploymentRolledOut(&deployment); !rolledOut {
+		return nil, nil
+	}
+
+	if stage == istiov1beta1.CARotationWaitingForWorkloadCerts {
+		status.PendingWorkloadCerts, err = citadel.PendingWorkloadCerts(ctx, c.ctrlRuntimeClient, secret.Data["ca-cert.pem"])
+		if err != nil {
+			return nil, emperror.Wrap(err, "could not check workload certificates of remote cluster")
+		}
+	}
+
+	return status, nil
+}
@@ -17,7 +17,10 @@ limitations under the License.
 package remoteclusters
 
 import (
+	"bytes"
 	"context"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
@@ -26,8 +29,10 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
 )
 
 type Cluster struct {
@@ -40,13 +45,33 @@ type Cluster struct {
 	dynamicClient     dynamic.Interface
 	istioConfig       *istiov1beta1.Istio
 	remoteConfig      *istiov1beta1.RemoteIstio
+	recorder          *k8sutil.EventRecorder
+
+	options       Options
+	watchEvents   chan<- event.GenericEvent
+	stopWatchesCh chan struct{}
+
+	// mu guards the fields read by the prober concurrently with the reconciliation
+	mu sync.RWMutex
+}
+
+// Options configure the clients of the remote clusters
+type Options struct {
+	// ResyncPeriod is the period the objects reconciled in the remote clusters are re-checked at,
+	// zero disables the periodic resync
+	ResyncPeriod time.Duration
+	// RequestTimeout limits the requests made to the API server of a remote cluster
+	RequestTimeout time.Duration
+	// ReconcileTimeout limits the reconciliation of a remote cluster
+	ReconcileTimeout time.Duration
 }
 
-func NewCluster(name string, config []byte, log logr.Logger) (*Cluster, error) {
+func NewCluster(name string, config []byte, options Options, log logr.Logger) (*Cluster, error) {
 	cluster := &Cluster{
-		name:   name,
-		config: config,
-		log:    log.WithValues("cluster", name),
+		name:    name,
+		config:  config,
+		options: options,
+		log:     log.WithValues("cluster", name),
 	}
 
 	err := cluster.initK8SClients()
@@ -61,18 +86,39 @@ func (c *Cluster) GetName() string {
 	return c.name
 }
 
+// SetConfig sets the kubeconfig of the cluster, the clients are rebuilt if it changed
+func (c *Cluster) SetConfig(config []byte) error {
+	if bytes.Equal(c.config, config) {
+		return nil
+	}
+
+	c.log.Info("kubeconfig changed, rebuilding clients")
+	// the watches are restarted with the new config after the next reconciliation
+	c.stopWatches()
+	previous := c.config
+	c.config = config
+	err := c.initK8SClients()
+	if err != nil {
+		c.config = previous
+		return emperror.Wrap(err, "could not re-init k8s clients")
+	}
+
+	return nil
+}
+
 func (c *Cluster) initK8SClients() error {
 	restConfig, err := c.getRestConfig(c.config)
 	if err != nil {
 		return emperror.Wrap(err, "could not get k8s rest config")
 	}
-	c.restConfig = restConfig
-
 	ctrlRuntimeClient, err := c.getCtrlRuntimeClient(restConfig)
 	if err != nil {
 		return emperror.Wrap(err, "could not get control-runtime client")
 	}
+	c.mu.Lock()
+	c.restConfig = restConfig
 	c.ctrlRuntimeClient = ctrlRuntimeClient
+	c.mu.Unlock()
 
 	dynamicClient, err := dynamic.NewForConfig(restConfig)
 	if err != nil {
@@ -86,7 +132,10 @@ func (c *Cluster) initK8SClients() error {
 func (c *Cluster) Reconcile(remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
 	c.log.Info("reconciling remote istio")
 
-	var ReconcilerFuncs []func(remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error
+	ctx, cancel := c.newContext()
+	defer cancel()
+
+	var ReconcilerFuncs []func(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error
 
 	ReconcilerFuncs = append(ReconcilerFuncs,
 		c.reconcileConfigCrd,
@@ -94,31 +143,60 @@ func (c *Cluster) Reconcile(remoteConfig *istiov1beta1.RemoteIstio, istio *istio
 		c.reconcileSignCert,
 		c.reconcileEnabledServices,
 		c.ReconcileEnabledServiceEndpoints,
+		c.pruneEnabledServices,
 		c.reconcileComponents,
+		c.removeLegacyClusterScopedResources,
 		c.getIngressGatewayAddress,
 	)
 
 	for _, f := range ReconcilerFuncs {
-		if err := f(remoteConfig, istio); err != nil {
+		if err := f(ctx, remoteConfig, istio); err != nil {
 			return emperror.Wrapf(err, "could not reconcile")
 		}
+		// the component reconcilers are only limited by the request timeout
+		if err := ctx.Err(); err != nil {
+			return emperror.Wrap(err, "reconciliation of remote cluster timed out")
+		}
 	}
 
+	c.mu.Lock()
 	c.remoteConfig = remoteConfig
+	c.mu.Unlock()
+
+	c.startWatches(remoteConfig)
 
 	return nil
 }
 
+// SetEventRecorder sets the recorder the changes made in the remote cluster are recorded with
+func (c *Cluster) SetEventRecorder(recorder *k8sutil.EventRecorder) {
+	c.recorder = recorder
+}
+
 func (c *Cluster) GetRemoteConfig() *istiov1beta1.RemoteIstio {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.remoteConfig
 }
 
-func (c *Cluster) RemoveConfig() error {
+// RemoveConfig removes the Services and Endpoints of the enabled services, the namespaces created for them and
+// the Istio config from the remote cluster, the rest of the remote resources are garbage collected with the config
+func (c *Cluster) RemoveConfig(remoteConfig *istiov1beta1.RemoteIstio) error {
+	ctx, cancel := c.newContext()
+	defer cancel()
+
+	c.log.Info("removing enabled services from remote cluster")
+	err := c.removeEnabledServices(ctx, remoteConfig)
+	if err != nil {
+		return emperror.Wrap(err, "could not remove enabled services from remote cluster")
+	}
+
 	if c.istioConfig == nil {
 		return nil
 	}
 	c.log.Info("removing istio from remote cluster by removing its config")
-	err := c.ctrlRuntimeClient.Delete(context.TODO(), c.istioConfig)
+	err = c.ctrlRuntimeClient.Delete(ctx, c.istioConfig)
 	if k8serrors.IsNotFound(err) {
 		err = nil
 	}
@@ -126,6 +204,15 @@ func (c *Cluster) RemoveConfig() error {
 	return emperror.Wrap(err, "could not remove istio config from remote cluster")
 }
 
+// newContext returns the context the requests of a reconciliation are made with
+func (c *Cluster) newContext() (context.Context, context.CancelFunc) {
+	if c.options.ReconcileTimeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+
+	return context.WithTimeout(context.Background(), c.options.ReconcileTimeout)
+}
+
 func (c *Cluster) getRestConfig(kubeconfig []byte) (*rest.Config, error) {
 	clusterConfig, err := clientcmd.Load(kubeconfig)
 	if err != nil {
@@ -136,6 +223,7 @@ func (c *Cluster) getRestConfig(kubeconfig []byte) (*rest.Config, error) {
 	if err != nil {
 		return nil, emperror.Wrap(err, "could not create k8s rest config")
 	}
+	rest.Timeout = c.options.RequestTimeout
 
 	return rest, nil
 }
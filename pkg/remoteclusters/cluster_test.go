@@ -0,0 +1,100 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+// newDiscoveryServer returns a server answering the discovery requests the clients are built with
+func newDiscoveryServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api":
+			w.Write([]byte(`{"kind": "APIVersions", "versions": ["v1"]}`))
+		case "/apis":
+			w.Write([]byte(`{"kind": "APIGroupList", "groups": []}`))
+		case "/api/v1":
+			w.Write([]byte(`{"kind": "APIResourceList", "groupVersion": "v1", "resources": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func testKubeconfig(server string) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: remote
+  cluster:
+    server: %s
+contexts:
+- name: remote
+  context:
+    cluster: remote
+    user: operator
+current-context: remote
+users:
+- name: operator
+  user:
+    token: secret
+`, server))
+}
+
+func TestSetConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := newDiscoveryServer()
+	defer server.Close()
+	rotated := newDiscoveryServer()
+	defer rotated.Close()
+
+	c, err := NewCluster("istio-system/remote", testKubeconfig(server.URL), Options{RequestTimeout: 5 * time.Second}, logf.NullLogger{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(c.restConfig.Host).To(gomega.Equal(server.URL))
+	g.Expect(c.restConfig.Timeout).To(gomega.Equal(5 * time.Second))
+
+	// the clients are kept while the kubeconfig is unchanged
+	restConfig, ctrlRuntimeClient := c.restConfig, c.ctrlRuntimeClient
+	g.Expect(c.SetConfig(testKubeconfig(server.URL))).To(gomega.Succeed())
+	g.Expect(c.restConfig).To(gomega.BeIdenticalTo(restConfig))
+	g.Expect(c.ctrlRuntimeClient).To(gomega.BeIdenticalTo(ctrlRuntimeClient))
+
+	// the clients are rebuilt and the watches are stopped when it is rotated
+	stop := make(chan struct{})
+	c.stopWatchesCh = stop
+	g.Expect(c.SetConfig(testKubeconfig(rotated.URL))).To(gomega.Succeed())
+	g.Expect(c.restConfig.Host).To(gomega.Equal(rotated.URL))
+	g.Expect(c.restConfig.Timeout).To(gomega.Equal(5 * time.Second))
+	g.Expect(c.ctrlRuntimeClient).NotTo(gomega.BeIdenticalTo(ctrlRuntimeClient))
+	g.Expect(c.stopWatchesCh).To(gomega.BeNil())
+	g.Expect(stop).To(gomega.BeClosed())
+
+	// the previous kubeconfig is kept when the new one is invalid
+	g.Expect(c.SetConfig([]byte("invalid"))).NotTo(gomega.Succeed())
+	g.Expect(c.config).To(gomega.Equal(testKubeconfig(rotated.URL)))
+	g.Expect(c.restConfig.Host).To(gomega.Equal(rotated.URL))
+}
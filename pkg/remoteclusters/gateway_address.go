@@ -27,7 +27,7 @@ import (
 	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
-func (c *Cluster) getIngressGatewayAddress(remoteIstio *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+func (c *Cluster) getIngressGatewayAddress(ctx context.Context, remoteIstio *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
 	if !util.PointerToBool(istio.Spec.MeshExpansion) {
 		return nil
 	}
@@ -37,7 +37,7 @@ func (c *Cluster) getIngressGatewayAddress(remoteIstio *istiov1beta1.RemoteIstio
 	var service corev1.Service
 	var ips []string
 
-	err := c.ctrlRuntimeClient.Get(context.Background(), types.NamespacedName{
+	err := c.ctrlRuntimeClient.Get(ctx, types.NamespacedName{
 		Name:      "istio-ingressgateway",
 		Namespace: remoteIstio.Namespace,
 	}, &service)
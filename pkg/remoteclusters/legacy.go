@@ -0,0 +1,35 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"context"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+)
+
+// removeLegacyClusterScopedResources removes the ClusterRoles, ClusterRoleBindings and the sidecar injector
+// webhook configuration created in the remote cluster by earlier versions of the operator without the
+// namespace suffix in their names
+func (c *Cluster) removeLegacyClusterScopedResources(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+	if c.istioConfig == nil {
+		return nil
+	}
+
+	return k8sutil.RemoveUnsuffixedClusterScopedObjects(ctx, c.ctrlRuntimeClient, c.istioConfig, "-"+c.istioConfig.Namespace, c.log)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func TestRemoveLegacyClusterScopedResources(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	istioConfig := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigName,
+			Namespace: "istio-system",
+			UID:       "remote-config-uid",
+		},
+	}
+	owned := []metav1.OwnerReference{{Kind: "Istio", Name: ConfigName, UID: istioConfig.UID}}
+
+	c := &Cluster{
+		ctrlRuntimeClient: fake.NewFakeClient(
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "istio-citadel-istio-system", OwnerReferences: owned}},
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "istio-citadel", OwnerReferences: owned}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "istio-citadel", OwnerReferences: owned}},
+			&rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "unrelated"}},
+			&admissionregistrationv1beta1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector", OwnerReferences: owned}},
+			&admissionregistrationv1beta1.MutatingWebhookConfiguration{ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector-istio-system", OwnerReferences: owned}},
+		),
+		log: logf.NullLogger{},
+	}
+
+	// nothing is removed before the Istio config of the remote cluster is reconciled
+	g.Expect(c.removeLegacyClusterScopedResources(context.TODO(), nil, nil)).NotTo(gomega.HaveOccurred())
+	var webhooks admissionregistrationv1beta1.MutatingWebhookConfigurationList
+	g.Expect(c.ctrlRuntimeClient.List(context.TODO(), &client.ListOptions{}, &webhooks)).NotTo(gomega.HaveOccurred())
+	g.Expect(webhooks.Items).To(gomega.HaveLen(2))
+
+	c.istioConfig = istioConfig
+	g.Expect(c.removeLegacyClusterScopedResources(context.TODO(), nil, nil)).NotTo(gomega.HaveOccurred())
+
+	var clusterRoles rbacv1.ClusterRoleList
+	g.Expect(c.ctrlRuntimeClient.List(context.TODO(), &client.ListOptions{}, &clusterRoles)).NotTo(gomega.HaveOccurred())
+	g.Expect(clusterRoles.Items).To(gomega.HaveLen(1))
+	g.Expect(clusterRoles.Items[0].Name).To(gomega.Equal("istio-citadel-istio-system"))
+
+	var bindings rbacv1.ClusterRoleBindingList
+	g.Expect(c.ctrlRuntimeClient.List(context.TODO(), &client.ListOptions{}, &bindings)).NotTo(gomega.HaveOccurred())
+	g.Expect(bindings.Items).To(gomega.HaveLen(1))
+	g.Expect(bindings.Items[0].Name).To(gomega.Equal("unrelated"))
+
+	g.Expect(c.ctrlRuntimeClient.List(context.TODO(), &client.ListOptions{}, &webhooks)).NotTo(gomega.HaveOccurred())
+	g.Expect(webhooks.Items).To(gomega.HaveLen(1))
+	g.Expect(webhooks.Items[0].Name).To(gomega.Equal("istio-sidecar-injector-istio-system"))
+}
@@ -19,31 +19,58 @@ package remoteclusters
 import (
 	"errors"
 	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 type Manager struct {
 	clusters map[string]*Cluster
 	mu       *sync.RWMutex
+
+	events  chan event.GenericEvent
+	options Options
 }
 
-func NewManager() *Manager {
+// NewManager creates a remote cluster manager, the objects the operator writes into the remote clusters are
+// watched to correct drift
+func NewManager(options Options) *Manager {
 	return &Manager{
 		clusters: make(map[string]*Cluster),
 		mu:       &sync.RWMutex{},
+		events:   make(chan event.GenericEvent),
+		options:  options,
 	}
 }
 
+// GetOptions returns the options the clusters are created with
+func (m *Manager) GetOptions() Options {
+	return m.options
+}
+
+// Events returns the channel the changes of the objects reconciled in the remote clusters are reported on,
+// the events refer to the RemoteIstio resource of the cluster
+func (m *Manager) Events() <-chan event.GenericEvent {
+	return m.events
+}
+
+// GetAll returns a copy of the registered clusters, so it is safe to iterate over while clusters are added or removed
 func (m *Manager) GetAll() map[string]*Cluster {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	return m.clusters
+	clusters := make(map[string]*Cluster, len(m.clusters))
+	for name, cluster := range m.clusters {
+		clusters[name] = cluster
+	}
+
+	return clusters
 }
 
 func (m *Manager) Add(cluster *Cluster) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	cluster.setWatchEvents(m.events)
 	m.clusters[cluster.GetName()] = cluster
 
 	return nil
@@ -54,13 +81,14 @@ func (m *Manager) Delete(cluster *Cluster) error {
 		return nil
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if m.clusters[cluster.GetName()] == nil {
 		return nil
 	}
 
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
+	cluster.stopWatches()
 	delete(m.clusters, cluster.GetName())
 
 	return nil
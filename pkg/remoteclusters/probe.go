@@ -0,0 +1,194 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+const (
+	ProbeReasonSucceeded = "ProbeSucceeded"
+	ProbeReasonFailed    = "ProbeFailed"
+	ProbeReasonPending   = "Pending"
+)
+
+// ProbeResult is the outcome of a health check of a remote cluster
+type ProbeResult struct {
+	// Time of the health check
+	Time metav1.Time
+	// KubernetesVersion is the version reported by the API server, empty if it could not be reached
+	KubernetesVersion string
+	// Conditions describe the outcome of the individual checks
+	Conditions []istiov1beta1.Condition
+}
+
+// Reachable returns whether the API server of the remote cluster could be reached
+func (r ProbeResult) Reachable() bool {
+	condition := istiov1beta1.GetCondition(r.Conditions, istiov1beta1.ClusterReachable)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// Probe checks whether the API server of the remote cluster is reachable, the Istio config resource exists
+// and the remote components and the ingress gateway are ready. The whole check is limited by the given timeout.
+func (c *Cluster) Probe(timeout time.Duration) ProbeResult {
+	c.mu.RLock()
+	restConfig := c.restConfig
+	remoteClient := c.ctrlRuntimeClient
+	remoteConfig := c.remoteConfig
+	c.mu.RUnlock()
+
+	result := ProbeResult{
+		Time: metav1.Now(),
+	}
+
+	if restConfig == nil || remoteClient == nil || remoteConfig == nil {
+		result.setCondition(istiov1beta1.ClusterReachable, corev1.ConditionUnknown, ProbeReasonPending, "the remote cluster is not reconciled yet")
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.Timeout = timeout
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		result.setCondition(istiov1beta1.ClusterReachable, corev1.ConditionFalse, ProbeReasonFailed, err.Error())
+		return result
+	}
+	version, err := discoveryClient.ServerVersion()
+	if err != nil {
+		result.setCondition(istiov1beta1.ClusterReachable, corev1.ConditionFalse, ProbeReasonFailed, err.Error())
+		for _, conditionType := range []istiov1beta1.ConditionType{istiov1beta1.RemoteConfigReady, istiov1beta1.RemoteComponentsReady, istiov1beta1.GatewayAddressKnown} {
+			result.setCondition(conditionType, corev1.ConditionUnknown, ProbeReasonPending, "the remote cluster is not reachable")
+		}
+		return result
+	}
+	result.KubernetesVersion = version.GitVersion
+	result.setCondition(istiov1beta1.ClusterReachable, corev1.ConditionTrue, ProbeReasonSucceeded, "")
+
+	var istioConfig istiov1beta1.Istio
+	err = remoteClient.Get(ctx, types.NamespacedName{
+		Name:      ConfigName,
+		Namespace: remoteConfig.Namespace,
+	}, &istioConfig)
+	if err != nil {
+		result.setCondition(istiov1beta1.RemoteConfigReady, corev1.ConditionFalse, ProbeReasonFailed, err.Error())
+		result.setCondition(istiov1beta1.RemoteComponentsReady, corev1.ConditionUnknown, ProbeReasonPending, "the Istio config resource is missing")
+		return result
+	}
+	result.setCondition(istiov1beta1.RemoteConfigReady, corev1.ConditionTrue, ProbeReasonSucceeded, "")
+
+	notReady, err := notReadyComponents(ctx, remoteClient, &istioConfig)
+	switch {
+	case err != nil:
+		result.setCondition(istiov1beta1.RemoteComponentsReady, corev1.ConditionUnknown, ProbeReasonFailed, err.Error())
+	case len(notReady) > 0:
+		result.setCondition(istiov1beta1.RemoteComponentsReady, corev1.ConditionFalse, ProbeReasonFailed, "not ready: "+strings.Join(notReady, ", "))
+	default:
+		result.setCondition(istiov1beta1.RemoteComponentsReady, corev1.ConditionTrue, ProbeReasonSucceeded, "")
+	}
+
+	// the gateway address is only needed for the remote clusters connected through gateways
+	if util.PointerToBool(istioConfig.Spec.MeshExpansion) {
+		var service corev1.Service
+		err = remoteClient.Get(ctx, types.NamespacedName{
+			Name:      "istio-ingressgateway",
+			Namespace: remoteConfig.Namespace,
+		}, &service)
+		var ips []string
+		if err == nil {
+			ips, err = k8sutil.GetServiceEndpointIPs(service)
+		}
+		if err != nil {
+			result.setCondition(istiov1beta1.GatewayAddressKnown, corev1.ConditionFalse, ProbeReasonFailed, err.Error())
+		} else {
+			result.setCondition(istiov1beta1.GatewayAddressKnown, corev1.ConditionTrue, ProbeReasonSucceeded, strings.Join(ips, ", "))
+		}
+	}
+
+	return result
+}
+
+// notReadyComponents returns the deployments and daemonsets of the Istio config resource which don't have all
+// their pods ready
+func notReadyComponents(ctx context.Context, remoteClient client.Client, istioConfig *istiov1beta1.Istio) ([]string, error) {
+	var deployments appsv1.DeploymentList
+	err := remoteClient.List(ctx, &client.ListOptions{Namespace: istioConfig.Namespace}, &deployments)
+	if err != nil {
+		return nil, err
+	}
+
+	notReady := make([]string, 0)
+	for _, deployment := range deployments.Items {
+		owner := metav1.GetControllerOf(&deployment)
+		if owner == nil || owner.UID != istioConfig.UID {
+			continue
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		if deployment.Status.ReadyReplicas < replicas {
+			notReady = append(notReady, fmt.Sprintf("%s (%d/%d)", deployment.Name, deployment.Status.ReadyReplicas, replicas))
+		}
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	err = remoteClient.List(ctx, &client.ListOptions{Namespace: istioConfig.Namespace}, &daemonSets)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, daemonSet := range daemonSets.Items {
+		owner := metav1.GetControllerOf(&daemonSet)
+		if owner == nil || owner.UID != istioConfig.UID {
+			continue
+		}
+		if daemonSet.Status.NumberReady < daemonSet.Status.DesiredNumberScheduled {
+			notReady = append(notReady, fmt.Sprintf("%s (%d/%d)", daemonSet.Name, daemonSet.Status.NumberReady, daemonSet.Status.DesiredNumberScheduled))
+		}
+	}
+	sort.Strings(notReady)
+
+	return notReady, nil
+}
+
+func (r *ProbeResult) setCondition(conditionType istiov1beta1.ConditionType, status corev1.ConditionStatus, reason, message string) {
+	r.Conditions = istiov1beta1.SetCondition(r.Conditions, istiov1beta1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+}
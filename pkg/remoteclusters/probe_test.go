@@ -0,0 +1,175 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := istiov1beta1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	return s
+}
+
+// newAPIServer returns a server answering the version requests of the probe after the given delay
+func newAPIServer(delay time.Duration) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"gitVersion": "v1.15.3"}`))
+	}))
+}
+
+func conditionStatuses(result ProbeResult) map[istiov1beta1.ConditionType]corev1.ConditionStatus {
+	statuses := make(map[istiov1beta1.ConditionType]corev1.ConditionStatus)
+	for _, condition := range result.Conditions {
+		statuses[condition.Type] = condition.Status
+	}
+
+	return statuses
+}
+
+func TestProbe(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	remoteConfig := &istiov1beta1.RemoteIstio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remote",
+			Namespace: "istio-system",
+		},
+	}
+	istioConfig := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ConfigName,
+			Namespace: "istio-system",
+			UID:       "remote-config-uid",
+		},
+	}
+	controlled := func(uid string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: "Istio", Name: ConfigName, UID: types.UID(uid), Controller: util.BoolPointer(true)}}
+	}
+
+	server := newAPIServer(0)
+	defer server.Close()
+
+	// clusters are not probed before they are reconciled
+	result := (&Cluster{}).Probe(time.Second)
+	g.Expect(conditionStatuses(result)).To(gomega.Equal(map[istiov1beta1.ConditionType]corev1.ConditionStatus{
+		istiov1beta1.ClusterReachable: corev1.ConditionUnknown,
+	}))
+
+	c := &Cluster{
+		restConfig:        &rest.Config{Host: server.URL},
+		ctrlRuntimeClient: fake.NewFakeClientWithScheme(newTestScheme(t)),
+		remoteConfig:      remoteConfig,
+		log:               logf.NullLogger{},
+	}
+	result = c.Probe(time.Second)
+	g.Expect(result.Reachable()).To(gomega.BeTrue())
+	g.Expect(result.KubernetesVersion).To(gomega.Equal("v1.15.3"))
+	g.Expect(conditionStatuses(result)).To(gomega.Equal(map[istiov1beta1.ConditionType]corev1.ConditionStatus{
+		istiov1beta1.ClusterReachable:      corev1.ConditionTrue,
+		istiov1beta1.RemoteConfigReady:     corev1.ConditionFalse,
+		istiov1beta1.RemoteComponentsReady: corev1.ConditionUnknown,
+	}))
+
+	c.ctrlRuntimeClient = fake.NewFakeClientWithScheme(newTestScheme(t),
+		istioConfig,
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio-citadel", Namespace: "istio-system", OwnerReferences: controlled("remote-config-uid")},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio-sidecar-injector", Namespace: "istio-system", OwnerReferences: controlled("remote-config-uid")},
+			Spec:       appsv1.DeploymentSpec{Replicas: util.IntPointer(2)},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio-nodeagent", Namespace: "istio-system", OwnerReferences: controlled("remote-config-uid")},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 2},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio-cni-node", Namespace: "istio-system", OwnerReferences: controlled("remote-config-uid")},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 3},
+		},
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "istio-system", OwnerReferences: controlled("other-uid")},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3},
+		},
+	)
+	result = c.Probe(time.Second)
+	g.Expect(conditionStatuses(result)).To(gomega.Equal(map[istiov1beta1.ConditionType]corev1.ConditionStatus{
+		istiov1beta1.ClusterReachable:      corev1.ConditionTrue,
+		istiov1beta1.RemoteConfigReady:     corev1.ConditionTrue,
+		istiov1beta1.RemoteComponentsReady: corev1.ConditionFalse,
+	}))
+	condition := istiov1beta1.GetCondition(result.Conditions, istiov1beta1.RemoteComponentsReady)
+	g.Expect(condition.Message).To(gomega.Equal("not ready: istio-nodeagent (2/3), istio-sidecar-injector (1/2)"))
+}
+
+func TestProbeTimeout(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	server := newAPIServer(time.Minute)
+	defer server.Close()
+
+	c := &Cluster{
+		restConfig:        &rest.Config{Host: server.URL},
+		ctrlRuntimeClient: fake.NewFakeClientWithScheme(newTestScheme(t)),
+		remoteConfig:      &istiov1beta1.RemoteIstio{},
+		log:               logf.NullLogger{},
+	}
+
+	start := time.Now()
+	result := c.Probe(100 * time.Millisecond)
+	g.Expect(time.Since(start)).To(gomega.BeNumerically("<", 5*time.Second))
+	g.Expect(result.Reachable()).To(gomega.BeFalse())
+	g.Expect(conditionStatuses(result)).To(gomega.Equal(map[istiov1beta1.ConditionType]corev1.ConditionStatus{
+		istiov1beta1.ClusterReachable:      corev1.ConditionFalse,
+		istiov1beta1.RemoteConfigReady:     corev1.ConditionUnknown,
+		istiov1beta1.RemoteComponentsReady: corev1.ConditionUnknown,
+		istiov1beta1.GatewayAddressKnown:   corev1.ConditionUnknown,
+	}))
+}
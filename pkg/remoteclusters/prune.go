@@ -0,0 +1,125 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"context"
+
+	"github.com/goph/emperror"
+	apiv1 "k8s.io/api/core/v1"
+	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+)
+
+// pruneEnabledServices removes the Services and Endpoints of the services which are not enabled any more, and
+// the namespaces created for them
+func (c *Cluster) pruneEnabledServices(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+	c.log.Info("pruning enabled services")
+
+	services := make(map[string]bool)
+	endpoints := make(map[string]bool)
+	// the namespace of the Istio config is kept even if it was created for an enabled service
+	namespaces := map[string]bool{
+		"/" + remoteConfig.Namespace: true,
+	}
+	for _, svc := range remoteConfig.Spec.EnabledServices {
+		key := remoteConfig.GetServiceNamespace(svc) + "/" + svc.Name
+		services[key] = true
+		namespaces["/"+remoteConfig.GetServiceNamespace(svc)] = true
+		// endpoints are only written for the services with known addresses
+		for _, ip := range svc.IPs {
+			if ip != "" {
+				endpoints[key] = true
+			}
+		}
+	}
+
+	err := c.pruneOwnedObjects(ctx, remoteConfig, "Endpoints", &apiv1.EndpointsList{}, endpoints)
+	if err != nil {
+		return err
+	}
+
+	err = c.pruneOwnedObjects(ctx, remoteConfig, "Service", &apiv1.ServiceList{}, services)
+	if err != nil {
+		return err
+	}
+
+	return c.pruneOwnedObjects(ctx, remoteConfig, "Namespace", &apiv1.NamespaceList{}, namespaces)
+}
+
+// removeEnabledServices removes every Service and Endpoints written into the cluster for the RemoteIstio resource,
+// and the namespaces created for them except the namespace of the Istio config
+func (c *Cluster) removeEnabledServices(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio) error {
+	err := c.pruneOwnedObjects(ctx, remoteConfig, "Endpoints", &apiv1.EndpointsList{}, nil)
+	if err != nil {
+		return err
+	}
+
+	err = c.pruneOwnedObjects(ctx, remoteConfig, "Service", &apiv1.ServiceList{}, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.pruneOwnedObjects(ctx, remoteConfig, "Namespace", &apiv1.NamespaceList{}, map[string]bool{
+		"/" + remoteConfig.Namespace: true,
+	})
+}
+
+// pruneOwnedObjects deletes the objects of the given list type labelled with the RemoteIstio resource which are
+// not desired any more, the desired objects are keyed by their namespace and name, which is empty for the
+// cluster scoped objects
+func (c *Cluster) pruneOwnedObjects(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, kind string, list runtime.Object, desired map[string]bool) error {
+	selector := labels.SelectorFromSet(remoteConfig.GetOwnerLabels())
+	// the enabled services can be in any namespace
+	err := c.ctrlRuntimeClient.List(ctx, &client.ListOptions{
+		LabelSelector: selector,
+	}, list)
+	if err != nil {
+		return emperror.Wrap(err, "could not list owned objects")
+	}
+
+	objects, err := meta.ExtractList(list)
+	if err != nil {
+		return emperror.Wrap(err, "could not extract owned objects")
+	}
+
+	for _, o := range objects {
+		m, err := meta.Accessor(o)
+		if err != nil {
+			return emperror.Wrap(err, "could not access object metadata")
+		}
+		// the objects not written by the operator are never removed, even if the list is not filtered
+		if !selector.Matches(labels.Set(m.GetLabels())) || desired[m.GetNamespace()+"/"+m.GetName()] {
+			continue
+		}
+
+		c.log.Info("removing stale object", "kind", kind, "namespace", m.GetNamespace(), "name", m.GetName())
+		err = c.ctrlRuntimeClient.Delete(ctx, o)
+		if err != nil && !k8sapierrors.IsNotFound(err) {
+			return emperror.WrapWith(err, "could not remove stale object", "name", m.GetName())
+		}
+		c.recorder.Normal(k8sutil.EventReasonDeleted, "%s %s/%s deleted", kind, m.GetNamespace(), m.GetName())
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func testRemoteConfig() *istiov1beta1.RemoteIstio {
+	return &istiov1beta1.RemoteIstio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remote",
+			Namespace: "istio-system",
+		},
+		Spec: istiov1beta1.RemoteIstioSpec{
+			EnabledServices: []istiov1beta1.IstioService{
+				{Name: "istio-pilot", IPs: []string{"10.1.1.1"}},
+				{Name: "backend", Namespace: "apps"},
+			},
+		},
+	}
+}
+
+// remoteObjects returns the Namespaces, Services and Endpoints of the remote cluster by their kind, namespace and name
+func remoteObjects(t *testing.T, c client.Client) []string {
+	var names []string
+
+	var namespaces corev1.NamespaceList
+	if err := c.List(context.TODO(), &client.ListOptions{}, &namespaces); err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range namespaces.Items {
+		names = append(names, "Namespace "+item.Name)
+	}
+
+	var services corev1.ServiceList
+	if err := c.List(context.TODO(), &client.ListOptions{}, &services); err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range services.Items {
+		names = append(names, "Service "+item.Namespace+"/"+item.Name)
+	}
+
+	var endpoints corev1.EndpointsList
+	if err := c.List(context.TODO(), &client.ListOptions{}, &endpoints); err != nil {
+		t.Fatal(err)
+	}
+	for _, item := range endpoints.Items {
+		names = append(names, "Endpoints "+item.Namespace+"/"+item.Name)
+	}
+
+	return names
+}
+
+func newPruneTestCluster(remoteConfig *istiov1beta1.RemoteIstio) *Cluster {
+	owned := remoteConfig.GetOwnerLabels()
+	otherRemoteConfig := remoteConfig.DeepCopy()
+	otherRemoteConfig.Name = "other"
+
+	objectMeta := func(namespace, name string, labels map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels}
+	}
+
+	return &Cluster{
+		ctrlRuntimeClient: fake.NewFakeClient([]runtime.Object{
+			// created for the enabled services
+			&corev1.Namespace{ObjectMeta: objectMeta("", "istio-system", owned)},
+			&corev1.Namespace{ObjectMeta: objectMeta("", "apps", owned)},
+			&corev1.Namespace{ObjectMeta: objectMeta("", "legacy", owned)},
+			// existed before
+			&corev1.Namespace{ObjectMeta: objectMeta("", "default", nil)},
+			// desired
+			&corev1.Service{ObjectMeta: objectMeta("istio-system", "istio-pilot", owned)},
+			&corev1.Endpoints{ObjectMeta: objectMeta("istio-system", "istio-pilot", owned)},
+			&corev1.Service{ObjectMeta: objectMeta("apps", "backend", owned)},
+			// not enabled any more
+			&corev1.Service{ObjectMeta: objectMeta("istio-system", "istio-telemetry", owned)},
+			&corev1.Endpoints{ObjectMeta: objectMeta("istio-system", "istio-telemetry", owned)},
+			// enabled in another namespace
+			&corev1.Service{ObjectMeta: objectMeta("istio-system", "backend", owned)},
+			// without addresses
+			&corev1.Endpoints{ObjectMeta: objectMeta("apps", "backend", owned)},
+			// not written by the operator for the RemoteIstio resource
+			&corev1.Service{ObjectMeta: objectMeta("istio-system", "unrelated", nil)},
+			&corev1.Endpoints{ObjectMeta: objectMeta("istio-system", "unrelated", nil)},
+			&corev1.Service{ObjectMeta: objectMeta("istio-system", "other", otherRemoteConfig.GetOwnerLabels())},
+		}...),
+		log: logf.NullLogger{},
+	}
+}
+
+func TestPruneEnabledServices(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	remoteConfig := testRemoteConfig()
+	c := newPruneTestCluster(remoteConfig)
+
+	g.Expect(c.pruneEnabledServices(context.TODO(), remoteConfig, nil)).NotTo(gomega.HaveOccurred())
+	g.Expect(remoteObjects(t, c.ctrlRuntimeClient)).To(gomega.ConsistOf(
+		"Namespace istio-system",
+		"Namespace apps",
+		"Namespace default",
+		"Service istio-system/istio-pilot",
+		"Endpoints istio-system/istio-pilot",
+		"Service apps/backend",
+		"Service istio-system/unrelated",
+		"Endpoints istio-system/unrelated",
+		"Service istio-system/other",
+	))
+}
+
+func TestRemoveEnabledServices(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	remoteConfig := testRemoteConfig()
+	c := newPruneTestCluster(remoteConfig)
+
+	g.Expect(c.removeEnabledServices(context.TODO(), remoteConfig)).NotTo(gomega.HaveOccurred())
+	g.Expect(remoteObjects(t, c.ctrlRuntimeClient)).To(gomega.ConsistOf(
+		"Namespace istio-system",
+		"Namespace default",
+		"Service istio-system/unrelated",
+		"Endpoints istio-system/unrelated",
+		"Service istio-system/other",
+	))
+}
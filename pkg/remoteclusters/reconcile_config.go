@@ -28,11 +28,11 @@ import (
 
 const ConfigName = "istio-config"
 
-func (c *Cluster) reconcileConfig(remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+func (c *Cluster) reconcileConfig(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
 	c.log.Info("reconciling config")
 
 	var istioConfig istiov1beta1.Istio
-	err := c.ctrlRuntimeClient.Get(context.TODO(), types.NamespacedName{
+	err := c.ctrlRuntimeClient.Get(ctx, types.NamespacedName{
 		Name:      ConfigName,
 		Namespace: remoteConfig.Namespace,
 	}, &istioConfig)
@@ -45,6 +45,7 @@ func (c *Cluster) reconcileConfig(remoteConfig *istiov1beta1.RemoteIstio, istio
 		caSecretName = remoteConfig.Spec.Citadel.CASecretName
 	}
 
+	istioConfig.Labels = util.MergeLabels(istioConfig.Labels, remoteConfig.GetOwnerLabels())
 	istioConfig.Spec = istio.Spec
 	istioConfig.Spec.AutoInjectionNamespaces = remoteConfig.Spec.AutoInjectionNamespaces
 	istioConfig.Spec.SidecarInjector.ReplicaCount = remoteConfig.Spec.SidecarInjector.ReplicaCount
@@ -53,6 +54,9 @@ func (c *Cluster) reconcileConfig(remoteConfig *istiov1beta1.RemoteIstio, istio
 	istioConfig.Spec.Citadel.Affinity = remoteConfig.Spec.Citadel.Affinity
 	istioConfig.Spec.Citadel.Tolerations = remoteConfig.Spec.Citadel.Tolerations
 	istioConfig.Spec.Citadel.CASecretName = caSecretName
+	// the remote Citadel signs with the CA of the primary cluster pushed into the CA secret
+	istioConfig.Spec.Citadel.CertManager = nil
+	istioConfig.Spec.Citadel.CARotation = nil
 	istioConfig.Spec.SidecarInjector.NodeSelector = remoteConfig.Spec.SidecarInjector.NodeSelector
 	istioConfig.Spec.SidecarInjector.Affinity = remoteConfig.Spec.SidecarInjector.Affinity
 	istioConfig.Spec.SidecarInjector.Tolerations = remoteConfig.Spec.SidecarInjector.Tolerations
@@ -75,12 +79,12 @@ func (c *Cluster) reconcileConfig(remoteConfig *istiov1beta1.RemoteIstio, istio
 		istioConfig.Name = ConfigName
 		istioConfig.Namespace = remoteConfig.Namespace
 
-		err = c.ctrlRuntimeClient.Create(context.TODO(), &istioConfig)
+		err = c.ctrlRuntimeClient.Create(ctx, &istioConfig)
 		if err != nil {
 			return err
 		}
 	} else {
-		err = c.ctrlRuntimeClient.Update(context.TODO(), &istioConfig)
+		err = c.ctrlRuntimeClient.Update(ctx, &istioConfig)
 		if err != nil {
 			return err
 		}
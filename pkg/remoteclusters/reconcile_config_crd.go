@@ -17,6 +17,8 @@ limitations under the License.
 package remoteclusters
 
 import (
+	"context"
+
 	extensionsobj "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -24,7 +26,7 @@ import (
 	"github.com/banzaicloud/istio-operator/pkg/crds"
 )
 
-func (c *Cluster) reconcileConfigCrd(remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+func (c *Cluster) reconcileConfigCrd(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
 	c.log.Info("reconciling config crd")
 
 	crdo, err := crds.New(c.restConfig, []*extensionsobj.CustomResourceDefinition{
@@ -34,7 +36,7 @@ func (c *Cluster) reconcileConfigCrd(remoteConfig *istiov1beta1.RemoteIstio, ist
 		return err
 	}
 
-	err = crdo.Reconcile(&istiov1beta1.Istio{}, c.log)
+	err = crdo.Reconcile(c.log)
 	if err != nil {
 		return err
 	}
@@ -24,12 +24,12 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
-	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
+	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
-func (c *Cluster) reconcileServiceEndpoints(endp apiv1.Endpoints) error {
+func (c *Cluster) reconcileServiceEndpoints(ctx context.Context, endp apiv1.Endpoints) error {
 	var endpoints apiv1.Endpoints
-	err := c.ctrlRuntimeClient.Get(context.TODO(), types.NamespacedName{
+	err := c.ctrlRuntimeClient.Get(ctx, types.NamespacedName{
 		Name:      endp.Name,
 		Namespace: endp.Namespace,
 	}, &endpoints)
@@ -38,13 +38,14 @@ func (c *Cluster) reconcileServiceEndpoints(endp apiv1.Endpoints) error {
 	}
 
 	if k8sapierrors.IsNotFound(err) {
-		err = c.ctrlRuntimeClient.Create(context.TODO(), &endp)
+		err = c.ctrlRuntimeClient.Create(ctx, &endp)
 		if err != nil {
 			return err
 		}
 	} else {
+		endpoints.Labels = util.MergeLabels(endpoints.Labels, endp.Labels)
 		endpoints.Subsets = endp.Subsets
-		err = c.ctrlRuntimeClient.Update(context.TODO(), &endpoints)
+		err = c.ctrlRuntimeClient.Update(ctx, &endpoints)
 		if err != nil {
 			return err
 		}
@@ -53,7 +54,7 @@ func (c *Cluster) reconcileServiceEndpoints(endp apiv1.Endpoints) error {
 	return nil
 }
 
-func (c *Cluster) ReconcileEnabledServiceEndpoints(remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+func (c *Cluster) ReconcileEnabledServiceEndpoints(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
 	for _, enabledSvc := range remoteConfig.Spec.EnabledServices {
 		addresses := make([]apiv1.EndpointAddress, 0)
 		for _, ip := range enabledSvc.IPs {
@@ -79,7 +80,7 @@ func (c *Cluster) ReconcileEnabledServiceEndpoints(remoteConfig *istiov1beta1.Re
 		}
 
 		endp := apiv1.Endpoints{
-			ObjectMeta: templates.ObjectMeta(enabledSvc.Name, map[string]string{}, c.istioConfig),
+			ObjectMeta: c.enabledServiceObjectMeta(remoteConfig, enabledSvc),
 			Subsets: []apiv1.EndpointSubset{
 				{
 					Addresses: addresses,
@@ -88,7 +89,7 @@ func (c *Cluster) ReconcileEnabledServiceEndpoints(remoteConfig *istiov1beta1.Re
 			},
 		}
 
-		err := c.reconcileServiceEndpoints(endp)
+		err := c.reconcileServiceEndpoints(ctx, endp)
 		if err != nil {
 			return err
 		}
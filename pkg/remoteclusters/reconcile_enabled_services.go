@@ -21,15 +21,17 @@ import (
 
 	apiv1 "k8s.io/api/core/v1"
 	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
+	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
-func (c *Cluster) reconcileService(svc apiv1.Service) error {
+func (c *Cluster) reconcileService(ctx context.Context, svc apiv1.Service) error {
 	var service apiv1.Service
-	err := c.ctrlRuntimeClient.Get(context.TODO(), types.NamespacedName{
+	err := c.ctrlRuntimeClient.Get(ctx, types.NamespacedName{
 		Name:      svc.Name,
 		Namespace: svc.Namespace,
 	}, &service)
@@ -38,13 +40,14 @@ func (c *Cluster) reconcileService(svc apiv1.Service) error {
 	}
 
 	if k8sapierrors.IsNotFound(err) {
-		err = c.ctrlRuntimeClient.Create(context.TODO(), &svc)
+		err = c.ctrlRuntimeClient.Create(ctx, &svc)
 		if err != nil {
 			return err
 		}
 	} else {
+		service.Labels = util.MergeLabels(service.Labels, svc.Labels)
 		service.Spec.Ports = svc.Spec.Ports
-		err = c.ctrlRuntimeClient.Update(context.TODO(), &service)
+		err = c.ctrlRuntimeClient.Update(ctx, &service)
 		if err != nil {
 			return err
 		}
@@ -53,16 +56,20 @@ func (c *Cluster) reconcileService(svc apiv1.Service) error {
 	return nil
 }
 
-func (c *Cluster) reconcileEnabledServices(remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+func (c *Cluster) reconcileEnabledServices(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
 	for _, enabledSvc := range remoteConfig.Spec.EnabledServices {
 		svc := apiv1.Service{
-			ObjectMeta: templates.ObjectMeta(enabledSvc.Name, map[string]string{}, c.istioConfig),
+			ObjectMeta: c.enabledServiceObjectMeta(remoteConfig, enabledSvc),
 			Spec: apiv1.ServiceSpec{
 				ClusterIP: "None",
 				Ports:     enabledSvc.Ports,
 			},
 		}
-		err := c.reconcileService(svc)
+		err := c.reconcileNamespace(ctx, svc.Namespace, remoteConfig)
+		if err != nil {
+			return err
+		}
+		err = c.reconcileService(ctx, svc)
 		if err != nil {
 			return err
 		}
@@ -70,3 +77,43 @@ func (c *Cluster) reconcileEnabledServices(remoteConfig *istiov1beta1.RemoteIsti
 
 	return nil
 }
+
+// reconcileNamespace creates the namespace of an enabled service if it doesn't exist in the remote cluster
+func (c *Cluster) reconcileNamespace(ctx context.Context, name string, remoteConfig *istiov1beta1.RemoteIstio) error {
+	var namespace apiv1.Namespace
+	err := c.ctrlRuntimeClient.Get(ctx, types.NamespacedName{
+		Name: name,
+	}, &namespace)
+	if !k8sapierrors.IsNotFound(err) {
+		return err
+	}
+
+	c.log.Info("creating namespace of enabled service", "namespace", name)
+	namespace = apiv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: remoteConfig.GetOwnerLabels(),
+		},
+	}
+	err = c.ctrlRuntimeClient.Create(ctx, &namespace)
+	if err != nil && !k8sapierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+// enabledServiceObjectMeta returns the metadata of the Service and Endpoints of an enabled service, they can
+// only be owned by the Istio config when they are in its namespace
+func (c *Cluster) enabledServiceObjectMeta(remoteConfig *istiov1beta1.RemoteIstio, svc istiov1beta1.IstioService) metav1.ObjectMeta {
+	namespace := remoteConfig.GetServiceNamespace(svc)
+	if namespace == c.istioConfig.Namespace {
+		return templates.ObjectMeta(svc.Name, remoteConfig.GetOwnerLabels(), c.istioConfig)
+	}
+
+	return metav1.ObjectMeta{
+		Name:      svc.Name,
+		Namespace: namespace,
+		Labels:    remoteConfig.GetOwnerLabels(),
+	}
+}
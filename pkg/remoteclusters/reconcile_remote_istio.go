@@ -17,6 +17,8 @@ limitations under the License.
 package remoteclusters
 
 import (
+	"context"
+
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 	"github.com/banzaicloud/istio-operator/pkg/resources"
 	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
@@ -27,7 +29,7 @@ import (
 	"github.com/banzaicloud/istio-operator/pkg/resources/sidecarinjector"
 )
 
-func (c *Cluster) reconcileComponents(remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+func (c *Cluster) reconcileComponents(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
 	c.log.Info("reconciling components")
 
 	reconcilers := []resources.ComponentReconciler{
@@ -40,6 +42,7 @@ func (c *Cluster) reconcileComponents(remoteConfig *istiov1beta1.RemoteIstio, is
 		sidecarinjector.New(c.ctrlRuntimeClient, c.istioConfig),
 		gateways.New(c.ctrlRuntimeClient, c.dynamicClient, c.istioConfig),
 	}
+	resources.SetEventRecorder(c.recorder, reconcilers...)
 
 	for _, rec := range reconcilers {
 		err := rec.Reconcile(c.log)
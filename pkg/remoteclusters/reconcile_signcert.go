@@ -30,7 +30,7 @@ import (
 
 const CASecretName = "cacerts"
 
-func (c *Cluster) reconcileSignCert(remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
+func (c *Cluster) reconcileSignCert(ctx context.Context, remoteConfig *istiov1beta1.RemoteIstio, istio *istiov1beta1.Istio) error {
 	c.log.Info("reconciling sign cert")
 
 	if remoteConfig.Spec.Citadel.CASecretName != "" {
@@ -38,7 +38,7 @@ func (c *Cluster) reconcileSignCert(remoteConfig *istiov1beta1.RemoteIstio, isti
 	}
 
 	var secret corev1.Secret
-	err := c.ctrlRuntimeClient.Get(context.TODO(), client.ObjectKey{
+	err := c.ctrlRuntimeClient.Get(ctx, client.ObjectKey{
 		Namespace: remoteConfig.Namespace,
 		Name:      CASecretName,
 	}, &secret)
@@ -59,6 +59,7 @@ func (c *Cluster) reconcileSignCert(remoteConfig *istiov1beta1.RemoteIstio, isti
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      CASecretName,
 				Namespace: remoteConfig.Namespace,
+				Labels:    remoteConfig.GetOwnerLabels(),
 			},
 			Type: corev1.SecretTypeOpaque,
 			Data: secretData,
@@ -73,7 +74,7 @@ func (c *Cluster) reconcileSignCert(remoteConfig *istiov1beta1.RemoteIstio, isti
 				BlockOwnerDeletion: util.BoolPointer(true),
 			},
 		})
-		err = c.ctrlRuntimeClient.Create(context.TODO(), &secret)
+		err = c.ctrlRuntimeClient.Create(ctx, &secret)
 		if err != nil {
 			return err
 		}
@@ -81,8 +82,9 @@ func (c *Cluster) reconcileSignCert(remoteConfig *istiov1beta1.RemoteIstio, isti
 		return nil
 	}
 
+	secret.Labels = util.MergeLabels(secret.Labels, remoteConfig.GetOwnerLabels())
 	secret.Data = secretData
-	err = c.ctrlRuntimeClient.Update(context.TODO(), &secret)
+	err = c.ctrlRuntimeClient.Update(ctx, &secret)
 	if err != nil {
 		return err
 	}
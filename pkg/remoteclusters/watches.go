@@ -0,0 +1,194 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+// watchedResource is a kind of object the operator writes into the remote clusters
+type watchedResource struct {
+	gvr schema.GroupVersionResource
+	// name restricts the watch to a single object
+	name string
+	// owned restricts the watch to the objects labelled with the RemoteIstio resource
+	owned bool
+}
+
+var watchedResources = []watchedResource{
+	{
+		gvr:  schema.GroupVersionResource{Group: "istio.banzaicloud.io", Version: "v1beta1", Resource: "istios"},
+		name: ConfigName,
+	},
+	{
+		gvr:  schema.GroupVersionResource{Version: "v1", Resource: "secrets"},
+		name: CASecretName,
+	},
+	{
+		gvr:   schema.GroupVersionResource{Version: "v1", Resource: "services"},
+		owned: true,
+	},
+	{
+		gvr:   schema.GroupVersionResource{Version: "v1", Resource: "endpoints"},
+		owned: true,
+	},
+}
+
+// setWatchEvents sets the channel the changes of the objects reconciled in the cluster are reported on, the
+// watched objects are re-sent periodically as well when the resync period is not zero
+func (c *Cluster) setWatchEvents(events chan<- event.GenericEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.watchEvents = events
+}
+
+// startWatches starts watching the objects reconciled in the cluster, so changes made to them in the
+// remote cluster trigger the reconciliation of the RemoteIstio resource. It is a no-op if the watches are
+// already running.
+func (c *Cluster) startWatches(remoteConfig *istiov1beta1.RemoteIstio) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.watchEvents == nil || c.stopWatchesCh != nil {
+		return
+	}
+
+	// the watches are long running requests, the request timeout would interrupt them
+	restConfig := rest.CopyConfig(c.restConfig)
+	restConfig.Timeout = 0
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		c.log.Error(err, "could not start watches on remote cluster")
+		return
+	}
+
+	stop := make(chan struct{})
+	for _, resource := range watchedResources {
+		resource := resource
+		informer := c.newInformer(dynamicClient, resource, remoteConfig)
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				if resource.gvr.Resource == "istios" && !specChanged(oldObj, newObj) {
+					return
+				}
+				c.objectChanged(resource, newObj, stop)
+			},
+			DeleteFunc: func(obj interface{}) {
+				if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+					obj = tombstone.Obj
+				}
+				c.objectChanged(resource, obj, stop)
+			},
+		})
+		go informer.Run(stop)
+	}
+	c.stopWatchesCh = stop
+
+	c.log.Info("watches started on remote cluster", "namespace", remoteConfig.Namespace)
+}
+
+// stopWatches stops the watches started on the cluster
+func (c *Cluster) stopWatches() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stopWatchesCh == nil {
+		return
+	}
+
+	close(c.stopWatchesCh)
+	c.stopWatchesCh = nil
+
+	c.log.Info("watches stopped on remote cluster")
+}
+
+func (c *Cluster) newInformer(dynamicClient dynamic.Interface, resource watchedResource, remoteConfig *istiov1beta1.RemoteIstio) cache.SharedIndexInformer {
+	namespace := remoteConfig.Namespace
+	if resource.owned {
+		// the enabled services can be in any namespace
+		namespace = metav1.NamespaceAll
+	}
+	client := dynamicClient.Resource(resource.gvr).Namespace(namespace)
+	setSelector := func(options *metav1.ListOptions) {
+		if resource.name != "" {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", resource.name).String()
+		}
+		if resource.owned {
+			options.LabelSelector = labels.SelectorFromSet(remoteConfig.GetOwnerLabels()).String()
+		}
+	}
+
+	return cache.NewSharedIndexInformer(&cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			setSelector(&options)
+			return client.List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			setSelector(&options)
+			return client.Watch(options)
+		},
+	}, &unstructured.Unstructured{}, c.options.ResyncPeriod, cache.Indexers{})
+}
+
+// objectChanged triggers the reconciliation of the RemoteIstio resource of the cluster
+func (c *Cluster) objectChanged(resource watchedResource, obj interface{}, stop <-chan struct{}) {
+	remoteConfig := c.GetRemoteConfig()
+	if remoteConfig == nil {
+		return
+	}
+
+	object, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+
+	c.log.V(1).Info("remote object changed", "resource", resource.gvr.Resource, "name", object.GetName())
+	select {
+	case c.watchEvents <- event.GenericEvent{Meta: remoteConfig, Object: remoteConfig}:
+	case <-stop:
+	}
+}
+
+// specChanged returns whether the spec of an object changed, the status updates of the remote Istio
+// resource are ignored. Periodic resyncs are let through.
+func specChanged(oldObj, newObj interface{}) bool {
+	oldMeta, err := meta.Accessor(oldObj)
+	if err != nil {
+		return true
+	}
+	newMeta, err := meta.Accessor(newObj)
+	if err != nil {
+		return true
+	}
+
+	return oldMeta.GetResourceVersion() == newMeta.GetResourceVersion() ||
+		oldMeta.GetGeneration() != newMeta.GetGeneration()
+}
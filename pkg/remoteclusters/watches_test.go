@@ -0,0 +1,110 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remoteclusters
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func TestSpecChanged(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	object := func(resourceVersion string, generation int64) *istiov1beta1.Istio {
+		return &istiov1beta1.Istio{
+			ObjectMeta: metav1.ObjectMeta{
+				ResourceVersion: resourceVersion,
+				Generation:      generation,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		oldObj  interface{}
+		newObj  interface{}
+		changed bool
+	}{
+		{
+			name:    "spec changed",
+			oldObj:  object("1", 1),
+			newObj:  object("2", 2),
+			changed: true,
+		},
+		{
+			name:   "status changed",
+			oldObj: object("1", 1),
+			newObj: object("2", 1),
+		},
+		{
+			name:    "resync",
+			oldObj:  object("1", 1),
+			newObj:  object("1", 1),
+			changed: true,
+		},
+		{
+			name:    "unknown object",
+			oldObj:  "unknown",
+			newObj:  object("2", 1),
+			changed: true,
+		},
+	}
+
+	for _, test := range tests {
+		g.Expect(specChanged(test.oldObj, test.newObj)).To(gomega.Equal(test.changed), test.name)
+	}
+}
+
+func TestObjectChanged(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	events := make(chan event.GenericEvent, 1)
+	c := &Cluster{
+		log: logf.NullLogger{},
+	}
+	c.setWatchEvents(events)
+	stop := make(chan struct{})
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "istio-pilot"}}
+
+	// changes are not reported before the first successful reconciliation
+	c.objectChanged(watchedResources[2], service, stop)
+	g.Expect(events).NotTo(gomega.Receive())
+
+	remoteConfig := &istiov1beta1.RemoteIstio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "remote",
+			Namespace: "istio-system",
+		},
+	}
+	c.remoteConfig = remoteConfig
+	c.objectChanged(watchedResources[2], service, stop)
+	var e event.GenericEvent
+	g.Expect(events).To(gomega.Receive(&e))
+	g.Expect(e.Object).To(gomega.BeIdenticalTo(remoteConfig))
+
+	// the watches do not block once they are stopped
+	close(stop)
+	c.objectChanged(watchedResources[2], service, stop)
+	c.objectChanged(watchedResources[2], service, stop)
+}
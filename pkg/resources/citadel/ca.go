@@ -0,0 +1,156 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// caCertHashAnnotation changes on the pod template of Citadel when its plugged in CA changes, so Citadel
+	// is restarted to pick it up
+	caCertHashAnnotation = "istio.banzaicloud.io/ca-cert-hash"
+
+	// workloadSecretType is the type of the secrets Citadel writes the workload certificates into
+	workloadSecretType = "istio.io/key-and-cert"
+)
+
+// caKeys are the keys of the plugged in CA secret Citadel reads
+var caKeys = []string{"ca-cert.pem", "ca-key.pem", "root-cert.pem", "cert-chain.pem"}
+
+// CASecretHash identifies the content of a CA secret in the layout Citadel reads
+func CASecretHash(secret *apiv1.Secret) string {
+	h := sha256.New()
+	for _, key := range caKeys {
+		h.Write([]byte(key))
+		h.Write(secret.Data[key])
+	}
+
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// IsDeploymentUsingCA returns whether the pods of the Citadel deployment are started with the content of the
+// given CA secret
+func IsDeploymentUsingCA(deployment *appsv1.Deployment, secret *apiv1.Secret) bool {
+	return deployment.Spec.Template.Annotations[caCertHashAnnotation] == CASecretHash(secret)
+}
+
+// caSecretHash returns the hash of the plugged in CA of Citadel, it is empty for the self-signed CA or
+// if the secret does not exist yet
+func (r *Reconciler) caSecretHash() (string, error) {
+	name := GetCASecretName(r.Config)
+	if name == SelfSignedCASecretName {
+		return "", nil
+	}
+
+	var secret apiv1.Secret
+	err := r.Client.Get(context.TODO(), client.ObjectKey{
+		Namespace: r.Config.Namespace,
+		Name:      name,
+	}, &secret)
+	if k8serrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", emperror.WrapWith(err, "could not get CA secret", "name", name)
+	}
+
+	return CASecretHash(&secret), nil
+}
+
+// caData returns the CA of a secret in the layout Citadel reads, the self-signed CA has no separate root
+// certificate and chain
+func caData(secret *apiv1.Secret) (map[string][]byte, error) {
+	data := make(map[string][]byte)
+	for _, key := range caKeys {
+		data[key] = secret.Data[key]
+	}
+	if len(data["ca-cert.pem"]) == 0 || len(data["ca-key.pem"]) == 0 {
+		return nil, errors.Errorf("CA certificate or key is missing from secret %s", secret.Name)
+	}
+	if len(data["root-cert.pem"]) == 0 {
+		data["root-cert.pem"] = data["ca-cert.pem"]
+	}
+	if len(data["cert-chain.pem"]) == 0 {
+		data["cert-chain.pem"] = data["ca-cert.pem"]
+	}
+
+	return data, nil
+}
+
+// trustBundle concatenates the root certificates, the duplicates are left out
+func trustBundle(roots ...[]byte) []byte {
+	var bundle []byte
+	for _, root := range roots {
+		root = bytes.TrimSpace(root)
+		if len(root) == 0 || bytes.Contains(bundle, root) {
+			continue
+		}
+		bundle = append(bundle, root...)
+		bundle = append(bundle, '\n')
+	}
+
+	return bundle
+}
+
+// PendingWorkloadCerts returns the number of workload certificates written by Citadel which are not signed by
+// the given CA certificate
+func PendingWorkloadCerts(ctx context.Context, c client.Client, caCert []byte) (int, error) {
+	block, _ := pem.Decode(caCert)
+	if block == nil {
+		return 0, errors.New("invalid CA certificate")
+	}
+	ca, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return 0, emperror.Wrap(err, "invalid CA certificate")
+	}
+
+	var secrets apiv1.SecretList
+	err = c.List(ctx, &client.ListOptions{}, &secrets)
+	if err != nil {
+		return 0, emperror.Wrap(err, "could not list secrets")
+	}
+
+	pending := 0
+	for _, secret := range secrets.Items {
+		if secret.Type != workloadSecretType {
+			continue
+		}
+		block, _ := pem.Decode(secret.Data["cert-chain.pem"])
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil || cert.CheckSignatureFrom(ca) != nil {
+			pending++
+		}
+	}
+
+	return pending, nil
+}
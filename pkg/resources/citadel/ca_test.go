@@ -0,0 +1,152 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestTrustBundle(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, _, oldRoot := testCA(t, "old", nil, nil)
+	_, _, newRoot := testCA(t, "new", nil, nil)
+	bundle := func(roots ...[]byte) []byte {
+		var b []byte
+		for _, root := range roots {
+			b = append(b, root...)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name     string
+		roots    [][]byte
+		expected []byte
+	}{
+		{
+			name:     "the order of the roots is kept",
+			roots:    [][]byte{oldRoot, newRoot},
+			expected: bundle(oldRoot, newRoot),
+		},
+		{
+			name:     "the new root first",
+			roots:    [][]byte{newRoot, oldRoot},
+			expected: bundle(newRoot, oldRoot),
+		},
+		{
+			name:     "duplicates are left out",
+			roots:    [][]byte{oldRoot, oldRoot, newRoot, oldRoot},
+			expected: bundle(oldRoot, newRoot),
+		},
+		{
+			name:     "duplicates differing in whitespace are left out",
+			roots:    [][]byte{append([]byte("\n"), oldRoot...), oldRoot},
+			expected: oldRoot,
+		},
+		{
+			name:     "empty roots are left out",
+			roots:    [][]byte{nil, newRoot, []byte("\n")},
+			expected: newRoot,
+		},
+	}
+
+	for _, test := range tests {
+		g.Expect(trustBundle(test.roots...)).To(gomega.Equal(test.expected), test.name)
+	}
+}
+
+func TestPendingWorkloadCerts(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	oldCA, oldKey, _ := testCA(t, "old", nil, nil)
+	newCA, newKey, newCAPEM := testCA(t, "new", nil, nil)
+	_, _, oldCert := testCA(t, "workload", oldCA, oldKey)
+	_, _, newCert := testCA(t, "workload", newCA, newKey)
+
+	workloadSecret := func(namespace, name string, certType string, chain []byte) runtime.Object {
+		return &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Type: apiv1.SecretType(certType),
+			Data: map[string][]byte{
+				"cert-chain.pem": chain,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		objects  []runtime.Object
+		expected int
+	}{
+		{
+			name:     "no workload certificates",
+			expected: 0,
+		},
+		{
+			name: "signed by the new CA",
+			objects: []runtime.Object{
+				workloadSecret("default", "istio.default", workloadSecretType, newCert),
+				workloadSecret("other", "istio.default", workloadSecretType, newCert),
+			},
+			expected: 0,
+		},
+		{
+			name: "signed by the old CA in every namespace",
+			objects: []runtime.Object{
+				workloadSecret("default", "istio.default", workloadSecretType, oldCert),
+				workloadSecret("default", "istio.app", workloadSecretType, newCert),
+				workloadSecret("other", "istio.default", workloadSecretType, oldCert),
+			},
+			expected: 2,
+		},
+		{
+			name: "invalid certificates are pending",
+			objects: []runtime.Object{
+				workloadSecret("default", "istio.default", workloadSecretType, []byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n")),
+			},
+			expected: 1,
+		},
+		{
+			name: "other secrets and empty chains are skipped",
+			objects: []runtime.Object{
+				workloadSecret("default", "tls", string(apiv1.SecretTypeTLS), oldCert),
+				workloadSecret("default", "istio.default", workloadSecretType, nil),
+			},
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		pending, err := PendingWorkloadCerts(context.TODO(), fake.NewFakeClient(test.objects...), newCAPEM)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+		g.Expect(pending).To(gomega.Equal(test.expected), test.name)
+	}
+
+	_, err := PendingWorkloadCerts(context.TODO(), fake.NewFakeClient(), []byte("not a certificate"))
+	g.Expect(err).To(gomega.HaveOccurred())
+}
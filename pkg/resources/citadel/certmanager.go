@@ -0,0 +1,202 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+const (
+	caCertificateName = "istio-ca"
+
+	// CertManagerIssuedSecretName is the secret cert-manager stores the CA certificate of Citadel in
+	CertManagerIssuedSecretName = "istio-ca-cert-manager"
+	// CertManagerCASecretName is the secret the certificate issued by cert-manager is mounted to Citadel from
+	CertManagerCASecretName = "istio-ca-cert-manager-cacerts"
+
+	certManagerCAKey = "ca.crt"
+)
+
+func (r *Reconciler) certManagerEnabled() bool {
+	return util.PointerToBool(r.Config.Spec.Citadel.Enabled) && r.Config.Spec.Citadel.CertManager != nil
+}
+
+// caCertificate returns the cert-manager Certificate of the intermediate CA Citadel signs the workload
+// certificates with
+func (r *Reconciler) caCertificate() *k8sutil.DynamicObject {
+	spec := map[string]interface{}{
+		"secretName":   CertManagerIssuedSecretName,
+		"commonName":   fmt.Sprintf("%s.%s", caCertificateName, r.Config.Namespace),
+		"organization": []interface{}{"cluster.local"},
+		"isCA":         true,
+		"keyAlgorithm": "rsa",
+		"keySize":      int64(2048),
+	}
+
+	if certManager := r.Config.Spec.Citadel.CertManager; certManager != nil {
+		spec["issuerRef"] = map[string]interface{}{
+			"name": certManager.IssuerRef.Name,
+			"kind": certManager.IssuerRef.Kind,
+		}
+		if certManager.Duration != "" {
+			spec["duration"] = certManager.Duration
+		}
+		if certManager.RenewBefore != "" {
+			spec["renewBefore"] = certManager.RenewBefore
+		}
+	}
+
+	return &k8sutil.DynamicObject{
+		Gvr: schema.GroupVersionResource{
+			Group:    "certmanager.k8s.io",
+			Version:  "v1alpha1",
+			Resource: "certificates",
+		},
+		Kind:      "Certificate",
+		Name:      caCertificateName,
+		Namespace: r.Config.Namespace,
+		Labels:    citadelLabels,
+		Spec:      spec,
+		Owner:     r.Config,
+	}
+}
+
+// certManagerDesiredState returns the desired state of the cert-manager Certificate and the CA secret copied from it
+func (r *Reconciler) certManagerDesiredState() k8sutil.DesiredState {
+	if r.certManagerEnabled() {
+		return k8sutil.DesiredStatePresent
+	}
+
+	return k8sutil.DesiredStateAbsent
+}
+
+// certManagerCASecret returns the secret Citadel reads the CA issued by cert-manager from
+func (r *Reconciler) certManagerCASecret() runtime.Object {
+	return &apiv1.Secret{
+		ObjectMeta: templates.ObjectMeta(CertManagerCASecretName, citadelLabels, r.Config),
+		Type:       apiv1.SecretTypeOpaque,
+		Data:       r.certManagerCAData,
+	}
+}
+
+// reconcileCertManagerCA requests the CA certificate from cert-manager and copies it to a secret in the layout
+// Citadel expects. It fails until cert-manager issues the certificate. The CA is reconciled ahead of the other
+// resources of Citadel as its deployment and the CA rotation read the copied secret.
+func (r *Reconciler) reconcileCertManagerCA(log logr.Logger) error {
+	desiredState := r.certManagerDesiredState()
+
+	err := r.caCertificate().Reconcile(log, r.dynamic, desiredState, r.Recorder)
+	if err != nil {
+		return emperror.Wrap(err, "failed to reconcile CA certificate")
+	}
+
+	if desiredState == k8sutil.DesiredStatePresent {
+		issued, err := r.loadCertManagerCA()
+		if err != nil {
+			return err
+		}
+		if !issued {
+			return errors.New("waiting for cert-manager to issue the CA certificate")
+		}
+	}
+
+	return k8sutil.Reconcile(log, r.Client, r.certManagerCASecret(), desiredState, r.Recorder)
+}
+
+// loadCertManagerCA reads the CA certificate issued by cert-manager, it returns false if it is not issued yet
+func (r *Reconciler) loadCertManagerCA() (bool, error) {
+	var issued apiv1.Secret
+	err := r.Client.Get(context.TODO(), client.ObjectKey{
+		Namespace: r.Config.Namespace,
+		Name:      CertManagerIssuedSecretName,
+	}, &issued)
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, emperror.Wrap(err, "could not get the CA certificate issued by cert-manager")
+	}
+
+	r.certManagerCAData, err = citadelCASecretData(&issued)
+	if err != nil {
+		return false, emperror.WrapWith(err, "invalid CA certificate issued by cert-manager", "secret", CertManagerIssuedSecretName)
+	}
+
+	return true, nil
+}
+
+// citadelCASecretData returns the signing certificate, key, root certificate and certificate chain of
+// Citadel from a certificate issued by cert-manager
+func citadelCASecretData(issued *apiv1.Secret) (map[string][]byte, error) {
+	certs := issued.Data[apiv1.TLSCertKey]
+	key := issued.Data[apiv1.TLSPrivateKeyKey]
+	if len(certs) == 0 || len(key) == 0 {
+		return nil, errors.New("certificate or key is missing")
+	}
+
+	var blocks []*pem.Block
+	for rest := certs; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type == "CERTIFICATE" {
+			blocks = append(blocks, block)
+		}
+	}
+	if len(blocks) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+
+	// the root certificate is either provided by the issuer or it is the last one of the chain
+	root := issued.Data[certManagerCAKey]
+	if len(root) == 0 {
+		if len(blocks) < 2 {
+			return nil, errors.New("root certificate is missing")
+		}
+		root = pem.EncodeToMemory(blocks[len(blocks)-1])
+	}
+
+	chain := certs
+	if !bytes.Contains(chain, bytes.TrimSpace(root)) {
+		chain = append(append(append([]byte{}, bytes.TrimSpace(chain)...), '\n'), root...)
+	}
+
+	return map[string][]byte{
+		"ca-cert.pem":    pem.EncodeToMemory(blocks[0]),
+		"ca-key.pem":     key,
+		"root-cert.pem":  root,
+		"cert-chain.pem": chain,
+	}, nil
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+)
+
+// testCA returns a CA certificate with its PEM encoding, signed by the parent or self-signed if the parent is nil
+func testCA(t *testing.T, commonName string, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, crypto.Signer, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, certPEM := testCAWithKey(t, commonName, key, parent, parentKey)
+
+	return cert, key, certPEM
+}
+
+// testCAWithKey returns a CA certificate of the given key with its PEM encoding, signed by the parent or
+// self-signed if the parent is nil
+func testCAWithKey(t *testing.T, commonName string, key crypto.Signer, parent *x509.Certificate, parentKey crypto.Signer) (*x509.Certificate, []byte) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+// testKeyPEM returns the PEM encoding of an EC private key
+func testKeyPEM(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalECPrivateKey(key.(*ecdsa.PrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func TestCitadelCASecretData(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	root, rootKey, rootPEM := testCA(t, "root", nil, nil)
+	_, key, intermediatePEM := testCA(t, "istio-ca", root, rootKey)
+	keyPEM := testKeyPEM(t, key)
+	chainPEM := append(append([]byte{}, intermediatePEM...), rootPEM...)
+
+	tests := []struct {
+		name      string
+		data      map[string][]byte
+		wantErr   bool
+		wantRoot  []byte
+		wantChain []byte
+	}{
+		{
+			name: "root at the end of the chain",
+			data: map[string][]byte{
+				apiv1.TLSCertKey:       chainPEM,
+				apiv1.TLSPrivateKeyKey: keyPEM,
+			},
+			wantRoot:  rootPEM,
+			wantChain: chainPEM,
+		},
+		{
+			name: "root provided by the issuer",
+			data: map[string][]byte{
+				apiv1.TLSCertKey:       intermediatePEM,
+				apiv1.TLSPrivateKeyKey: keyPEM,
+				certManagerCAKey:       rootPEM,
+			},
+			wantRoot:  rootPEM,
+			wantChain: chainPEM,
+		},
+		{
+			name: "root provided by the issuer and in the chain",
+			data: map[string][]byte{
+				apiv1.TLSCertKey:       chainPEM,
+				apiv1.TLSPrivateKeyKey: keyPEM,
+				certManagerCAKey:       rootPEM,
+			},
+			wantRoot:  rootPEM,
+			wantChain: chainPEM,
+		},
+		{
+			name: "missing root",
+			data: map[string][]byte{
+				apiv1.TLSCertKey:       intermediatePEM,
+				apiv1.TLSPrivateKeyKey: keyPEM,
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing key",
+			data: map[string][]byte{
+				apiv1.TLSCertKey: chainPEM,
+			},
+			wantErr: true,
+		},
+		{
+			name: "no certificate",
+			data: map[string][]byte{
+				apiv1.TLSCertKey:       []byte("not a certificate"),
+				apiv1.TLSPrivateKeyKey: keyPEM,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		data, err := citadelCASecretData(&apiv1.Secret{Data: test.data})
+		if test.wantErr {
+			g.Expect(err).To(gomega.HaveOccurred(), test.name)
+			continue
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+		g.Expect(data).To(gomega.HaveLen(4), test.name)
+		g.Expect(data["ca-cert.pem"]).To(gomega.Equal(intermediatePEM), test.name)
+		g.Expect(data["ca-key.pem"]).To(gomega.Equal(keyPEM), test.name)
+		g.Expect(data["root-cert.pem"]).To(gomega.Equal(test.wantRoot), test.name)
+		g.Expect(bytes.TrimSpace(data["cert-chain.pem"])).To(gomega.Equal(bytes.TrimSpace(test.wantChain)), test.name)
+	}
+}
+
+func TestCACertificate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system"},
+		Spec: istiov1beta1.IstioSpec{
+			Citadel: istiov1beta1.CitadelConfiguration{
+				CertManager: &istiov1beta1.CitadelCertManagerConfiguration{
+					IssuerRef: istiov1beta1.CertManagerIssuerReference{Name: "ca-issuer", Kind: "ClusterIssuer"},
+				},
+			},
+		},
+	}
+
+	spec := New(Configuration{}, nil, nil, config).caCertificate().Spec
+	g.Expect(spec).To(gomega.HaveKeyWithValue("commonName", "istio-ca.istio-system"))
+	g.Expect(spec).To(gomega.HaveKeyWithValue("organization", []interface{}{"cluster.local"}))
+	g.Expect(spec).To(gomega.HaveKeyWithValue("isCA", true))
+	g.Expect(spec).To(gomega.HaveKeyWithValue("issuerRef", map[string]interface{}{"name": "ca-issuer", "kind": "ClusterIssuer"}))
+}
+
+func TestPlanCertManagerCA(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	root, rootKey, rootPEM := testCA(t, "root", nil, nil)
+	_, key, intermediatePEM := testCA(t, "istio-ca", root, rootKey)
+	issued := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: CertManagerIssuedSecretName, Namespace: "istio-system"},
+		Data: map[string][]byte{
+			apiv1.TLSCertKey:       append(append([]byte{}, intermediatePEM...), rootPEM...),
+			apiv1.TLSPrivateKeyKey: testKeyPEM(t, key),
+		},
+	}
+	certificate := k8sutil.PlannedAction{
+		Action:     k8sutil.ActionCreate,
+		APIVersion: "certmanager.k8s.io/v1alpha1",
+		Kind:       "Certificate",
+		Namespace:  "istio-system",
+		Name:       caCertificateName,
+	}
+	caSecret := k8sutil.PlannedAction{
+		Action:     k8sutil.ActionCreate,
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  "istio-system",
+		Name:       CertManagerCASecretName,
+	}
+
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		wantSecret bool
+	}{
+		{
+			name: "certificate not issued yet",
+		},
+		{
+			name:       "certificate issued",
+			objects:    []runtime.Object{issued},
+			wantSecret: true,
+		},
+	}
+
+	for _, test := range tests {
+		config := &istiov1beta1.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system"},
+			Spec: istiov1beta1.IstioSpec{
+				Version: "1.3.5",
+				Citadel: istiov1beta1.CitadelConfiguration{
+					CertManager: &istiov1beta1.CitadelCertManagerConfiguration{
+						IssuerRef: istiov1beta1.CertManagerIssuerReference{Name: "ca-issuer", Kind: "ClusterIssuer"},
+					},
+				},
+			},
+		}
+		istiov1beta1.SetDefaults(config)
+
+		c := fake.NewFakeClient(test.objects...)
+		actions, err := New(Configuration{}, c, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), config).Plan()
+		g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+		g.Expect(actions).To(gomega.ContainElement(certificate), test.name)
+		if test.wantSecret {
+			g.Expect(actions).To(gomega.ContainElement(caSecret), test.name)
+		} else {
+			g.Expect(actions).NotTo(gomega.ContainElement(caSecret), test.name)
+		}
+
+		// the CA secret is only planned
+		err = c.Get(context.TODO(), client.ObjectKey{Namespace: "istio-system", Name: CertManagerCASecretName}, &apiv1.Secret{})
+		g.Expect(k8serrors.IsNotFound(err)).To(gomega.BeTrue(), test.name)
+	}
+}
@@ -19,6 +19,8 @@ package citadel
 import (
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -53,6 +55,10 @@ type Reconciler struct {
 	dynamic dynamic.Interface
 
 	configuration Configuration
+	// caCertHash identifies the content of the plugged in CA secret
+	caCertHash string
+	// certManagerCAData is the CA issued by cert-manager in the layout Citadel reads
+	certManagerCAData map[string][]byte
 }
 
 func New(configuration Configuration, client client.Client, dc dynamic.Interface, config *istiov1beta1.Istio) *Reconciler {
@@ -70,11 +76,119 @@ func GetDeploymentName() string {
 	return deploymentName
 }
 
+// GetCASecretName returns the name of the secret Citadel reads its signing CA from
+func GetCASecretName(config *istiov1beta1.Istio) string {
+	if config.Spec.Citadel.CARotation != nil {
+		return CARotationSecretName
+	}
+
+	return getCurrentCASecretName(config)
+}
+
+// getCurrentCASecretName returns the name of the secret of the CA Citadel is rotated from
+func getCurrentCASecretName(config *istiov1beta1.Istio) string {
+	switch {
+	case config.Spec.Citadel.CertManager != nil:
+		return CertManagerCASecretName
+	case config.Spec.Citadel.CASecretName != "":
+		return config.Spec.Citadel.CASecretName
+	default:
+		return SelfSignedCASecretName
+	}
+}
+
 func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 
 	log.Info("Reconciling")
 
+	// the Citadels of the meshes would overwrite the service account secrets of each other
+	if util.PointerToBool(r.Config.Spec.Citadel.Enabled) && len(r.Config.Spec.GetMeshes()) > 1 && !r.Config.Spec.Version.IsAtLeast("1.3") {
+		return errors.New("Citadel is only limited to the namespaces of its mesh from Istio 1.3, multiple meshes require Istio 1.3 or later")
+	}
+
+	// the CA has to be in place before Citadel is started
+	err := r.reconcileCertManagerCA(log)
+	if err != nil {
+		return err
+	}
+	err = r.reconcileCARotation(log)
+	if err != nil {
+		return err
+	}
+	// Citadel is restarted when its plugged in CA changes
+	r.caCertHash, err = r.caSecretHash()
+	if err != nil {
+		return err
+	}
+
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	for _, dr := range r.DynamicResources() {
+		o := dr.DynamicResource()
+		err := o.Reconcile(log, r.dynamic, dr.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile dynamic resource", "resource", o.Gvr)
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// Plan returns the changes of the objects of the component and of the CA secrets Citadel reads without
+// applying them
+func (r *Reconciler) Plan() ([]k8sutil.PlannedAction, error) {
+	// the CA secret is only planned once cert-manager issued the certificate
+	if r.certManagerEnabled() {
+		if _, err := r.loadCertManagerCA(); err != nil {
+			return nil, err
+		}
+	}
+
+	rotationSecret, rotationDesiredState, err := r.caRotationSecret()
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not plan CA rotation")
+	}
+	actions := make([]k8sutil.PlannedAction, 0)
+	action, err := k8sutil.Plan(r.Client, rotationSecret, rotationDesiredState)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not plan CA rotation")
+	}
+	if action != nil {
+		actions = append(actions, *action)
+	}
+
+	// the hash of the CA is calculated from the secrets which would be written
+	switch GetCASecretName(r.Config) {
+	case CARotationSecretName:
+		r.caCertHash = CASecretHash(rotationSecret)
+	case CertManagerCASecretName:
+		r.caCertHash = CASecretHash(r.certManagerCASecret().(*apiv1.Secret))
+	default:
+		r.caCertHash, err = r.caSecretHash()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rendered, err := resources.PlanResources(r.Client, r.dynamic, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(actions, rendered...), nil
+}
+
+// Resources returns the typed objects of the component with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
 	var citadelDesiredState k8sutil.DesiredState
 	if util.PointerToBool(r.Config.Spec.Citadel.Enabled) {
 		citadelDesiredState = k8sutil.DesiredStatePresent
@@ -82,22 +196,29 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		citadelDesiredState = k8sutil.DesiredStateAbsent
 	}
 
-	for _, res := range []resources.Resource{
-		r.serviceAccount,
-		r.clusterRole,
-		r.clusterRoleBinding,
-		r.deployment,
-		r.service,
-	} {
-		o := res()
-		err := k8sutil.Reconcile(log, r.Client, o, citadelDesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
-		}
+	rs := make([]resources.ResourceWithDesiredState, 0)
+	// the content of the CA secret is copied from the certificate issued by cert-manager, it is only known
+	// once the certificate is issued
+	if !r.certManagerEnabled() || r.certManagerCAData != nil {
+		rs = append(rs, resources.ResourceWithDesiredState{Resource: r.certManagerCASecret, DesiredState: r.certManagerDesiredState()})
 	}
 
+	return append(rs, []resources.ResourceWithDesiredState{
+		{Resource: r.serviceAccount, DesiredState: citadelDesiredState},
+		{Resource: r.clusterRole, DesiredState: citadelDesiredState},
+		{Resource: r.clusterRoleBinding, DesiredState: citadelDesiredState},
+		{Resource: r.deployment, DesiredState: citadelDesiredState},
+		{Resource: r.service, DesiredState: citadelDesiredState},
+	}...)
+}
+
+// DynamicResources returns the dynamic objects of the component with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
+	rs := []resources.DynamicResourceWithDesiredState{
+		{DynamicResource: r.caCertificate, DesiredState: r.certManagerDesiredState()},
+	}
 	if !r.configuration.DeployMeshPolicy {
-		return nil
+		return rs
 	}
 
 	var meshExpansionDesiredState k8sutil.DesiredState
@@ -121,22 +242,11 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		meshPolicyDesiredState = k8sutil.DesiredStateAbsent
 		mTLSDesiredState = k8sutil.DesiredStateAbsent
 	}
-	drs := []resources.DynamicResourceWithDesiredState{
+
+	return append(rs, []resources.DynamicResourceWithDesiredState{
 		{DynamicResource: r.meshPolicy, DesiredState: meshPolicyDesiredState},
 		{DynamicResource: r.destinationRuleDefaultMtls, DesiredState: mTLSDesiredState},
 		{DynamicResource: r.destinationRuleApiServerMtls, DesiredState: mTLSDesiredState},
 		{DynamicResource: r.meshExpansion, DesiredState: meshExpansionDesiredState},
-	}
-
-	for _, dr := range drs {
-		o := dr.DynamicResource()
-		err := o.Reconcile(log, r.dynamic, dr.DesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile dynamic resource", "resource", o.Gvr)
-		}
-	}
-
-	log.Info("Reconciled")
-
-	return nil
+	}...)
 }
@@ -18,6 +18,7 @@ package citadel
 
 import (
 	"fmt"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	apiv1 "k8s.io/api/core/v1"
@@ -28,21 +29,48 @@ import (
 	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
+func (r *Reconciler) customDNSNames() string {
+	dnsNames := []string{
+		fmt.Sprintf("istio-pilot-service-account.%[1]s:istio-pilot.%[1]s", r.Config.Namespace),
+	}
+
+	// the sidecar injectors of the control plane revisions are served through their own services
+	for _, revision := range r.Config.Spec.GetRevisionNames() {
+		dnsNames = append(dnsNames, fmt.Sprintf("istio-sidecar-injector-service-account-%[1]s.%[2]s:istio-sidecar-injector-%[1]s.%[2]s.svc", revision, r.Config.Namespace))
+	}
+
+	return strings.Join(dnsNames, ",")
+}
+
 func (r *Reconciler) deployment() runtime.Object {
 	var args []string
 	if util.PointerToBool(r.Config.Spec.SDS.Enabled) {
 		args = append(args, "--sds-enabled=true")
 	}
 
+	// the node agents request the workload certificates from Vault instead of the gRPC server of Citadel
+	grpcPort := 8060
+	if r.Config.Spec.NodeAgent.IsVaultCAProvider() {
+		grpcPort = 0
+	}
+
+	// the Citadels of the other meshes only manage the namespaces labelled for them, the default mesh also manages
+	// the namespaces without a label
+	if !r.Config.IsDefaultMesh() {
+		args = append(args, "--explicit-opt-in-required=true")
+	}
+
 	args = append(args,
 		"--append-dns-names=true",
-		"--grpc-port=8060",
+		fmt.Sprintf("--grpc-port=%d", grpcPort),
 		fmt.Sprintf("--citadel-storage-namespace=%s", r.Config.Namespace),
-		fmt.Sprintf("--custom-dns-names=istio-pilot-service-account.%[1]s:istio-pilot.%[1]s", r.Config.Namespace),
+		fmt.Sprintf("--custom-dns-names=%s", r.customDNSNames()),
 		"--monitoring-port=15014",
 	)
 
-	if r.Config.Spec.Citadel.CASecretName == "" {
+	caSecretName := GetCASecretName(r.Config)
+	selfSignedCA := caSecretName == SelfSignedCASecretName
+	if selfSignedCA {
 		args = append(args, "--self-signed-ca=true")
 	} else {
 		args = append(args,
@@ -109,7 +137,7 @@ func (r *Reconciler) deployment() runtime.Object {
 		}
 	}
 
-	if r.Config.Spec.Citadel.CASecretName != "" {
+	if !selfSignedCA {
 		citadelContainer.VolumeMounts = []apiv1.VolumeMount{
 			{
 				Name:      "cacerts",
@@ -135,13 +163,13 @@ func (r *Reconciler) deployment() runtime.Object {
 	}
 
 	var optional = false
-	if r.Config.Spec.Citadel.CASecretName != "" {
+	if !selfSignedCA {
 		podSpec.Volumes = []apiv1.Volume{
 			{
 				Name: "cacerts",
 				VolumeSource: apiv1.VolumeSource{
 					Secret: &apiv1.SecretVolumeSource{
-						SecretName:  r.Config.Spec.Citadel.CASecretName,
+						SecretName:  caSecretName,
 						Optional:    &optional,
 						DefaultMode: util.IntPointer(420),
 					},
@@ -150,6 +178,13 @@ func (r *Reconciler) deployment() runtime.Object {
 		}
 	}
 
+	annotations := templates.DefaultDeployAnnotations()
+	if r.caCertHash != "" {
+		annotations = util.MergeLabels(annotations, map[string]string{
+			caCertHashAnnotation: r.caCertHash,
+		})
+	}
+
 	var deployment = &appsv1.Deployment{
 		ObjectMeta: templates.ObjectMeta(deploymentName, util.MergeLabels(citadelLabels, labelSelector), r.Config),
 		Spec: appsv1.DeploymentSpec{
@@ -161,7 +196,7 @@ func (r *Reconciler) deployment() runtime.Object {
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels:      util.MergeLabels(citadelLabels, labelSelector),
-					Annotations: templates.DefaultDeployAnnotations(),
+					Annotations: annotations,
 				},
 				Spec: podSpec,
 			},
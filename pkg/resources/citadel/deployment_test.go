@@ -0,0 +1,89 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func TestDeploymentOfMeshes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		meshes        []string
+		explicitOptIn bool
+	}{
+		{
+			name:      "single mesh",
+			namespace: "istio-system",
+			meshes:    []string{"istio-system"},
+		},
+		{
+			name:      "default mesh",
+			namespace: "istio-system",
+			meshes:    []string{"istio-system", "mesh-b"},
+		},
+		{
+			name:          "other mesh",
+			namespace:     "mesh-b",
+			meshes:        []string{"istio-system", "mesh-b"},
+			explicitOptIn: true,
+		},
+	}
+
+	for _, test := range tests {
+		config := &istiov1beta1.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: test.namespace},
+			Spec:       istiov1beta1.IstioSpec{Version: "1.3.5"},
+		}
+		istiov1beta1.SetDefaults(config)
+		config.Spec.SetMeshes(test.meshes)
+
+		deployment := New(Configuration{}, nil, nil, config).deployment().(*appsv1.Deployment)
+		args := deployment.Spec.Template.Spec.Containers[0].Args
+		if test.explicitOptIn {
+			g.Expect(args).To(gomega.ContainElement("--explicit-opt-in-required=true"), test.name)
+		} else {
+			g.Expect(args).NotTo(gomega.ContainElement("--explicit-opt-in-required=true"), test.name)
+		}
+	}
+}
+
+func TestReconcileMeshesOfIstio12(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// Citadel 1.2 manages every namespace, the meshes would fight over the service account secrets
+	config := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "mesh-b"},
+		Spec:       istiov1beta1.IstioSpec{Version: "1.2.5"},
+	}
+	istiov1beta1.SetDefaults(config)
+	config.Spec.SetMeshes([]string{"istio-system", "mesh-b"})
+
+	err := New(Configuration{}, fake.NewFakeClient(), nil, config).Reconcile(logf.NullLogger{})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("multiple meshes require Istio 1.3")))
+}
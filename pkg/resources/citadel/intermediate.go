@@ -0,0 +1,185 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"time"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const intermediateCAKeySize = 2048
+
+// IntermediateCA is a CA issued by the CA of Citadel for the Citadel of a remote cluster
+type IntermediateCA struct {
+	// Data holds the intermediate CA in the layout Citadel reads
+	Data        map[string][]byte
+	Certificate *x509.Certificate
+}
+
+// IssueIntermediateCA issues an intermediate CA signed by the CA in the given secret, its lifetime does not
+// exceed the one of the signing CA
+func IssueIntermediateCA(signer *apiv1.Secret, commonName string, duration time.Duration) (*IntermediateCA, error) {
+	signerData, err := caData(signer)
+	if err != nil {
+		return nil, err
+	}
+	signerCert, err := parseCertificate(signerData["ca-cert.pem"])
+	if err != nil {
+		return nil, emperror.Wrap(err, "invalid signing CA certificate")
+	}
+	signerKey, err := parsePrivateKey(signerData["ca-key.pem"])
+	if err != nil {
+		return nil, emperror.Wrap(err, "invalid signing CA key")
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, intermediateCAKeySize)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not generate key")
+	}
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not generate serial number")
+	}
+
+	notBefore := time.Now().Add(-time.Minute)
+	notAfter := notBefore.Add(duration)
+	if notAfter.After(signerCert.NotAfter) {
+		notAfter = signerCert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{"cluster.local"},
+		},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not sign intermediate CA certificate")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not parse intermediate CA certificate")
+	}
+
+	data := map[string][]byte{
+		"ca-cert.pem": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		"ca-key.pem":  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+	}
+	setIntermediateCAChain(data, signerData)
+
+	return &IntermediateCA{
+		Data:        data,
+		Certificate: cert,
+	}, nil
+}
+
+// RefreshIntermediateCA returns the intermediate CA in the given secret with the root certificates and chain of
+// the signing CA, it fails if the intermediate CA is not signed by the signing CA
+func RefreshIntermediateCA(intermediate *apiv1.Secret, signer *apiv1.Secret) (*IntermediateCA, error) {
+	signerData, err := caData(signer)
+	if err != nil {
+		return nil, err
+	}
+	signerCert, err := parseCertificate(signerData["ca-cert.pem"])
+	if err != nil {
+		return nil, emperror.Wrap(err, "invalid signing CA certificate")
+	}
+
+	cert, err := parseCertificate(intermediate.Data["ca-cert.pem"])
+	if err != nil {
+		return nil, emperror.Wrap(err, "invalid intermediate CA certificate")
+	}
+	if len(intermediate.Data["ca-key.pem"]) == 0 {
+		return nil, errors.New("intermediate CA key is missing")
+	}
+	err = cert.CheckSignatureFrom(signerCert)
+	if err != nil {
+		return nil, emperror.Wrap(err, "intermediate CA is not signed by the signing CA")
+	}
+
+	data := map[string][]byte{
+		"ca-cert.pem": intermediate.Data["ca-cert.pem"],
+		"ca-key.pem":  intermediate.Data["ca-key.pem"],
+	}
+	setIntermediateCAChain(data, signerData)
+
+	return &IntermediateCA{
+		Data:        data,
+		Certificate: cert,
+	}, nil
+}
+
+// setIntermediateCAChain sets the root certificates of the signing CA and the chain of the intermediate CA up
+// to the root
+func setIntermediateCAChain(data map[string][]byte, signerData map[string][]byte) {
+	data["root-cert.pem"] = signerData["root-cert.pem"]
+	chain := append([]byte{}, bytes.TrimSpace(data["ca-cert.pem"])...)
+	chain = append(chain, '\n')
+	data["cert-chain.pem"] = append(chain, signerData["cert-chain.pem"]...)
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded certificate found")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM encoded key found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, emperror.Wrap(err, "unsupported key format")
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported key type")
+	}
+
+	return signer, nil
+}
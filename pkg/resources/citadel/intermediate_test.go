@@ -0,0 +1,185 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// testSigner returns a secret with a CA signed by a separate root, its key is encoded in the given format
+func testSigner(t *testing.T, keyFormat string) (*apiv1.Secret, *x509.Certificate) {
+	var key crypto.Signer
+	var err error
+	if keyFormat == "RSA PRIVATE KEY" {
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	} else {
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var der []byte
+	switch keyFormat {
+	case "RSA PRIVATE KEY":
+		der = x509.MarshalPKCS1PrivateKey(key.(*rsa.PrivateKey))
+	case "EC PRIVATE KEY":
+		der, err = x509.MarshalECPrivateKey(key.(*ecdsa.PrivateKey))
+	default:
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, rootKey, rootPEM := testCA(t, "root", nil, nil)
+	cert, certPEM := testCAWithKey(t, "signer", key, root, rootKey)
+
+	return &apiv1.Secret{
+		Data: map[string][]byte{
+			"ca-cert.pem":    certPEM,
+			"ca-key.pem":     pem.EncodeToMemory(&pem.Block{Type: keyFormat, Bytes: der}),
+			"root-cert.pem":  rootPEM,
+			"cert-chain.pem": append(append([]byte{}, certPEM...), rootPEM...),
+		},
+	}, cert
+}
+
+// certificateChain returns the certificates of the PEM encoded chain in their order
+func certificateChain(t *testing.T, data []byte) []*x509.Certificate {
+	var chain []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		chain = append(chain, cert)
+	}
+
+	return chain
+}
+
+func TestIssueIntermediateCA(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for _, keyFormat := range []string{"RSA PRIVATE KEY", "EC PRIVATE KEY", "PRIVATE KEY"} {
+		signer, signerCert := testSigner(t, keyFormat)
+
+		ca, err := IssueIntermediateCA(signer, "istio-ca.remote.istio-system", 365*24*time.Hour)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), keyFormat)
+
+		cert := ca.Certificate
+		g.Expect(cert.CheckSignatureFrom(signerCert)).To(gomega.Succeed(), keyFormat)
+		g.Expect(cert.IsCA).To(gomega.BeTrue(), keyFormat)
+		g.Expect(cert.MaxPathLen).To(gomega.Equal(0), keyFormat)
+		g.Expect(cert.MaxPathLenZero).To(gomega.BeTrue(), keyFormat)
+		g.Expect(cert.Subject.CommonName).To(gomega.Equal("istio-ca.remote.istio-system"), keyFormat)
+		g.Expect(cert.Subject.Organization).To(gomega.Equal([]string{"cluster.local"}), keyFormat)
+		// the intermediate CA does not outlive the signing CA
+		g.Expect(cert.NotAfter).To(gomega.BeTemporally("==", signerCert.NotAfter), keyFormat)
+
+		g.Expect(certificateChain(t, ca.Data["ca-cert.pem"])).To(gomega.Equal([]*x509.Certificate{cert}), keyFormat)
+		g.Expect(ca.Data["root-cert.pem"]).To(gomega.Equal(signer.Data["root-cert.pem"]), keyFormat)
+		chain := certificateChain(t, ca.Data["cert-chain.pem"])
+		g.Expect(chain).To(gomega.HaveLen(3), keyFormat)
+		g.Expect(chain[0]).To(gomega.Equal(cert), keyFormat)
+		g.Expect(chain[1]).To(gomega.Equal(signerCert), keyFormat)
+		g.Expect(chain[2].Subject.CommonName).To(gomega.Equal("root"), keyFormat)
+
+		key, err := parsePrivateKey(ca.Data["ca-key.pem"])
+		g.Expect(err).NotTo(gomega.HaveOccurred(), keyFormat)
+		g.Expect(key.Public()).To(gomega.Equal(cert.PublicKey), keyFormat)
+	}
+}
+
+func TestIssueIntermediateCALifetime(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	signer, signerCert := testSigner(t, "EC PRIVATE KEY")
+
+	ca, err := IssueIntermediateCA(signer, "istio-ca.remote.istio-system", 10*time.Minute)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(ca.Certificate.NotAfter.Sub(ca.Certificate.NotBefore)).To(gomega.Equal(10 * time.Minute))
+	g.Expect(ca.Certificate.NotAfter).To(gomega.BeTemporally("<", signerCert.NotAfter))
+}
+
+func TestIssueIntermediateCAKeys(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	signer, _ := testSigner(t, "EC PRIVATE KEY")
+
+	// every remote cluster gets a key of its own
+	keys := make(map[string]bool)
+	for _, name := range []string{"remote-a", "remote-b", "remote-c"} {
+		ca, err := IssueIntermediateCA(signer, "istio-ca."+name+".istio-system", time.Hour)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), name)
+		g.Expect(keys).NotTo(gomega.HaveKey(string(ca.Data["ca-key.pem"])), name)
+		g.Expect(bytes.Contains(ca.Data["ca-key.pem"], signer.Data["ca-key.pem"])).To(gomega.BeFalse(), name)
+		keys[string(ca.Data["ca-key.pem"])] = true
+	}
+
+	_, err := IssueIntermediateCA(&apiv1.Secret{}, "istio-ca.remote.istio-system", time.Hour)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestRefreshIntermediateCA(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	for _, keyFormat := range []string{"RSA PRIVATE KEY", "EC PRIVATE KEY", "PRIVATE KEY"} {
+		signer, signerCert := testSigner(t, keyFormat)
+		issued, err := IssueIntermediateCA(signer, "istio-ca.remote.istio-system", time.Hour)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), keyFormat)
+		current := &apiv1.Secret{Data: issued.Data}
+
+		// the chain of the signing CA is taken over, the intermediate CA is kept
+		refreshed, err := RefreshIntermediateCA(current, signer)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), keyFormat)
+		g.Expect(refreshed.Certificate).To(gomega.Equal(issued.Certificate), keyFormat)
+		g.Expect(refreshed.Data).To(gomega.Equal(issued.Data), keyFormat)
+
+		// the root certificates of the signing CA are updated during a CA rotation
+		rotating := signer.DeepCopy()
+		_, _, otherRootPEM := testCA(t, "other root", nil, nil)
+		rotating.Data["root-cert.pem"] = trustBundle(signer.Data["root-cert.pem"], otherRootPEM)
+		refreshed, err = RefreshIntermediateCA(current, rotating)
+		g.Expect(err).NotTo(gomega.HaveOccurred(), keyFormat)
+		g.Expect(refreshed.Data["root-cert.pem"]).To(gomega.Equal(rotating.Data["root-cert.pem"]), keyFormat)
+		g.Expect(refreshed.Data["ca-key.pem"]).To(gomega.Equal(issued.Data["ca-key.pem"]), keyFormat)
+
+		// an intermediate CA of another signing CA is rejected
+		otherSigner, otherSignerCert := testSigner(t, keyFormat)
+		g.Expect(otherSignerCert.Equal(signerCert)).To(gomega.BeFalse(), keyFormat)
+		_, err = RefreshIntermediateCA(current, otherSigner)
+		g.Expect(err).To(gomega.HaveOccurred(), keyFormat)
+
+		// the key of the intermediate CA is required
+		_, err = RefreshIntermediateCA(&apiv1.Secret{Data: map[string][]byte{"ca-cert.pem": issued.Data["ca-cert.pem"]}}, signer)
+		g.Expect(err).To(gomega.HaveOccurred(), keyFormat)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	apiv1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+const (
+	// CARotationSecretName is the secret Citadel reads its CA from while the CA is rotated
+	CARotationSecretName = "istio-ca-rotation"
+	// CARotationStageAnnotation is the stage of the CA rotation the content of the rotation secret belongs to
+	CARotationStageAnnotation = "istio.banzaicloud.io/ca-rotation-stage"
+)
+
+// reconcileCARotation writes the CA of the active stage of the CA rotation into the secret Citadel reads
+func (r *Reconciler) reconcileCARotation(log logr.Logger) error {
+	secret, desiredState, err := r.caRotationSecret()
+	if err != nil {
+		return err
+	}
+
+	return k8sutil.Reconcile(log, r.Client, secret, desiredState, r.Recorder)
+}
+
+// caRotationSecret returns the secret with the CA of the active stage of the CA rotation and its desired state
+func (r *Reconciler) caRotationSecret() (*apiv1.Secret, k8sutil.DesiredState, error) {
+	secret := &apiv1.Secret{
+		ObjectMeta: templates.ObjectMeta(CARotationSecretName, citadelLabels, r.Config),
+		Type:       apiv1.SecretTypeOpaque,
+	}
+
+	rotation := r.Config.Spec.Citadel.CARotation
+	if rotation == nil || !util.PointerToBool(r.Config.Spec.Citadel.Enabled) {
+		return secret, k8sutil.DesiredStateAbsent, nil
+	}
+
+	status := r.Config.Status.CARotation
+	if status == nil || status.NewCASecretName != rotation.NewCASecretName {
+		return nil, "", errors.New("the CA rotation is not started yet")
+	}
+
+	newCA, err := r.getCAData(rotation.NewCASecretName)
+	if err != nil {
+		return nil, "", emperror.Wrap(err, "could not get the new CA")
+	}
+
+	data := newCA
+	switch status.Stage {
+	case istiov1beta1.CARotationDistributingTrustBundle:
+		oldCA, err := r.getCAData(getCurrentCASecretName(r.Config))
+		if err != nil {
+			return nil, "", emperror.Wrap(err, "could not get the current CA")
+		}
+		data = oldCA
+		data["root-cert.pem"] = trustBundle(oldCA["root-cert.pem"], newCA["root-cert.pem"])
+	case istiov1beta1.CARotationSigningWithNewCA, istiov1beta1.CARotationWaitingForWorkloadCerts:
+		oldCA, err := r.getCAData(getCurrentCASecretName(r.Config))
+		if err != nil {
+			return nil, "", emperror.Wrap(err, "could not get the current CA")
+		}
+		data["root-cert.pem"] = trustBundle(newCA["root-cert.pem"], oldCA["root-cert.pem"])
+	}
+
+	secret.Annotations = map[string]string{
+		CARotationStageAnnotation: string(status.Stage),
+	}
+	secret.Data = data
+
+	return secret, k8sutil.DesiredStatePresent, nil
+}
+
+func (r *Reconciler) getCAData(name string) (map[string][]byte, error) {
+	var secret apiv1.Secret
+	err := r.Client.Get(context.TODO(), client.ObjectKey{
+		Namespace: r.Config.Namespace,
+		Name:      name,
+	}, &secret)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not get CA secret", "name", name)
+	}
+
+	return caData(&secret)
+}
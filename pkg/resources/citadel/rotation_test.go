@@ -0,0 +1,204 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package citadel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+func TestReconcileCARotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// the current CA is self-signed, the new one is signed by a separate root
+	_, oldKey, oldCAPEM := testCA(t, "old", nil, nil)
+	newRoot, newRootKey, newRootPEM := testCA(t, "new root", nil, nil)
+	_, newKey, newCAPEM := testCA(t, "new", newRoot, newRootKey)
+	oldSecret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cacerts", Namespace: "istio-system"},
+		Data: map[string][]byte{
+			"ca-cert.pem": oldCAPEM,
+			"ca-key.pem":  testKeyPEM(t, oldKey),
+		},
+	}
+	newSecret := &apiv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cacerts-2020", Namespace: "istio-system"},
+		Data: map[string][]byte{
+			"ca-cert.pem":    newCAPEM,
+			"ca-key.pem":     testKeyPEM(t, newKey),
+			"root-cert.pem":  newRootPEM,
+			"cert-chain.pem": append(append([]byte{}, newCAPEM...), newRootPEM...),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		rotation     *istiov1beta1.CitadelCARotation
+		status       *istiov1beta1.CARotationStatus
+		wantErr      bool
+		wantAbsent   bool
+		wantCACert   []byte
+		wantRootCert []byte
+	}{
+		{
+			name:       "no rotation",
+			wantAbsent: true,
+		},
+		{
+			name:     "rotation not started",
+			rotation: &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"},
+			wantErr:  true,
+		},
+		{
+			name:     "rotation of another secret started",
+			rotation: &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"},
+			status:   &istiov1beta1.CARotationStatus{NewCASecretName: "cacerts-2019", Stage: istiov1beta1.CARotationSigningWithNewCA},
+			wantErr:  true,
+		},
+		{
+			name:         "distributing the trust bundle",
+			rotation:     &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"},
+			status:       &istiov1beta1.CARotationStatus{NewCASecretName: "cacerts-2020", Stage: istiov1beta1.CARotationDistributingTrustBundle},
+			wantCACert:   oldCAPEM,
+			wantRootCert: trustBundle(oldCAPEM, newRootPEM),
+		},
+		{
+			name:         "signing with the new CA",
+			rotation:     &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"},
+			status:       &istiov1beta1.CARotationStatus{NewCASecretName: "cacerts-2020", Stage: istiov1beta1.CARotationSigningWithNewCA},
+			wantCACert:   newCAPEM,
+			wantRootCert: trustBundle(newRootPEM, oldCAPEM),
+		},
+		{
+			name:         "waiting for the workload certificates",
+			rotation:     &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"},
+			status:       &istiov1beta1.CARotationStatus{NewCASecretName: "cacerts-2020", Stage: istiov1beta1.CARotationWaitingForWorkloadCerts},
+			wantCACert:   newCAPEM,
+			wantRootCert: trustBundle(newRootPEM, oldCAPEM),
+		},
+		{
+			name:         "dropping the old root",
+			rotation:     &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"},
+			status:       &istiov1beta1.CARotationStatus{NewCASecretName: "cacerts-2020", Stage: istiov1beta1.CARotationDroppingOldRoot},
+			wantCACert:   newCAPEM,
+			wantRootCert: newRootPEM,
+		},
+		{
+			name:         "completed",
+			rotation:     &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"},
+			status:       &istiov1beta1.CARotationStatus{NewCASecretName: "cacerts-2020", Stage: istiov1beta1.CARotationCompleted},
+			wantCACert:   newCAPEM,
+			wantRootCert: newRootPEM,
+		},
+	}
+
+	for _, test := range tests {
+		config := &istiov1beta1.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system"},
+			Spec: istiov1beta1.IstioSpec{
+				Citadel: istiov1beta1.CitadelConfiguration{
+					Enabled:      util.BoolPointer(true),
+					CASecretName: "cacerts",
+					CARotation:   test.rotation,
+				},
+			},
+			Status: istiov1beta1.IstioStatus{
+				CARotation: test.status,
+			},
+		}
+		c := fake.NewFakeClient(oldSecret.DeepCopy(), newSecret.DeepCopy())
+		r := New(Configuration{}, c, nil, config)
+
+		err := r.reconcileCARotation(logf.NullLogger{})
+		if test.wantErr {
+			g.Expect(err).To(gomega.HaveOccurred(), test.name)
+			continue
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+
+		var secret apiv1.Secret
+		err = c.Get(context.TODO(), client.ObjectKey{Namespace: "istio-system", Name: CARotationSecretName}, &secret)
+		if test.wantAbsent {
+			g.Expect(k8serrors.IsNotFound(err)).To(gomega.BeTrue(), test.name)
+			continue
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred(), test.name)
+		g.Expect(secret.Annotations).To(gomega.HaveKeyWithValue(CARotationStageAnnotation, string(test.status.Stage)), test.name)
+		g.Expect(secret.Data["ca-cert.pem"]).To(gomega.Equal(test.wantCACert), test.name)
+		g.Expect(secret.Data["root-cert.pem"]).To(gomega.Equal(test.wantRootCert), test.name)
+	}
+}
+
+func TestPlanCARotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	_, oldKey, oldCAPEM := testCA(t, "old", nil, nil)
+	_, newKey, newCAPEM := testCA(t, "new", nil, nil)
+	c := fake.NewFakeClient(
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cacerts", Namespace: "istio-system"},
+			Data:       map[string][]byte{"ca-cert.pem": oldCAPEM, "ca-key.pem": testKeyPEM(t, oldKey)},
+		},
+		&apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cacerts-2020", Namespace: "istio-system"},
+			Data:       map[string][]byte{"ca-cert.pem": newCAPEM, "ca-key.pem": testKeyPEM(t, newKey)},
+		},
+	)
+	config := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system"},
+		Spec: istiov1beta1.IstioSpec{
+			Version: "1.3.5",
+			Citadel: istiov1beta1.CitadelConfiguration{
+				CASecretName: "cacerts",
+				CARotation:   &istiov1beta1.CitadelCARotation{NewCASecretName: "cacerts-2020"},
+			},
+		},
+		Status: istiov1beta1.IstioStatus{
+			CARotation: &istiov1beta1.CARotationStatus{NewCASecretName: "cacerts-2020", Stage: istiov1beta1.CARotationSigningWithNewCA},
+		},
+	}
+	istiov1beta1.SetDefaults(config)
+
+	actions, err := New(Configuration{}, c, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), config).Plan()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(actions).NotTo(gomega.BeEmpty())
+	g.Expect(actions[0]).To(gomega.Equal(k8sutil.PlannedAction{
+		Action:     k8sutil.ActionCreate,
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  "istio-system",
+		Name:       CARotationSecretName,
+	}))
+
+	// the CA rotation secret is only planned
+	err = c.Get(context.TODO(), client.ObjectKey{Namespace: "istio-system", Name: CARotationSecretName}, &apiv1.Secret{})
+	g.Expect(k8serrors.IsNotFound(err)).To(gomega.BeTrue())
+}
@@ -60,28 +60,38 @@ func New(client client.Client, config *istiov1beta1.Istio) *Reconciler {
 func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 
+	log.Info("Reconciling")
+
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// Resources returns the typed objects of the component with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
 	desiredState := k8sutil.DesiredStatePresent
 	if !util.PointerToBool(r.Config.Spec.SidecarInjector.InitCNIConfiguration.Enabled) {
 		desiredState = k8sutil.DesiredStateAbsent
 	}
 
-	log.Info("Reconciling")
-
-	for _, res := range []resources.ResourceWithDesiredState{
+	return []resources.ResourceWithDesiredState{
 		{Resource: r.serviceAccount, DesiredState: desiredState},
 		{Resource: r.clusterRole, DesiredState: desiredState},
 		{Resource: r.clusterRoleBinding, DesiredState: desiredState},
 		{Resource: r.configMap, DesiredState: desiredState},
 		{Resource: r.daemonSet, DesiredState: desiredState},
-	} {
-		o := res.Resource()
-		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
-		}
 	}
+}
 
-	log.Info("Reconciled")
-
+// DynamicResources returns the dynamic objects of the component with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
 	"github.com/banzaicloud/istio-operator/pkg/resources"
+	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
 const (
@@ -51,11 +52,9 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
-	for _, res := range []resources.Resource{
-		r.configMap,
-	} {
-		o := res()
-		err := k8sutil.Reconcile(log, r.Client, o, k8sutil.DesiredStatePresent)
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
 		if err != nil {
 			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
 		}
@@ -65,3 +64,24 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	return nil
 }
+
+// Resources returns the typed objects of the component with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
+	desiredState := k8sutil.DesiredStatePresent
+	// the mesh config of a revision is only used by the control plane components of the revision
+	if r.Config.Spec.GetRevision() != "" &&
+		!util.PointerToBool(r.Config.Spec.Pilot.Enabled) &&
+		!util.PointerToBool(r.Config.Spec.Galley.Enabled) &&
+		!util.PointerToBool(r.Config.Spec.SidecarInjector.Enabled) {
+		desiredState = k8sutil.DesiredStateAbsent
+	}
+
+	return []resources.ResourceWithDesiredState{
+		{Resource: r.configMap, DesiredState: desiredState},
+	}
+}
+
+// DynamicResources returns the dynamic objects of the component with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
+	return nil
+}
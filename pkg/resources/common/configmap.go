@@ -34,7 +34,7 @@ var cmLabels = map[string]string{
 
 func (r *Reconciler) configMap() runtime.Object {
 	return &apiv1.ConfigMap{
-		ObjectMeta: templates.ObjectMeta(IstioConfigMapName, cmLabels, r.Config),
+		ObjectMeta: templates.ObjectMeta(templates.RevisionedName(IstioConfigMapName, r.Config), cmLabels, r.Config),
 		Data: map[string]string{
 			"mesh":         r.meshConfig(),
 			"meshNetworks": r.meshNetworks(),
@@ -53,7 +53,7 @@ func (r *Reconciler) meshConfig() string {
 		"proxyAdminPort":         15000,
 		"concurrency":            0,
 		"controlPlaneAuthPolicy": templates.ControlPlaneAuthPolicy(r.Config.Spec.ControlPlaneSecurityEnabled),
-		"discoveryAddress":       fmt.Sprintf("istio-pilot.%s:%s", r.Config.Namespace, r.discoveryPort()),
+		"discoveryAddress":       fmt.Sprintf("%s.%s:%s", templates.RevisionedName("istio-pilot", r.Config), r.Config.Namespace, r.discoveryPort()),
 	}
 
 	if util.PointerToBool(r.Config.Spec.Tracing.Enabled) {
@@ -102,11 +102,22 @@ func (r *Reconciler) meshConfig() string {
 			"mode": r.Config.Spec.OutboundTrafficPolicy.Mode,
 		},
 		"defaultConfig":     defaultConfig,
-		"rootNamespace":     "istio-system",
+		"rootNamespace":     r.Config.Namespace,
 		"connectTimeout":    "10s",
 		"localityLbSetting": r.getLocalityLBConfiguration(),
 	}
 
+	if r.Config.Spec.Version.IsAtLeast("1.3") {
+		meshConfig["protocolDetectionTimeout"] = "100ms"
+		meshConfig["reportBatchMaxEntries"] = 100
+		meshConfig["reportBatchMaxTime"] = "1s"
+		meshConfig["disableMixerHttpReports"] = false
+	}
+
+	if r.Config.Spec.Version.IsAtLeast("1.4") {
+		meshConfig["enableAutoMtls"] = false
+	}
+
 	if util.PointerToBool(r.Config.Spec.UseMCP) {
 		meshConfig["configSources"] = []map[string]interface{}{
 			r.defaultConfigSource(),
@@ -127,9 +138,6 @@ func (r *Reconciler) getLocalityLBConfiguration() *istiov1beta1.LocalityLBConfig
 	if r.Config.Spec.LocalityLB != nil {
 		localityLbConfiguration = r.Config.Spec.LocalityLB.DeepCopy()
 		localityLbConfiguration.Enabled = nil
-		if localityLbConfiguration.Distribute != nil && localityLbConfiguration.Failover != nil {
-			localityLbConfiguration.Failover = nil
-		}
 	}
 
 	return localityLbConfiguration
@@ -152,7 +160,7 @@ func (r *Reconciler) mixerServer(mixerType string) string {
 
 func (r *Reconciler) defaultConfigSource() map[string]interface{} {
 	cs := map[string]interface{}{
-		"address": fmt.Sprintf("istio-galley.%s.svc:9901", r.Config.Namespace),
+		"address": fmt.Sprintf("%s.%s.svc:9901", templates.RevisionedName("istio-galley", r.Config), r.Config.Namespace),
 	}
 	if r.Config.Spec.ControlPlaneSecurityEnabled {
 		cs["tlsSettings"] = map[string]interface{}{
@@ -45,7 +45,7 @@ func (r *Reconciler) validatingWebhookConfig(ns string) string {
 	se := admissionv1beta1.SideEffectClassNone
 	webhook := admissionv1beta1.ValidatingWebhookConfiguration{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      webhookName,
+			Name:      templates.ClusterScopeName(webhookName, r.Config),
 			Namespace: ns,
 			Labels:    galleyLabels,
 		},
@@ -40,6 +40,7 @@ func (r *Reconciler) deployment() runtime.Object {
 		fmt.Sprintf("--deployment-namespace=%s", r.Config.Namespace),
 		"--validation-webhook-config-file",
 		"/etc/config/validatingwebhookconfiguration.yaml",
+		fmt.Sprintf("--webhook-name=%s", templates.ClusterScopeName(webhookName, r.Config)),
 		"--monitoringPort=15014",
 	}
 
@@ -53,8 +54,13 @@ func (r *Reconciler) deployment() runtime.Object {
 		containerArgs = append(containerArgs, "--enable-server=false")
 	}
 
+	// config validation is served by the galley of the default control plane
+	if r.Config.Spec.GetRevision() != "" {
+		containerArgs = append(containerArgs, "--enable-validation=false")
+	}
+
 	return &appsv1.Deployment{
-		ObjectMeta: templates.ObjectMeta(deploymentName, util.MergeLabels(galleyLabels, labelSelector), r.Config),
+		ObjectMeta: templates.ObjectMeta(templates.RevisionedName(deploymentName, r.Config), util.MergeLabels(galleyLabels, r.labelSelector()), r.Config),
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &r.Config.Spec.Galley.ReplicaCount,
 			Strategy: appsv1.DeploymentStrategy{
@@ -64,11 +70,11 @@ func (r *Reconciler) deployment() runtime.Object {
 				},
 			},
 			Selector: &metav1.LabelSelector{
-				MatchLabels: util.MergeLabels(galleyLabels, labelSelector),
+				MatchLabels: util.MergeLabels(galleyLabels, r.labelSelector()),
 			},
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      util.MergeLabels(galleyLabels, labelSelector),
+					Labels:      util.MergeLabels(galleyLabels, r.labelSelector()),
 					Annotations: templates.DefaultDeployAnnotations(),
 				},
 				Spec: apiv1.PodSpec{
@@ -147,7 +153,7 @@ func (r *Reconciler) deployment() runtime.Object {
 							VolumeSource: apiv1.VolumeSource{
 								ConfigMap: &apiv1.ConfigMapVolumeSource{
 									LocalObjectReference: apiv1.LocalObjectReference{
-										Name: common.IstioConfigMapName,
+										Name: templates.RevisionedName(common.IstioConfigMapName, r.Config),
 									},
 									DefaultMode: util.IntPointer(420),
 								},
@@ -25,6 +25,7 @@ import (
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
 	"github.com/banzaicloud/istio-operator/pkg/resources"
+	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
 )
 
 const (
@@ -60,11 +61,31 @@ func New(client client.Client, config *istiov1beta1.Istio) *Reconciler {
 	}
 }
 
+func (r *Reconciler) labelSelector() map[string]string {
+	return templates.RevisionedLabelSelector(labelSelector, r.Config)
+}
+
 func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 
 	log.Info("Reconciling")
 
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+	// TODO: wait for deployment to be available?
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// Resources returns the typed objects of the component with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
 	var galleyDesiredState k8sutil.DesiredState
 	var pdbDesiredState k8sutil.DesiredState
 	if util.PointerToBool(r.Config.Spec.Galley.Enabled) {
@@ -79,26 +100,25 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		pdbDesiredState = k8sutil.DesiredStateAbsent
 	}
 
-	resources := []resources.ResourceWithDesiredState{
-		{Resource: r.serviceAccount, DesiredState: galleyDesiredState},
-		{Resource: r.clusterRole, DesiredState: galleyDesiredState},
-		{Resource: r.clusterRoleBinding, DesiredState: galleyDesiredState},
-		{Resource: r.configMap, DesiredState: galleyDesiredState},
+	rs := []resources.ResourceWithDesiredState{
 		{Resource: r.deployment, DesiredState: galleyDesiredState},
 		{Resource: r.service, DesiredState: galleyDesiredState},
 		{Resource: r.podDisruptionBudget, DesiredState: pdbDesiredState},
 	}
-
-	for _, res := range resources {
-		o := res.Resource()
-		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
-		}
+	// the service account, the RBAC resources and the validation config are shared between the control plane revisions
+	if r.Config.Spec.GetRevision() == "" {
+		rs = append([]resources.ResourceWithDesiredState{
+			{Resource: r.serviceAccount, DesiredState: galleyDesiredState},
+			{Resource: r.clusterRole, DesiredState: galleyDesiredState},
+			{Resource: r.clusterRoleBinding, DesiredState: galleyDesiredState},
+			{Resource: r.configMap, DesiredState: galleyDesiredState},
+		}, rs...)
 	}
-	// TODO: wait for deployment to be available?
 
-	log.Info("Reconciled")
+	return rs
+}
 
+// DynamicResources returns the dynamic objects of the component with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
 	return nil
 }
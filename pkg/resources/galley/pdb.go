@@ -27,11 +27,11 @@ import (
 
 func (r *Reconciler) podDisruptionBudget() runtime.Object {
 	return &policyv1beta1.PodDisruptionBudget{
-		ObjectMeta: templates.ObjectMeta(pdbName, labelSelector, r.Config),
+		ObjectMeta: templates.ObjectMeta(templates.RevisionedName(pdbName, r.Config), r.labelSelector(), r.Config),
 		Spec: policyv1beta1.PodDisruptionBudgetSpec{
 			MinAvailable: util.IntstrPointer(1),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: util.MergeLabels(labelSelector, galleyLabels),
+				MatchLabels: util.MergeLabels(r.labelSelector(), galleyLabels),
 			},
 		},
 	}
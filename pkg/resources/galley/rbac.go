@@ -91,7 +91,7 @@ func (r *Reconciler) clusterRoleBinding() runtime.Object {
 		RoleRef: rbacv1.RoleRef{
 			Kind:     "ClusterRole",
 			APIGroup: "rbac.authorization.k8s.io",
-			Name:     clusterRoleName,
+			Name:     templates.ClusterScopeName(clusterRoleName, r.Config),
 		},
 		Subjects: []rbacv1.Subject{
 			{
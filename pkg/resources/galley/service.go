@@ -30,7 +30,7 @@ var serviceLabels = map[string]string{
 
 func (r *Reconciler) service() runtime.Object {
 	return &apiv1.Service{
-		ObjectMeta: templates.ObjectMeta(serviceName, serviceLabels, r.Config),
+		ObjectMeta: templates.ObjectMeta(templates.RevisionedName(serviceName, r.Config), serviceLabels, r.Config),
 		Spec: apiv1.ServiceSpec{
 			Ports: []apiv1.ServicePort{
 				{
@@ -52,7 +52,7 @@ func (r *Reconciler) service() runtime.Object {
 					Protocol:   apiv1.ProtocolTCP,
 				},
 			},
-			Selector: labelSelector,
+			Selector: r.labelSelector(),
 		},
 	}
 }
@@ -18,6 +18,7 @@ package gateways
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
@@ -57,6 +58,35 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	for _, dr := range r.DynamicResources() {
+		o := dr.DynamicResource()
+		err := o.Reconcile(log, r.dynamic, dr.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile dynamic resource", "resource", o.Gvr)
+		}
+	}
+
+	if r.Config.Name != "istio-config" {
+		err := r.pruneTLSCertificates(log, r.tlsCertificateNames())
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("Reconciled")
+	return nil
+}
+
+// Resources returns the typed objects of every configured gateway with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
 	var pdbDesiredState k8sutil.DesiredState
 	var sdsDesiredState k8sutil.DesiredState
 	if util.PointerToBool(r.Config.Spec.Gateways.Enabled) {
@@ -90,7 +120,9 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		{ResourceVariation: r.roleBinding, DesiredState: sdsDesiredState},
 	}
 
-	for gateway, conf := range r.Config.Spec.Gateways.Configs {
+	rs := make([]resources.ResourceWithDesiredState, 0)
+	for _, gateway := range r.gatewayNames() {
+		conf := r.Config.Spec.Gateways.Configs[gateway]
 		var desiredState k8sutil.DesiredState
 		if util.PointerToBool(r.Config.Spec.Gateways.Enabled) && util.PointerToBool(conf.Enabled) {
 			desiredState = k8sutil.DesiredStatePresent
@@ -98,13 +130,16 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 			desiredState = k8sutil.DesiredStateAbsent
 		}
 
-		for _, res := range resources.ResolveVariations(gateway, rsv, desiredState) {
-			o := res.Resource()
-			err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
-			if err != nil {
-				return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
-			}
-		}
+		rs = append(rs, resources.ResolveVariations(gateway, rsv, desiredState)...)
+	}
+
+	return rs
+}
+
+// DynamicResources returns the dynamic objects of the gateways with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
+	if r.Config.Name == "istio-config" {
+		return nil
 	}
 
 	var k8sIngressDesiredState k8sutil.DesiredState
@@ -133,12 +168,7 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		multimeshDesiredState = k8sutil.DesiredStateAbsent
 	}
 
-	if r.Config.Name == "istio-config" {
-		log.Info("Reconciled")
-		return nil
-	}
-
-	var drs = []resources.DynamicResourceWithDesiredState{
+	drs := []resources.DynamicResourceWithDesiredState{
 		{DynamicResource: r.gateway, DesiredState: k8sIngressDesiredState},
 		{DynamicResource: r.meshExpansionGateway, DesiredState: meshExpansionDesiredState},
 		{DynamicResource: r.clusterAwareGateway, DesiredState: meshExpansionDesiredState},
@@ -147,16 +177,67 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		{DynamicResource: r.multimeshDestinationRule, DesiredState: multimeshDesiredState},
 		{DynamicResource: r.multimeshEnvoyFilter, DesiredState: multimeshDesiredState},
 	}
-	for _, dr := range drs {
-		o := dr.DynamicResource()
-		err := o.Reconcile(log, r.dynamic, dr.DesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile dynamic resource", "resource", o.Gvr)
+
+	// the certificates of the TLS hosts are issued before the Gateway referencing them is created, the ones
+	// not served anymore are pruned after the reconciliation
+	for _, gateway := range r.gatewayNames() {
+		gateway := gateway
+		tlsDesiredState := k8sutil.DesiredStateAbsent
+		if r.tlsHostsServed(gateway) {
+			tlsDesiredState = k8sutil.DesiredStatePresent
+			for _, host := range r.getGatewayConfig(gateway).TLSHosts {
+				host := host
+				drs = append(drs, resources.DynamicResourceWithDesiredState{
+					DynamicResource: func() *k8sutil.DynamicObject {
+						return r.tlsCertificate(gateway, host)
+					},
+					DesiredState: k8sutil.DesiredStatePresent,
+				})
+			}
 		}
+		drs = append(drs, resources.DynamicResourceWithDesiredState{
+			DynamicResource: func() *k8sutil.DynamicObject {
+				return r.tlsGateway(gateway)
+			},
+			DesiredState: tlsDesiredState,
+		})
 	}
 
-	log.Info("Reconciled")
-	return nil
+	return drs
+}
+
+// gatewayNames returns the names of the configured gateways, they are resolved in a stable order to produce
+// reproducible output
+func (r *Reconciler) gatewayNames() []string {
+	gateways := make([]string, 0, len(r.Config.Spec.Gateways.Configs))
+	for gateway := range r.Config.Spec.Gateways.Configs {
+		gateways = append(gateways, gateway)
+	}
+	sort.Strings(gateways)
+
+	return gateways
+}
+
+// tlsHostsServed returns whether the gateway is deployed with TLS hosts to serve
+func (r *Reconciler) tlsHostsServed(gw string) bool {
+	conf := r.getGatewayConfig(gw)
+
+	return conf != nil && util.PointerToBool(r.Config.Spec.Gateways.Enabled) && util.PointerToBool(conf.Enabled) && len(conf.TLSHosts) > 0
+}
+
+// tlsCertificateNames returns the names of the Certificates of the TLS hosts served by the gateways
+func (r *Reconciler) tlsCertificateNames() map[string]bool {
+	names := make(map[string]bool)
+	for _, gateway := range r.gatewayNames() {
+		if !r.tlsHostsServed(gateway) {
+			continue
+		}
+		for _, host := range r.getGatewayConfig(gateway).TLSHosts {
+			names[tlsCredentialName(gateway, host.Host)] = true
+		}
+	}
+
+	return names
 }
 
 func (r *Reconciler) getGatewayConfig(gw string) *istiov1beta1.GatewayConfiguration {
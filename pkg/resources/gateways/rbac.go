@@ -48,7 +48,7 @@ func (r *Reconciler) clusterRoleBinding(gw string) runtime.Object {
 		RoleRef: rbacv1.RoleRef{
 			Kind:     "ClusterRole",
 			APIGroup: "rbac.authorization.k8s.io",
-			Name:     clusterRoleName(gw),
+			Name:     templates.ClusterScopeName(clusterRoleName(gw), r.Config),
 		},
 		Subjects: []rbacv1.Subject{
 			{
@@ -0,0 +1,171 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateways
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/metrics"
+)
+
+// tlsGatewayLabel marks the cert-manager Certificates of the TLS hosts with the gateway they are served by
+const tlsGatewayLabel = "istio.banzaicloud.io/gateway-tls"
+
+var certificateGvr = schema.GroupVersionResource{
+	Group:    "certmanager.k8s.io",
+	Version:  "v1alpha1",
+	Resource: "certificates",
+}
+
+func tlsGatewayName(gw string) string {
+	return fmt.Sprintf("istio-%s-tls", gw)
+}
+
+// tlsHostName returns the host in a form usable in object and port names
+func tlsHostName(host string) string {
+	return strings.Replace(host, "*", "wildcard", 1)
+}
+
+// tlsCredentialName returns the name of the Certificate and of the secret of a TLS host, the gateway reads the
+// secret through SDS
+func tlsCredentialName(gw, host string) string {
+	return fmt.Sprintf("istio-%s-%s", gw, tlsHostName(host))
+}
+
+// tlsCertificate returns the cert-manager Certificate of a TLS host of the gateway
+func (r *Reconciler) tlsCertificate(gw string, host istiov1beta1.GatewayTLSHost) *k8sutil.DynamicObject {
+	name := tlsCredentialName(gw, host.Host)
+
+	return &k8sutil.DynamicObject{
+		Gvr:       certificateGvr,
+		Kind:      "Certificate",
+		Name:      name,
+		Namespace: r.Config.Namespace,
+		Labels: map[string]string{
+			tlsGatewayLabel: gw,
+		},
+		Spec: map[string]interface{}{
+			"secretName": name,
+			"commonName": host.Host,
+			"dnsNames":   []interface{}{host.Host},
+			"issuerRef": map[string]interface{}{
+				"name": host.IssuerRef.Name,
+				"kind": host.IssuerRef.Kind,
+			},
+		},
+		Owner: r.Config,
+	}
+}
+
+// tlsGateway returns the Gateway serving the TLS hosts of the gateway over HTTPS with their certificates
+func (r *Reconciler) tlsGateway(gw string) *k8sutil.DynamicObject {
+	servers := make([]interface{}, 0)
+	if conf := r.getGatewayConfig(gw); conf != nil {
+		for _, host := range conf.TLSHosts {
+			servers = append(servers, map[string]interface{}{
+				"port": map[string]interface{}{
+					"name":     "https-" + strings.Replace(tlsHostName(host.Host), ".", "-", -1),
+					"protocol": "HTTPS",
+					"number":   443,
+				},
+				"hosts": []interface{}{host.Host},
+				"tls": map[string]interface{}{
+					"mode":           "SIMPLE",
+					"credentialName": tlsCredentialName(gw, host.Host),
+				},
+			})
+		}
+	}
+
+	selector := make(map[string]interface{})
+	for k, v := range labelSelector(gw) {
+		selector[k] = v
+	}
+
+	return &k8sutil.DynamicObject{
+		Gvr: schema.GroupVersionResource{
+			Group:    "networking.istio.io",
+			Version:  "v1alpha3",
+			Resource: "gateways",
+		},
+		Kind:      "Gateway",
+		Name:      tlsGatewayName(gw),
+		Namespace: r.Config.Namespace,
+		Spec: map[string]interface{}{
+			"servers":  servers,
+			"selector": selector,
+		},
+		Owner: r.Config,
+	}
+}
+
+// pruneTLSCertificates removes the Certificates of the TLS hosts which are not served by the gateways anymore,
+// together with the secrets cert-manager issued them into
+func (r *Reconciler) pruneTLSCertificates(log logr.Logger, desired map[string]bool) error {
+	certificates, err := r.dynamic.Resource(certificateGvr).Namespace(r.Config.Namespace).List(metav1.ListOptions{
+		LabelSelector: tlsGatewayLabel,
+	})
+	// cert-manager is not installed, there is nothing to remove
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return emperror.Wrap(err, "could not list TLS certificates")
+	}
+
+	for _, certificate := range certificates.Items {
+		owner := metav1.GetControllerOf(&certificate)
+		if owner == nil || owner.UID != r.Config.UID || desired[certificate.GetName()] {
+			continue
+		}
+		err = r.dynamic.Resource(certificateGvr).Namespace(r.Config.Namespace).Delete(certificate.GetName(), &metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return emperror.WrapWith(err, "could not remove TLS certificate", "name", certificate.GetName())
+		}
+		log.Info("resource deleted", "kind", certificateGvr.Resource, "name", certificate.GetName())
+		metrics.ResourceOperation("Certificate", metrics.OperationDeleted)
+		r.Recorder.Normal(k8sutil.EventReasonDeleted, "Certificate %s/%s deleted", r.Config.Namespace, certificate.GetName())
+
+		// cert-manager does not remove the secret of the certificate
+		secretName, _, _ := unstructured.NestedString(certificate.Object, "spec", "secretName")
+		if secretName == "" {
+			continue
+		}
+		err = k8sutil.Reconcile(log, r.Client, &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: r.Config.Namespace,
+			},
+		}, k8sutil.DesiredStateAbsent, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "could not remove TLS secret", "name", secretName)
+		}
+	}
+
+	return nil
+}
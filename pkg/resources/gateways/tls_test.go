@@ -0,0 +1,229 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gateways
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	apiv1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+func testTLSConfig() *istiov1beta1.Istio {
+	return &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system", UID: "mesh-uid"},
+		Spec: istiov1beta1.IstioSpec{
+			Gateways: istiov1beta1.GatewaysConfiguration{
+				Enabled: util.BoolPointer(true),
+				Configs: map[string]*istiov1beta1.GatewayConfiguration{
+					"ingress": {
+						Enabled: util.BoolPointer(true),
+						TLSHosts: []istiov1beta1.GatewayTLSHost{
+							{
+								Host:      "*.example.com",
+								IssuerRef: istiov1beta1.CertManagerIssuerReference{Name: "letsencrypt", Kind: "ClusterIssuer"},
+							},
+							{
+								Host:      "www.example.org",
+								IssuerRef: istiov1beta1.CertManagerIssuerReference{Name: "ca-issuer", Kind: "Issuer"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestTLSCredentialName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tests := []struct {
+		name     string
+		gateway  string
+		host     string
+		expected string
+	}{
+		{
+			name:     "host",
+			gateway:  "ingress",
+			host:     "www.example.com",
+			expected: "istio-ingress-www.example.com",
+		},
+		{
+			name:     "wildcard host",
+			gateway:  "ingress",
+			host:     "*.example.com",
+			expected: "istio-ingress-wildcard.example.com",
+		},
+		{
+			name:     "other gateway",
+			gateway:  "internal",
+			host:     "*.internal.example.com",
+			expected: "istio-internal-wildcard.internal.example.com",
+		},
+	}
+
+	for _, test := range tests {
+		g.Expect(tlsCredentialName(test.gateway, test.host)).To(gomega.Equal(test.expected), test.name)
+	}
+}
+
+func TestTLSCertificate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := testTLSConfig()
+	r := New(nil, nil, config)
+
+	certificate := r.tlsCertificate("ingress", config.Spec.Gateways.Configs["ingress"].TLSHosts[0])
+	g.Expect(certificate.Gvr).To(gomega.Equal(certificateGvr))
+	g.Expect(certificate.Kind).To(gomega.Equal("Certificate"))
+	g.Expect(certificate.Name).To(gomega.Equal("istio-ingress-wildcard.example.com"))
+	g.Expect(certificate.Namespace).To(gomega.Equal("istio-system"))
+	g.Expect(certificate.Labels).To(gomega.Equal(map[string]string{tlsGatewayLabel: "ingress"}))
+	g.Expect(certificate.Owner).To(gomega.BeIdenticalTo(config))
+	g.Expect(certificate.Spec).To(gomega.Equal(map[string]interface{}{
+		"secretName": "istio-ingress-wildcard.example.com",
+		"commonName": "*.example.com",
+		"dnsNames":   []interface{}{"*.example.com"},
+		"issuerRef": map[string]interface{}{
+			"name": "letsencrypt",
+			"kind": "ClusterIssuer",
+		},
+	}))
+}
+
+func TestTLSGateway(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	r := New(nil, nil, testTLSConfig())
+
+	gateway := r.tlsGateway("ingress")
+	g.Expect(gateway.Kind).To(gomega.Equal("Gateway"))
+	g.Expect(gateway.Name).To(gomega.Equal("istio-ingress-tls"))
+	g.Expect(gateway.Namespace).To(gomega.Equal("istio-system"))
+	g.Expect(gateway.Spec).To(gomega.Equal(map[string]interface{}{
+		"selector": map[string]interface{}{
+			"app":   "istio-ingress",
+			"istio": "ingress",
+		},
+		"servers": []interface{}{
+			map[string]interface{}{
+				"port": map[string]interface{}{
+					"name":     "https-wildcard-example-com",
+					"protocol": "HTTPS",
+					"number":   443,
+				},
+				"hosts": []interface{}{"*.example.com"},
+				"tls": map[string]interface{}{
+					"mode":           "SIMPLE",
+					"credentialName": "istio-ingress-wildcard.example.com",
+				},
+			},
+			map[string]interface{}{
+				"port": map[string]interface{}{
+					"name":     "https-www-example-org",
+					"protocol": "HTTPS",
+					"number":   443,
+				},
+				"hosts": []interface{}{"www.example.org"},
+				"tls": map[string]interface{}{
+					"mode":           "SIMPLE",
+					"credentialName": "istio-ingress-www.example.org",
+				},
+			},
+		},
+	}))
+
+	// a gateway without configuration serves nothing
+	g.Expect(r.tlsGateway("unknown").Spec["servers"]).To(gomega.BeEmpty())
+}
+
+func TestPruneTLSCertificates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := testTLSConfig()
+	owner := metav1.NewControllerRef(config, istiov1beta1.SchemeGroupVersion.WithKind("Istio"))
+	certificate := func(name string, owned bool) runtime.Object {
+		o := &unstructured.Unstructured{}
+		o.SetAPIVersion("certmanager.k8s.io/v1alpha1")
+		o.SetKind("Certificate")
+		o.SetName(name)
+		o.SetNamespace("istio-system")
+		o.SetLabels(map[string]string{tlsGatewayLabel: "ingress"})
+		if owned {
+			o.SetOwnerReferences([]metav1.OwnerReference{*owner})
+		}
+		o.Object["spec"] = map[string]interface{}{
+			"secretName": name,
+		}
+		return o
+	}
+	secret := func(name string) runtime.Object {
+		return &apiv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "istio-system"},
+		}
+	}
+
+	dc := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(),
+		certificate("istio-ingress-wildcard.example.com", true),
+		certificate("istio-ingress-removed.example.com", true),
+		certificate("istio-ingress-other.example.com", false),
+	)
+	c := fake.NewFakeClient(
+		secret("istio-ingress-wildcard.example.com"),
+		secret("istio-ingress-removed.example.com"),
+		secret("istio-ingress-other.example.com"),
+	)
+	r := New(c, dc, config)
+
+	err := r.pruneTLSCertificates(logf.NullLogger{}, map[string]bool{"istio-ingress-wildcard.example.com": true})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	certificates, err := dc.Resource(certificateGvr).Namespace("istio-system").List(metav1.ListOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	names := make([]string, 0)
+	for _, certificate := range certificates.Items {
+		names = append(names, certificate.GetName())
+	}
+	g.Expect(names).To(gomega.ConsistOf("istio-ingress-wildcard.example.com", "istio-ingress-other.example.com"))
+
+	for name, exists := range map[string]bool{
+		"istio-ingress-wildcard.example.com": true,
+		"istio-ingress-removed.example.com":  false,
+		"istio-ingress-other.example.com":    true,
+	} {
+		err := c.Get(context.TODO(), client.ObjectKey{Namespace: "istio-system", Name: name}, &apiv1.Secret{})
+		if exists {
+			g.Expect(err).NotTo(gomega.HaveOccurred(), name)
+		} else {
+			g.Expect(k8serrors.IsNotFound(err)).To(gomega.BeTrue(), name)
+		}
+	}
+}
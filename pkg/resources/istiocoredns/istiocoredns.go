@@ -64,28 +64,16 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
-	var desiredState k8sutil.DesiredState
-	if util.PointerToBool(r.Config.Spec.IstioCoreDNS.Enabled) {
-		desiredState = k8sutil.DesiredStatePresent
-	} else {
-		desiredState = k8sutil.DesiredStateAbsent
-	}
-
-	for _, res := range []resources.Resource{
-		r.serviceAccount,
-		r.clusterRole,
-		r.clusterRoleBinding,
-		r.configMap,
-		r.service,
-		r.deployment,
-	} {
-		o := res()
-		err := k8sutil.Reconcile(log, r.Client, o, desiredState)
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
 		if err != nil {
 			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
 		}
 	}
 
+	desiredState := r.desiredState()
+
 	err := r.reconcileCoreDNSConfigMap(log, desiredState)
 	if err != nil {
 		return emperror.WrapWith(err, "failed to update coredns configmap")
@@ -100,3 +88,30 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	return nil
 }
+
+// Resources returns the typed objects of the component with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
+	desiredState := r.desiredState()
+
+	return []resources.ResourceWithDesiredState{
+		{Resource: r.serviceAccount, DesiredState: desiredState},
+		{Resource: r.clusterRole, DesiredState: desiredState},
+		{Resource: r.clusterRoleBinding, DesiredState: desiredState},
+		{Resource: r.configMap, DesiredState: desiredState},
+		{Resource: r.service, DesiredState: desiredState},
+		{Resource: r.deployment, DesiredState: desiredState},
+	}
+}
+
+// DynamicResources returns the dynamic objects of the component with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
+	return nil
+}
+
+func (r *Reconciler) desiredState() k8sutil.DesiredState {
+	if util.PointerToBool(r.Config.Spec.IstioCoreDNS.Enabled) {
+		return k8sutil.DesiredStatePresent
+	}
+
+	return k8sutil.DesiredStateAbsent
+}
@@ -45,11 +45,11 @@ func (r *Reconciler) clusterRole() runtime.Object {
 
 func (r *Reconciler) clusterRoleBinding() runtime.Object {
 	return &rbacv1.ClusterRoleBinding{
-		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleBindingName+"-"+r.Config.Namespace, labels, r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(clusterRoleBindingName, labels, r.Config),
 		RoleRef: rbacv1.RoleRef{
 			Kind:     "ClusterRole",
 			APIGroup: "rbac.authorization.k8s.io",
-			Name:     clusterRoleName,
+			Name:     templates.ClusterScopeName(clusterRoleName, r.Config),
 		},
 		Subjects: []rbacv1.Subject{
 			{
@@ -65,6 +65,29 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	for _, dr := range r.DynamicResources() {
+		o := dr.DynamicResource()
+		err := o.Reconcile(log, r.dynamic, dr.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile dynamic resource", "resource", o.Gvr)
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// Resources returns the typed objects of the policy and telemetry components with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
 	var mixerDesiredState k8sutil.DesiredState
 	var pdbDesiredState k8sutil.DesiredState
 	if util.PointerToBool(r.Config.Spec.Mixer.Enabled) {
@@ -92,52 +115,50 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	rs = append(rs, resources.ResolveVariations("policy", rsv, mixerDesiredState)...)
 	rs = append(rs, resources.ResolveVariations("telemetry", rsv, mixerDesiredState)...)
-	for _, res := range rs {
-		o := res.Resource()
-		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
-		}
-	}
-	drs := []resources.DynamicResourceWithDesiredState{
-		{DynamicResource: r.istioProxyAttributeManifest},
-		{DynamicResource: r.kubernetesAttributeManifest},
-		{DynamicResource: r.stdioHandler},
-		{DynamicResource: r.accessLogLogentry},
-		{DynamicResource: r.tcpAccessLogLogentry},
-		{DynamicResource: r.stdioRule},
-		{DynamicResource: r.stdioTcpRule},
-		{DynamicResource: r.prometheusHandler},
-		{DynamicResource: r.requestCountMetric},
-		{DynamicResource: r.requestDurationMetric},
-		{DynamicResource: r.requestSizeMetric},
-		{DynamicResource: r.responseSizeMetric},
-		{DynamicResource: r.tcpByteReceivedMetric},
-		{DynamicResource: r.tcpByteSentMetric},
-		{DynamicResource: r.tcpConnectionsOpenedMetric},
-		{DynamicResource: r.tcpConnectionsClosedMetric},
-		{DynamicResource: r.promHttpRule},
-		{DynamicResource: r.promTcpRule},
-		{DynamicResource: r.promTcpConnectionOpenRule},
-		{DynamicResource: r.promTcpConnectionClosedRule},
-		{DynamicResource: r.kubernetesEnvHandler},
-		{DynamicResource: r.attributesKubernetes},
-		{DynamicResource: r.kubeAttrRule},
-		{DynamicResource: r.tcpKubeAttrRule},
-		{DynamicResource: r.policyDestinationRule},
-		{DynamicResource: r.telemetryDestinationRule},
-	}
-	for _, dr := range drs {
-		o := dr.DynamicResource()
-		err := o.Reconcile(log, r.dynamic, mixerDesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile dynamic resource", "resource", o.Gvr)
-		}
+
+	return rs
+}
+
+// DynamicResources returns the dynamic objects of the mixer with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
+	mixerDesiredState := r.desiredState()
+
+	return []resources.DynamicResourceWithDesiredState{
+		{DynamicResource: r.istioProxyAttributeManifest, DesiredState: mixerDesiredState},
+		{DynamicResource: r.kubernetesAttributeManifest, DesiredState: mixerDesiredState},
+		{DynamicResource: r.stdioHandler, DesiredState: mixerDesiredState},
+		{DynamicResource: r.accessLogLogentry, DesiredState: mixerDesiredState},
+		{DynamicResource: r.tcpAccessLogLogentry, DesiredState: mixerDesiredState},
+		{DynamicResource: r.stdioRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.stdioTcpRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.prometheusHandler, DesiredState: mixerDesiredState},
+		{DynamicResource: r.requestCountMetric, DesiredState: mixerDesiredState},
+		{DynamicResource: r.requestDurationMetric, DesiredState: mixerDesiredState},
+		{DynamicResource: r.requestSizeMetric, DesiredState: mixerDesiredState},
+		{DynamicResource: r.responseSizeMetric, DesiredState: mixerDesiredState},
+		{DynamicResource: r.tcpByteReceivedMetric, DesiredState: mixerDesiredState},
+		{DynamicResource: r.tcpByteSentMetric, DesiredState: mixerDesiredState},
+		{DynamicResource: r.tcpConnectionsOpenedMetric, DesiredState: mixerDesiredState},
+		{DynamicResource: r.tcpConnectionsClosedMetric, DesiredState: mixerDesiredState},
+		{DynamicResource: r.promHttpRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.promTcpRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.promTcpConnectionOpenRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.promTcpConnectionClosedRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.kubernetesEnvHandler, DesiredState: mixerDesiredState},
+		{DynamicResource: r.attributesKubernetes, DesiredState: mixerDesiredState},
+		{DynamicResource: r.kubeAttrRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.tcpKubeAttrRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.policyDestinationRule, DesiredState: mixerDesiredState},
+		{DynamicResource: r.telemetryDestinationRule, DesiredState: mixerDesiredState},
 	}
+}
 
-	log.Info("Reconciled")
+func (r *Reconciler) desiredState() k8sutil.DesiredState {
+	if util.PointerToBool(r.Config.Spec.Mixer.Enabled) {
+		return k8sutil.DesiredStatePresent
+	}
 
-	return nil
+	return k8sutil.DesiredStateAbsent
 }
 
 func deploymentName(t string) string {
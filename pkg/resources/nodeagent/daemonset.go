@@ -58,20 +58,7 @@ func (r *Reconciler) daemonSet() runtime.Object {
 									MountPath: "/var/run/sds",
 								},
 							},
-							Env: []apiv1.EnvVar{
-								{
-									Name:  "CA_PROVIDER",
-									Value: "Citadel",
-								},
-								{
-									Name:  "CA_ADDR",
-									Value: "istio-citadel:8060",
-								},
-								{
-									Name:  "VALID_TOKEN",
-									Value: "true",
-								},
-							},
+							Env: r.env(),
 							Resources: templates.GetResourcesRequirementsOrDefault(
 								r.Config.Spec.NodeAgent.Resources,
 								r.Config.Spec.DefaultResources,
@@ -100,6 +87,69 @@ func (r *Reconciler) daemonSet() runtime.Object {
 	}
 }
 
+// env returns the settings of the CA the node agent requests the workload certificates from
+func (r *Reconciler) env() []apiv1.EnvVar {
+	vault := r.Config.Spec.NodeAgent.Vault
+	if !r.Config.Spec.NodeAgent.IsVaultCAProvider() {
+		return []apiv1.EnvVar{
+			{
+				Name:  "CA_PROVIDER",
+				Value: "Citadel",
+			},
+			{
+				Name:  "CA_ADDR",
+				Value: "istio-citadel:8060",
+			},
+			{
+				Name:  "VALID_TOKEN",
+				Value: "true",
+			},
+		}
+	}
+
+	return []apiv1.EnvVar{
+		{
+			Name:  "CA_PROVIDER",
+			Value: "VaultCA",
+		},
+		{
+			Name:  "CA_ADDR",
+			Value: vault.Address,
+		},
+		{
+			Name:  "VALID_TOKEN",
+			Value: "true",
+		},
+		{
+			Name:  "VAULT_ADDR",
+			Value: vault.Address,
+		},
+		{
+			Name:  "VAULT_AUTH_PATH",
+			Value: vault.AuthPath,
+		},
+		{
+			Name:  "VAULT_ROLE",
+			Value: vault.Role,
+		},
+		{
+			Name:  "VAULT_SIGN_CSR_PATH",
+			Value: vault.SignCSRPath,
+		},
+		{
+			Name: "VAULT_TLS_ROOT_CERT",
+			ValueFrom: &apiv1.EnvVarSource{
+				SecretKeyRef: &apiv1.SecretKeySelector{
+					LocalObjectReference: apiv1.LocalObjectReference{
+						Name: vault.CACertSecret.Name,
+					},
+					Key: vault.CACertSecret.Key,
+				},
+			},
+		},
+	}
+}
+
 func (r *Reconciler) galleyProbe(path string) *apiv1.Probe {
 	return &apiv1.Probe{
 		Handler: apiv1.Handler{
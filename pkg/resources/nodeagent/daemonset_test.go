@@ -0,0 +1,146 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeagent
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apiv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+func testConfig(nodeAgent istiov1beta1.NodeAgentConfiguration) *istiov1beta1.Istio {
+	config := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system"},
+		Spec: istiov1beta1.IstioSpec{
+			Version:   "1.3.5",
+			NodeAgent: nodeAgent,
+		},
+	}
+	istiov1beta1.SetDefaults(config)
+
+	return config
+}
+
+func TestEnv(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	vault := &istiov1beta1.VaultCAConfiguration{
+		Address:     "https://vault.vault:8200",
+		SignCSRPath: "istio_ca/sign/istio-pki-role",
+		AuthPath:    "auth/kubernetes/login",
+		Role:        "istio-cert",
+		CACertSecret: istiov1beta1.VaultCACertSecretReference{
+			Name: "vault-tls",
+			Key:  "ca.crt",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		nodeAgent istiov1beta1.NodeAgentConfiguration
+		expected  []apiv1.EnvVar
+	}{
+		{
+			name: "Citadel",
+			expected: []apiv1.EnvVar{
+				{Name: "CA_PROVIDER", Value: "Citadel"},
+				{Name: "CA_ADDR", Value: "istio-citadel:8060"},
+				{Name: "VALID_TOKEN", Value: "true"},
+			},
+		},
+		{
+			name: "Vault",
+			nodeAgent: istiov1beta1.NodeAgentConfiguration{
+				CAProvider: istiov1beta1.CAProviderVault,
+				Vault:      vault,
+			},
+			expected: []apiv1.EnvVar{
+				{Name: "CA_PROVIDER", Value: "VaultCA"},
+				{Name: "CA_ADDR", Value: "https://vault.vault:8200"},
+				{Name: "VALID_TOKEN", Value: "true"},
+				{Name: "VAULT_ADDR", Value: "https://vault.vault:8200"},
+				{Name: "VAULT_AUTH_PATH", Value: "auth/kubernetes/login"},
+				{Name: "VAULT_ROLE", Value: "istio-cert"},
+				{Name: "VAULT_SIGN_CSR_PATH", Value: "istio_ca/sign/istio-pki-role"},
+				{
+					Name: "VAULT_TLS_ROOT_CERT",
+					ValueFrom: &apiv1.EnvVarSource{
+						SecretKeyRef: &apiv1.SecretKeySelector{
+							LocalObjectReference: apiv1.LocalObjectReference{Name: "vault-tls"},
+							Key:                  "ca.crt",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		r := New(nil, testConfig(test.nodeAgent))
+		g.Expect(r.env()).To(gomega.Equal(test.expected), test.name)
+	}
+}
+
+func TestResourcesWithoutVaultConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	daemonSetState := func(r *Reconciler) (k8sutil.DesiredState, bool) {
+		for _, res := range r.Resources() {
+			if _, ok := res.Resource().(*appsv1.DaemonSet); ok {
+				return res.DesiredState, true
+			}
+		}
+		return "", false
+	}
+
+	// the node agents are not started without the settings of Vault
+	r := New(fake.NewFakeClient(), testConfig(istiov1beta1.NodeAgentConfiguration{
+		Enabled:    util.BoolPointer(true),
+		CAProvider: istiov1beta1.CAProviderVault,
+	}))
+	_, found := daemonSetState(r)
+	g.Expect(found).To(gomega.BeFalse())
+	g.Expect(r.Reconcile(logf.NullLogger{})).NotTo(gomega.Succeed())
+
+	// the daemonset is never built without the settings of Vault
+	r = New(fake.NewFakeClient(), testConfig(istiov1beta1.NodeAgentConfiguration{
+		Enabled:    util.BoolPointer(false),
+		CAProvider: istiov1beta1.CAProviderVault,
+	}))
+	_, found = daemonSetState(r)
+	g.Expect(found).To(gomega.BeFalse())
+	g.Expect(r.Reconcile(logf.NullLogger{})).To(gomega.Succeed())
+
+	// the daemonset is removed when the node agent is disabled
+	r = New(fake.NewFakeClient(), testConfig(istiov1beta1.NodeAgentConfiguration{
+		Enabled:    util.BoolPointer(false),
+		CAProvider: istiov1beta1.CAProviderCitadel,
+	}))
+	state, found := daemonSetState(r)
+	g.Expect(found).To(gomega.BeTrue())
+	g.Expect(state).To(gomega.Equal(k8sutil.DesiredStateAbsent))
+	g.Expect(r.Reconcile(logf.NullLogger{})).To(gomega.Succeed())
+}
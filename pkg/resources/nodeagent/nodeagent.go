@@ -20,6 +20,7 @@ import (
 	"github.com/banzaicloud/istio-operator/pkg/util"
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
+	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
@@ -61,6 +62,25 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 
 	log.Info("Reconciling")
 
+	if util.PointerToBool(r.Config.Spec.NodeAgent.Enabled) && r.isVaultConfigMissing() {
+		return errors.New("the Vault CA provider requires the Vault configuration of the node agent")
+	}
+
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// Resources returns the typed objects of the component with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
 	var nodeAgentDesiredState k8sutil.DesiredState
 	if util.PointerToBool(r.Config.Spec.NodeAgent.Enabled) {
 		nodeAgentDesiredState = k8sutil.DesiredStatePresent
@@ -68,20 +88,26 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		nodeAgentDesiredState = k8sutil.DesiredStateAbsent
 	}
 
-	for _, res := range []resources.Resource{
-		r.serviceAccount,
-		r.clusterRole,
-		r.clusterRoleBinding,
-		r.daemonSet,
-	} {
-		o := res()
-		err := k8sutil.Reconcile(log, r.Client, o, nodeAgentDesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
-		}
+	rs := []resources.ResourceWithDesiredState{
+		{Resource: r.serviceAccount, DesiredState: nodeAgentDesiredState},
+		{Resource: r.clusterRole, DesiredState: nodeAgentDesiredState},
+		{Resource: r.clusterRoleBinding, DesiredState: nodeAgentDesiredState},
+	}
+	// the node agents are not started without the CA they request the workload certificates from
+	if !r.isVaultConfigMissing() {
+		rs = append(rs, resources.ResourceWithDesiredState{Resource: r.daemonSet, DesiredState: nodeAgentDesiredState})
 	}
 
-	log.Info("Reconciled")
+	return rs
+}
+
+// isVaultConfigMissing returns whether the node agent is set to request the workload certificates from Vault
+// without the settings of Vault
+func (r *Reconciler) isVaultConfigMissing() bool {
+	return r.Config.Spec.NodeAgent.IsVaultCAProvider() && r.Config.Spec.NodeAgent.Vault == nil
+}
 
+// DynamicResources returns the dynamic objects of the component with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
 	return nil
 }
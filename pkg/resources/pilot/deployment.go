@@ -57,10 +57,59 @@ func (r *Reconciler) containerArgs() []string {
 	if r.Config.Spec.WatchOneNamespace {
 		containerArgs = append(containerArgs, "-a", r.Config.Namespace)
 	}
+	if r.Config.Spec.Version.IsAtLeast("1.3") {
+		containerArgs = append(containerArgs, "--trust-domain=cluster.local")
+	}
 
 	return containerArgs
 }
 
+func (r *Reconciler) versionSpecificEnv() []apiv1.EnvVar {
+	if r.Config.Spec.Version.IsAtLeast("1.4") {
+		return []apiv1.EnvVar{
+			{Name: "PILOT_ENABLE_PROTOCOL_SNIFFING_FOR_OUTBOUND", Value: "true"},
+			{Name: "PILOT_ENABLE_PROTOCOL_SNIFFING_FOR_INBOUND", Value: "false"},
+		}
+	}
+
+	return []apiv1.EnvVar{
+		{Name: "PILOT_DISABLE_XDS_MARSHALING_TO_ANY", Value: "1"},
+	}
+}
+
+func (r *Reconciler) containerEnv() []apiv1.EnvVar {
+	env := []apiv1.EnvVar{
+		{
+			Name: "POD_NAME",
+			ValueFrom: &apiv1.EnvVarSource{
+				FieldRef: &apiv1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.name",
+				},
+			},
+		},
+		{
+			Name: "POD_NAMESPACE",
+			ValueFrom: &apiv1.EnvVarSource{
+				FieldRef: &apiv1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.namespace",
+				},
+			},
+		},
+		{Name: "PILOT_PUSH_THROTTLE", Value: "100"},
+		{Name: "GODEBUG", Value: "gctrace=2"},
+		{
+			Name:  "PILOT_TRACE_SAMPLING",
+			Value: fmt.Sprintf("%.2f", r.Config.Spec.Pilot.TraceSampling),
+		},
+	}
+	env = append(env, r.versionSpecificEnv()...)
+	env = append(env, apiv1.EnvVar{Name: "MESHNETWORKS_HASH", Value: r.Config.Spec.GetMeshNetworksHash()})
+
+	return env
+}
+
 func (r *Reconciler) containerPorts() []apiv1.ContainerPort {
 
 	containerPorts := []apiv1.ContainerPort{
@@ -96,34 +145,7 @@ func (r *Reconciler) containers() []apiv1.Container {
 			FailureThreshold:    3,
 			SuccessThreshold:    1,
 		},
-		Env: []apiv1.EnvVar{
-			{
-				Name: "POD_NAME",
-				ValueFrom: &apiv1.EnvVarSource{
-					FieldRef: &apiv1.ObjectFieldSelector{
-						APIVersion: "v1",
-						FieldPath:  "metadata.name",
-					},
-				},
-			},
-			{
-				Name: "POD_NAMESPACE",
-				ValueFrom: &apiv1.EnvVarSource{
-					FieldRef: &apiv1.ObjectFieldSelector{
-						APIVersion: "v1",
-						FieldPath:  "metadata.namespace",
-					},
-				},
-			},
-			{Name: "PILOT_PUSH_THROTTLE", Value: "100"},
-			{Name: "GODEBUG", Value: "gctrace=2"},
-			{
-				Name:  "PILOT_TRACE_SAMPLING",
-				Value: fmt.Sprintf("%.2f", r.Config.Spec.Pilot.TraceSampling),
-			},
-			{Name: "PILOT_DISABLE_XDS_MARSHALING_TO_ANY", Value: "1"},
-			{Name: "MESHNETWORKS_HASH", Value: r.Config.Spec.GetMeshNetworksHash()},
-		},
+		Env: r.containerEnv(),
 		Resources: templates.GetResourcesRequirementsOrDefault(
 			r.Config.Spec.Pilot.Resources,
 			r.Config.Spec.DefaultResources,
@@ -215,19 +237,19 @@ func (r *Reconciler) containers() []apiv1.Container {
 
 func (r *Reconciler) deployment() runtime.Object {
 	deployment := &appsv1.Deployment{
-		ObjectMeta: templates.ObjectMeta(deploymentName, util.MergeLabels(pilotLabels, labelSelector), r.Config),
+		ObjectMeta: templates.ObjectMeta(r.deploymentName(), util.MergeLabels(pilotLabels, r.labelSelector()), r.Config),
 		Spec: appsv1.DeploymentSpec{
 			Replicas: util.IntPointer(k8sutil.GetHPAReplicaCountOrDefault(r.Client, types.NamespacedName{
-				Name:      hpaName,
+				Name:      r.hpaName(),
 				Namespace: r.Config.Namespace,
 			}, r.Config.Spec.Pilot.ReplicaCount)),
 			Strategy: templates.DefaultRollingUpdateStrategy(),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: util.MergeLabels(appLabels, labelSelector),
+				MatchLabels: util.MergeLabels(appLabels, r.labelSelector()),
 			},
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      util.MergeLabels(appLabels, labelSelector),
+					Labels:      util.MergeLabels(appLabels, r.labelSelector()),
 					Annotations: templates.DefaultDeployAnnotations(),
 				},
 				Spec: apiv1.PodSpec{
@@ -249,7 +271,7 @@ func (r *Reconciler) deployment() runtime.Object {
 							VolumeSource: apiv1.VolumeSource{
 								ConfigMap: &apiv1.ConfigMapVolumeSource{
 									LocalObjectReference: apiv1.LocalObjectReference{
-										Name: common.IstioConfigMapName,
+										Name: templates.RevisionedName(common.IstioConfigMapName, r.Config),
 									},
 									DefaultMode: util.IntPointer(420),
 								},
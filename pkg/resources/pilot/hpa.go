@@ -24,12 +24,12 @@ import (
 
 func (r *Reconciler) horizontalPodAutoscaler() runtime.Object {
 	return &autoscalev2beta1.HorizontalPodAutoscaler{
-		ObjectMeta: templates.ObjectMeta(hpaName, nil, r.Config),
+		ObjectMeta: templates.ObjectMeta(r.hpaName(), nil, r.Config),
 		Spec: autoscalev2beta1.HorizontalPodAutoscalerSpec{
 			MaxReplicas: r.Config.Spec.Pilot.MaxReplicas,
 			MinReplicas: &r.Config.Spec.Pilot.MinReplicas,
 			ScaleTargetRef: autoscalev2beta1.CrossVersionObjectReference{
-				Name:       deploymentName,
+				Name:       r.deploymentName(),
 				Kind:       "Deployment",
 				APIVersion: "apps/v1",
 			},
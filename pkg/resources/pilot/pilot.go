@@ -25,6 +25,7 @@ import (
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
 	"github.com/banzaicloud/istio-operator/pkg/resources"
+	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
 	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
@@ -62,11 +63,46 @@ func New(client client.Client, dc dynamic.Interface, config *istiov1beta1.Istio)
 	}
 }
 
+func (r *Reconciler) deploymentName() string {
+	return templates.RevisionedName(deploymentName, r.Config)
+}
+
+func (r *Reconciler) hpaName() string {
+	return templates.RevisionedName(hpaName, r.Config)
+}
+
+func (r *Reconciler) labelSelector() map[string]string {
+	return templates.RevisionedLabelSelector(labelSelector, r.Config)
+}
+
 func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 
 	log.Info("Reconciling")
 
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	for _, dr := range r.DynamicResources() {
+		o := dr.DynamicResource()
+		err := o.Reconcile(log, r.dynamic, dr.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile dynamic resource", "resource", o.Gvr)
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// Resources returns the typed objects of the component with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
 	var pilotDesiredState k8sutil.DesiredState
 	var pdbDesiredState k8sutil.DesiredState
 	if util.PointerToBool(r.Config.Spec.Pilot.Enabled) {
@@ -81,20 +117,29 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		pdbDesiredState = k8sutil.DesiredStateAbsent
 	}
 
-	for _, res := range []resources.ResourceWithDesiredState{
-		{Resource: r.serviceAccount, DesiredState: pilotDesiredState},
-		{Resource: r.clusterRole, DesiredState: pilotDesiredState},
-		{Resource: r.clusterRoleBinding, DesiredState: pilotDesiredState},
+	rs := []resources.ResourceWithDesiredState{
 		{Resource: r.deployment, DesiredState: pilotDesiredState},
 		{Resource: r.service, DesiredState: pilotDesiredState},
 		{Resource: r.horizontalPodAutoscaler, DesiredState: pilotDesiredState},
 		{Resource: r.podDisruptionBudget, DesiredState: pdbDesiredState},
-	} {
-		o := res.Resource()
-		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
-		}
+	}
+	// the service account and the RBAC resources are shared between the control plane revisions
+	if r.Config.Spec.GetRevision() == "" {
+		rs = append([]resources.ResourceWithDesiredState{
+			{Resource: r.serviceAccount, DesiredState: pilotDesiredState},
+			{Resource: r.clusterRole, DesiredState: pilotDesiredState},
+			{Resource: r.clusterRoleBinding, DesiredState: pilotDesiredState},
+		}, rs...)
+	}
+
+	return rs
+}
+
+// DynamicResources returns the dynamic objects of the component with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
+	// mesh expansion is served by the default control plane
+	if r.Config.Spec.GetRevision() != "" {
+		return nil
 	}
 
 	var meshExpansionDesiredState k8sutil.DesiredState
@@ -111,18 +156,8 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		controlPlaneSecurityDesiredState = k8sutil.DesiredStateAbsent
 	}
 
-	for _, dr := range []resources.DynamicResourceWithDesiredState{
+	return []resources.DynamicResourceWithDesiredState{
 		{DynamicResource: r.meshExpansionDestinationRule, DesiredState: controlPlaneSecurityDesiredState},
 		{DynamicResource: r.meshExpansionVirtualService, DesiredState: meshExpansionDesiredState},
-	} {
-		o := dr.DynamicResource()
-		err := o.Reconcile(log, r.dynamic, dr.DesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile dynamic resource", "resource", o.Gvr)
-		}
 	}
-
-	log.Info("Reconciled")
-
-	return nil
 }
@@ -26,7 +26,7 @@ import (
 
 func (r *Reconciler) service() runtime.Object {
 	return &apiv1.Service{
-		ObjectMeta: templates.ObjectMeta(serviceName, pilotLabels, r.Config),
+		ObjectMeta: templates.ObjectMeta(templates.RevisionedName(serviceName, r.Config), pilotLabels, r.Config),
 		Spec: apiv1.ServiceSpec{
 			Ports: []apiv1.ServicePort{
 				{
@@ -54,7 +54,7 @@ func (r *Reconciler) service() runtime.Object {
 					Protocol:   apiv1.ProtocolTCP,
 				},
 			},
-			Selector: labelSelector,
+			Selector: r.labelSelector(),
 		},
 	}
 }
@@ -18,7 +18,9 @@ package resources
 
 import (
 	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
@@ -27,13 +29,71 @@ import (
 
 type Reconciler struct {
 	client.Client
-	Config *istiov1beta1.Istio
+	Config   *istiov1beta1.Istio
+	Recorder *k8sutil.EventRecorder
+}
+
+// SetEventRecorder sets the recorder the changes of the managed objects are recorded with
+func (r *Reconciler) SetEventRecorder(recorder *k8sutil.EventRecorder) {
+	r.Recorder = recorder
 }
 
 type ComponentReconciler interface {
 	Reconcile(log logr.Logger) error
 }
 
+// EventRecording is implemented by the component reconcilers which record the changes of the managed objects as events
+type EventRecording interface {
+	SetEventRecorder(recorder *k8sutil.EventRecorder)
+}
+
+// SetEventRecorder sets the recorder of the given component reconcilers which record events
+func SetEventRecorder(recorder *k8sutil.EventRecorder, reconcilers ...ComponentReconciler) {
+	for _, rec := range reconcilers {
+		if recording, ok := rec.(EventRecording); ok {
+			recording.SetEventRecorder(recorder)
+		}
+	}
+}
+
+// ComponentRenderer is implemented by the components whose objects can be built without contacting the cluster
+type ComponentRenderer interface {
+	Resources() []ResourceWithDesiredState
+	DynamicResources() []DynamicResourceWithDesiredState
+}
+
+// ComponentPlanner is implemented by the components which change more than the objects they render, Plan returns
+// every change the reconciliation of the component would make without applying them
+type ComponentPlanner interface {
+	Plan() ([]k8sutil.PlannedAction, error)
+}
+
+// PlanResources returns the changes of the objects rendered by the component without applying them
+func PlanResources(c client.Client, dc dynamic.Interface, renderer ComponentRenderer) ([]k8sutil.PlannedAction, error) {
+	actions := make([]k8sutil.PlannedAction, 0)
+	for _, res := range renderer.Resources() {
+		action, err := k8sutil.Plan(c, res.Resource(), res.DesiredState)
+		if err != nil {
+			return nil, emperror.Wrap(err, "could not plan resource")
+		}
+		if action != nil {
+			actions = append(actions, *action)
+		}
+	}
+
+	for _, dr := range renderer.DynamicResources() {
+		action, err := dr.DynamicResource().Plan(dc, dr.DesiredState)
+		if err != nil {
+			return nil, emperror.Wrap(err, "could not plan dynamic resource")
+		}
+		if action != nil {
+			actions = append(actions, *action)
+		}
+	}
+
+	return actions, nil
+}
+
 type Resource func() runtime.Object
 
 type ResourceVariation func(t string) runtime.Object
@@ -0,0 +1,226 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revisions
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources"
+	"github.com/banzaicloud/istio-operator/pkg/resources/common"
+	"github.com/banzaicloud/istio-operator/pkg/resources/galley"
+	"github.com/banzaicloud/istio-operator/pkg/resources/pilot"
+	"github.com/banzaicloud/istio-operator/pkg/resources/sidecarinjector"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+const (
+	componentName = "revisions"
+)
+
+// Reconciler reconciles the control plane revisions running alongside the default control plane
+type Reconciler struct {
+	resources.Reconciler
+	dynamic dynamic.Interface
+}
+
+func New(client client.Client, dc dynamic.Interface, config *istiov1beta1.Istio) *Reconciler {
+	return &Reconciler{
+		Reconciler: resources.Reconciler{
+			Client: client,
+			Config: config,
+		},
+		dynamic: dc,
+	}
+}
+
+func (r *Reconciler) Reconcile(log logr.Logger) error {
+	log = log.WithValues("component", componentName)
+
+	log.Info("Reconciling")
+
+	desired := make(map[string]bool)
+	for _, revision := range r.Config.Spec.Revisions {
+		desired[revision.Name] = true
+		config := r.Config.ForRevision(revision)
+		reconcilers := []resources.ComponentReconciler{
+			common.New(r.Client, config, false),
+			galley.New(r.Client, config),
+			pilot.New(r.Client, r.dynamic, config),
+			sidecarinjector.New(r.Client, config),
+		}
+		resources.SetEventRecorder(r.Recorder, reconcilers...)
+		for _, rec := range reconcilers {
+			err := rec.Reconcile(log.WithValues("revision", revision.Name))
+			if err != nil {
+				return emperror.WrapWith(err, "failed to reconcile revision", "revision", revision.Name)
+			}
+		}
+	}
+
+	deployed, err := r.getDeployedRevisions()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range deployed {
+		if desired[name] {
+			continue
+		}
+
+		// the mesh config is removed last as it is used to discover the deployed revisions
+		config := removedRevision(r.Config, name)
+		reconcilers := []resources.ComponentReconciler{
+			sidecarinjector.New(r.Client, config),
+			pilot.New(r.Client, r.dynamic, config),
+			galley.New(r.Client, config),
+			common.New(r.Client, config, false),
+		}
+		resources.SetEventRecorder(r.Recorder, reconcilers...)
+		for _, rec := range reconcilers {
+			err := rec.Reconcile(log.WithValues("revision", name))
+			if err != nil {
+				return emperror.WrapWith(err, "failed to remove revision", "revision", name)
+			}
+		}
+
+		log.Info("revision removed", "revision", name)
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// Plan returns the changes of the objects of the desired revisions and the removal of the deployed revisions
+// which are not desired anymore without applying them
+func (r *Reconciler) Plan() ([]k8sutil.PlannedAction, error) {
+	actions, err := resources.PlanResources(r.Client, r.dynamic, r)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed, err := r.getDeployedRevisions()
+	if err != nil {
+		return nil, err
+	}
+
+	desired := make(map[string]bool)
+	for _, revision := range r.Config.Spec.Revisions {
+		desired[revision.Name] = true
+	}
+
+	for _, name := range deployed {
+		if desired[name] {
+			continue
+		}
+
+		for _, renderer := range r.renderers(removedRevision(r.Config, name)) {
+			removals, err := resources.PlanResources(r.Client, r.dynamic, renderer)
+			if err != nil {
+				return nil, emperror.WrapWith(err, "failed to plan the removal of revision", "revision", name)
+			}
+			actions = append(actions, removals...)
+		}
+	}
+
+	return actions, nil
+}
+
+// Resources returns the typed objects of the desired revisions with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
+	rs := make([]resources.ResourceWithDesiredState, 0)
+	for _, revision := range r.Config.Spec.Revisions {
+		for _, renderer := range r.renderers(r.Config.ForRevision(revision)) {
+			rs = append(rs, renderer.Resources()...)
+		}
+	}
+
+	return rs
+}
+
+// DynamicResources returns the dynamic objects of the desired revisions with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
+	drs := make([]resources.DynamicResourceWithDesiredState, 0)
+	for _, revision := range r.Config.Spec.Revisions {
+		for _, renderer := range r.renderers(r.Config.ForRevision(revision)) {
+			drs = append(drs, renderer.DynamicResources()...)
+		}
+	}
+
+	return drs
+}
+
+func (r *Reconciler) renderers(config *istiov1beta1.Istio) []resources.ComponentRenderer {
+	return []resources.ComponentRenderer{
+		common.New(r.Client, config, false),
+		galley.New(r.Client, config),
+		pilot.New(r.Client, r.dynamic, config),
+		sidecarinjector.New(r.Client, config),
+	}
+}
+
+// removedRevision returns the config of a deployed revision which is not desired anymore, its components are disabled
+func removedRevision(config *istiov1beta1.Istio, name string) *istiov1beta1.Istio {
+	revisionConfig := config.ForRevision(istiov1beta1.RevisionConfiguration{
+		Name: name,
+	})
+	revisionConfig.Spec.Pilot.Enabled = util.BoolPointer(false)
+	revisionConfig.Spec.Galley.Enabled = util.BoolPointer(false)
+	revisionConfig.Spec.SidecarInjector.Enabled = util.BoolPointer(false)
+
+	return revisionConfig
+}
+
+// getDeployedRevisions returns the names of the revisions which have a mesh config owned by the Istio resource
+func (r *Reconciler) getDeployedRevisions() ([]string, error) {
+	var configMaps corev1.ConfigMapList
+	o := &client.ListOptions{
+		Namespace: r.Config.Namespace,
+	}
+	err := o.SetLabelSelector(istiov1beta1.RevisionLabel)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not set label selector to list options", "selector", istiov1beta1.RevisionLabel)
+	}
+
+	err = r.Client.List(context.Background(), o, &configMaps)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not list config maps")
+	}
+
+	revisions := make([]string, 0)
+	for _, cm := range configMaps.Items {
+		revision := cm.Labels[istiov1beta1.RevisionLabel]
+		if cm.Name != common.IstioConfigMapName+"-"+revision {
+			continue
+		}
+		if ref := metav1.GetControllerOf(&cm); ref == nil || ref.UID != r.Config.UID {
+			continue
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revisions
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
+	"github.com/banzaicloud/istio-operator/pkg/resources/common"
+	"github.com/banzaicloud/istio-operator/pkg/util"
+)
+
+func TestPlanRemovedRevision(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system", UID: "mesh-uid"},
+		Spec:       istiov1beta1.IstioSpec{Version: "1.3.5"},
+	}
+	istiov1beta1.SetDefaults(config)
+
+	// the mesh config of the revision is used to discover it
+	c := fake.NewFakeClient(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      common.IstioConfigMapName + "-canary",
+			Namespace: "istio-system",
+			Labels:    map[string]string{istiov1beta1.RevisionLabel: "canary"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "Istio", Name: "mesh", UID: "mesh-uid", Controller: util.BoolPointer(true)},
+			},
+		},
+	})
+
+	actions, err := New(c, dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()), config).Plan()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(actions).To(gomega.ConsistOf(k8sutil.PlannedAction{
+		Action:     k8sutil.ActionDelete,
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  "istio-system",
+		Name:       common.IstioConfigMapName + "-canary",
+	}))
+}
@@ -31,7 +31,7 @@ import (
 
 func (r *Reconciler) configMap() runtime.Object {
 	return &apiv1.ConfigMap{
-		ObjectMeta: templates.ObjectMeta(configMapName, util.MergeLabels(sidecarInjectorLabels, labelSelector), r.Config),
+		ObjectMeta: templates.ObjectMeta(templates.RevisionedName(configMapName, r.Config), util.MergeLabels(sidecarInjectorLabels, r.labelSelector()), r.Config),
 		Data: map[string]string{
 			"config": r.siConfig(),
 			"values": r.getValues(),
@@ -234,7 +234,7 @@ containers:
   - name: ISTIO_META_INTERCEPTION_MODE
     value: "{{ or (index .ObjectMeta.Annotations ` + "`" + `sidecar.istio.io/interceptionMode` + "`" + `) .ProxyConfig.InterceptionMode.String }}"
   - name: ISTIO_META_INCLUDE_INBOUND_PORTS
-    value: "{{ annotation .ObjectMeta ` + "`" + `traffic.sidecar.istio.io/includeInboundPorts` + "`" + ` (applicationPorts .Spec.Containers) }}"
+    value: "{{ annotation .ObjectMeta ` + "`" + `traffic.sidecar.istio.io/includeInboundPorts` + "`" + ` (applicationPorts .Spec.Containers) }}"` + r.versionSpecificProxyEnv() + `
   {{- if .Values.global.network }}
   - name: ISTIO_META_NETWORK
     value: "{{ .Values.global.network }}"
@@ -410,6 +410,40 @@ func (r *Reconciler) tracingProxyArgs() string {
 `
 }
 
+func (r *Reconciler) versionSpecificProxyEnv() string {
+	env := ""
+	if r.Config.Spec.Version.IsAtLeast("1.3") {
+		env += `
+  - name: ISTIO_META_POD_PORTS
+    value: |-
+      [
+      {{- range $index1, $c := .Spec.Containers }}
+        {{- range $index2, $p := $c.Ports }}
+          {{if or (ne $index1 0) (ne $index2 0)}},{{end}}{{ structToJSON $p }}
+        {{- end}}
+      {{- end}}
+      ]`
+	}
+	if r.Config.Spec.Version.IsAtLeast("1.4") {
+		env += `
+  - name: ISTIO_META_CLUSTER_ID
+    value: "Kubernetes"`
+	}
+
+	return env
+}
+
+func (r *Reconciler) proxyInitCommand() string {
+	// the iptables script has been replaced by the istio-iptables binary in 1.4
+	if r.Config.Spec.Version.IsAtLeast("1.4") {
+		return `
+  command:
+  - istio-iptables`
+	}
+
+	return ""
+}
+
 func (r *Reconciler) coreDumpContainer() string {
 	if !r.Config.Spec.Proxy.EnableCoreDump {
 		return ""
@@ -431,7 +465,7 @@ func (r *Reconciler) proxyInitContainer() string {
 	}
 
 	return `- name: istio-init
-  image: "{{ .Values.global.proxy_init.image }}"
+  image: "{{ .Values.global.proxy_init.image }}"` + r.proxyInitCommand() + `
   args:
   - "-p"
   - "15001"
@@ -29,37 +29,44 @@ import (
 	"github.com/banzaicloud/istio-operator/pkg/util"
 )
 
+func (r *Reconciler) containerArgs() []string {
+	containerArgs := []string{
+		"--caCertFile=/etc/istio/certs/root-cert.pem",
+		"--tlsCertFile=/etc/istio/certs/cert-chain.pem",
+		"--tlsKeyFile=/etc/istio/certs/key.pem",
+		"--injectConfig=/etc/istio/inject/config",
+		"--meshConfig=/etc/istio/config/mesh",
+		"--healthCheckInterval=2s",
+		"--healthCheckFile=/health",
+		fmt.Sprintf("--webhookConfigName=%s", r.webhookName()),
+	}
+
+	return containerArgs
+}
+
 func (r *Reconciler) deployment() runtime.Object {
 	return &appsv1.Deployment{
-		ObjectMeta: templates.ObjectMeta(deploymentName, util.MergeLabels(sidecarInjectorLabels, labelSelector), r.Config),
+		ObjectMeta: templates.ObjectMeta(templates.RevisionedName(deploymentName, r.Config), util.MergeLabels(sidecarInjectorLabels, r.labelSelector()), r.Config),
 		Spec: appsv1.DeploymentSpec{
 			Replicas: &r.Config.Spec.SidecarInjector.ReplicaCount,
 			Strategy: templates.DefaultRollingUpdateStrategy(),
 			Selector: &metav1.LabelSelector{
-				MatchLabels: util.MergeLabels(sidecarInjectorLabels, labelSelector),
+				MatchLabels: util.MergeLabels(sidecarInjectorLabels, r.labelSelector()),
 			},
 			Template: apiv1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels:      util.MergeLabels(sidecarInjectorLabels, labelSelector),
+					Labels:      util.MergeLabels(sidecarInjectorLabels, r.labelSelector()),
 					Annotations: templates.DefaultDeployAnnotations(),
 				},
 				Spec: apiv1.PodSpec{
-					ServiceAccountName: serviceAccountName,
+					ServiceAccountName: r.serviceAccountName(),
 					PriorityClassName:  "",
 					Containers: []apiv1.Container{
 						{
 							Name:            "sidecar-injector-webhook",
 							Image:           r.Config.Spec.SidecarInjector.Image,
 							ImagePullPolicy: r.Config.Spec.ImagePullPolicy,
-							Args: []string{
-								"--caCertFile=/etc/istio/certs/root-cert.pem",
-								"--tlsCertFile=/etc/istio/certs/cert-chain.pem",
-								"--tlsKeyFile=/etc/istio/certs/key.pem",
-								"--injectConfig=/etc/istio/inject/config",
-								"--meshConfig=/etc/istio/config/mesh",
-								"--healthCheckInterval=2s",
-								"--healthCheckFile=/health",
-							},
+							Args:            r.containerArgs(),
 							VolumeMounts: []apiv1.VolumeMount{
 								{
 									Name:      "config-volume",
@@ -92,7 +99,7 @@ func (r *Reconciler) deployment() runtime.Object {
 							Name: "certs",
 							VolumeSource: apiv1.VolumeSource{
 								Secret: &apiv1.SecretVolumeSource{
-									SecretName:  fmt.Sprintf("istio.%s", serviceAccountName),
+									SecretName:  fmt.Sprintf("istio.%s", r.serviceAccountName()),
 									DefaultMode: util.IntPointer(420),
 								},
 							},
@@ -102,7 +109,7 @@ func (r *Reconciler) deployment() runtime.Object {
 							VolumeSource: apiv1.VolumeSource{
 								ConfigMap: &apiv1.ConfigMapVolumeSource{
 									LocalObjectReference: apiv1.LocalObjectReference{
-										Name: common.IstioConfigMapName,
+										Name: templates.RevisionedName(common.IstioConfigMapName, r.Config),
 									},
 									DefaultMode: util.IntPointer(420),
 								},
@@ -113,7 +120,7 @@ func (r *Reconciler) deployment() runtime.Object {
 							VolumeSource: apiv1.VolumeSource{
 								ConfigMap: &apiv1.ConfigMapVolumeSource{
 									LocalObjectReference: apiv1.LocalObjectReference{
-										Name: configMapName,
+										Name: templates.RevisionedName(configMapName, r.Config),
 									},
 									Items: []apiv1.KeyToPath{
 										{
@@ -18,12 +18,14 @@ package sidecarinjector
 
 import (
 	"context"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/goph/emperror"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
 )
 
@@ -32,45 +34,126 @@ const (
 	autoInjectionLabelKey        = "istio-injection"
 )
 
+// namespaceLabels are the labels to be added to and removed from a namespace
+type namespaceLabels struct {
+	namespace string
+	labels    map[string]string
+	remove    []string
+}
+
 func (r *Reconciler) reconcileAutoInjectionLabels(log logr.Logger) error {
-	var autoInjectLabels = map[string]string{
-		autoInjectionLabelKey:        "enabled",
-		managedAutoInjectionLabelKey: "enabled",
+	changes, err := r.namespaceLabelChanges()
+	if err != nil {
+		return err
 	}
 
-	managedNamespaces := make(map[string]bool)
-	for _, ns := range r.Config.Spec.AutoInjectionNamespaces {
-		managedNamespaces[ns] = true
-		err := k8sutil.ReconcileNamespaceLabelsIgnoreNotFound(log, r.Client, ns, autoInjectLabels, nil)
+	for _, change := range changes {
+		err := k8sutil.ReconcileNamespaceLabelsIgnoreNotFound(log, r.Client, change.namespace, change.labels, change.remove)
 		if err != nil {
-			log.Error(err, "failed to label namespace", "namespace", ns)
+			log.Error(emperror.Wrap(err, "failed to label namespace"), "namespace", change.namespace)
 		}
 	}
 
-	var namespaces corev1.NamespaceList
-	o := &client.ListOptions{}
-	selector := managedAutoInjectionLabelKey + "=" + autoInjectLabels[managedAutoInjectionLabelKey]
-	err := o.SetLabelSelector(selector)
+	return nil
+}
+
+// planAutoInjectionLabels returns the changes of the namespace labels without applying them
+func (r *Reconciler) planAutoInjectionLabels() ([]k8sutil.PlannedAction, error) {
+	changes, err := r.namespaceLabelChanges()
 	if err != nil {
-		return emperror.WrapWith(err, "could set label selector to list options", "selector", selector)
+		return nil, err
+	}
+
+	actions := make([]k8sutil.PlannedAction, 0)
+	for _, change := range changes {
+		action, err := k8sutil.PlanNamespaceLabels(r.Client, change.namespace, change.labels, change.remove)
+		if err != nil {
+			return nil, err
+		}
+		if action != nil {
+			actions = append(actions, *action)
+		}
+	}
+
+	return actions, nil
+}
+
+// namespaceLabelChanges returns the label changes which assign the namespaces to the mesh: the auto injection
+// labels of the namespaces listed in autoInjectionNamespaces, and the labels pointing the namespaces of the mesh
+// and the namespace of the control plane at the Citadel of the mesh, so that the Citadels of the other meshes
+// leave their service account secrets alone
+func (r *Reconciler) namespaceLabelChanges() ([]namespaceLabels, error) {
+	var autoInjectLabels = map[string]string{
+		autoInjectionLabelKey:        "enabled",
+		managedAutoInjectionLabelKey: "enabled",
+		istiov1beta1.MeshLabel:       r.Config.Namespace,
+	}
+	var citadelLabels = map[string]string{
+		istiov1beta1.CitadelNamespaceLabel: r.Config.Namespace,
 	}
 
-	err = r.Client.List(context.Background(), o, &namespaces)
+	var namespaces corev1.NamespaceList
+	err := r.Client.List(context.Background(), &client.ListOptions{}, &namespaces)
 	if err != nil {
-		return emperror.Wrap(err, "could not list namespaces")
+		return nil, emperror.Wrap(err, "could not list namespaces")
+	}
+
+	managedNamespaces := make(map[string]bool)
+	for _, ns := range r.Config.Spec.AutoInjectionNamespaces {
+		managedNamespaces[ns] = true
 	}
 
+	changes := make([]namespaceLabels, 0)
 	for _, ns := range namespaces.Items {
-		if !managedNamespaces[ns.Name] {
-			err := k8sutil.ReconcileNamespaceLabelsIgnoreNotFound(log, r.Client, ns.Name, nil, []string{
-				autoInjectionLabelKey,
-				managedAutoInjectionLabelKey,
-			})
-			if err != nil {
-				log.Error(emperror.Wrap(err, "failed to label namespace"), "namespace", ns.Name)
+		current := ns.Labels
+		desired := make(map[string]string, len(current))
+		for k, v := range current {
+			desired[k] = v
+		}
+
+		if managedNamespaces[ns.Name] {
+			for k, v := range autoInjectLabels {
+				desired[k] = v
+			}
+		} else if current[managedAutoInjectionLabelKey] == autoInjectLabels[managedAutoInjectionLabelKey] {
+			// namespaces managed by the control planes of other meshes are left alone
+			if mesh := current[istiov1beta1.MeshLabel]; mesh == r.Config.Namespace || (mesh == "" && r.Config.IsDefaultMesh()) {
+				delete(desired, autoInjectionLabelKey)
+				delete(desired, managedAutoInjectionLabelKey)
+				delete(desired, istiov1beta1.MeshLabel)
 			}
 		}
+
+		inMesh := ns.Name == r.Config.Namespace || desired[istiov1beta1.MeshLabel] == r.Config.Namespace
+		targeted := desired[istiov1beta1.CitadelNamespaceLabel] == r.Config.Namespace
+		switch {
+		case inMesh && !targeted:
+			for k, v := range citadelLabels {
+				desired[k] = v
+			}
+		case !inMesh && targeted:
+			delete(desired, istiov1beta1.CitadelNamespaceLabel)
+		}
+
+		change := namespaceLabels{
+			namespace: ns.Name,
+			labels:    make(map[string]string),
+		}
+		for k, v := range desired {
+			if cv, ok := current[k]; !ok || cv != v {
+				change.labels[k] = v
+			}
+		}
+		for k := range current {
+			if _, ok := desired[k]; !ok {
+				change.remove = append(change.remove, k)
+			}
+		}
+		sort.Strings(change.remove)
+		if len(change.labels) > 0 || len(change.remove) > 0 {
+			changes = append(changes, change)
+		}
 	}
 
-	return nil
+	return changes, nil
 }
@@ -0,0 +1,204 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarinjector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/resources/citadel"
+)
+
+// selectingClient filters the listed namespaces by the label selector, which is ignored by the fake client
+type selectingClient struct {
+	client.Client
+}
+
+func (c selectingClient) List(ctx context.Context, opts *client.ListOptions, list runtime.Object) error {
+	err := c.Client.List(ctx, opts, list)
+	namespaces, ok := list.(*corev1.NamespaceList)
+	if err != nil || !ok || opts.LabelSelector == nil {
+		return err
+	}
+
+	selected := make([]corev1.Namespace, 0, len(namespaces.Items))
+	for _, ns := range namespaces.Items {
+		if opts.LabelSelector.Matches(labels.Set(ns.Labels)) {
+			selected = append(selected, ns)
+		}
+	}
+	namespaces.Items = selected
+
+	return nil
+}
+
+// citadelManages mirrors the namespace targeting of Citadel: a namespace labelled with ca.istio.io/env is only
+// managed by the Citadel running in the namespace of the label, the others unless Citadel requires an opt in
+func citadelManages(deployment *appsv1.Deployment, ns corev1.Namespace) bool {
+	if namespace, ok := ns.Labels[istiov1beta1.CitadelNamespaceLabel]; ok {
+		return namespace == deployment.Namespace
+	}
+	for _, arg := range deployment.Spec.Template.Spec.Containers[0].Args {
+		if arg == "--explicit-opt-in-required=true" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func citadelDeployment(config *istiov1beta1.Istio) *appsv1.Deployment {
+	for _, res := range citadel.New(citadel.Configuration{}, nil, nil, config).Resources() {
+		if deployment, ok := res.Resource().(*appsv1.Deployment); ok {
+			return deployment
+		}
+	}
+
+	return nil
+}
+
+func TestCitadelsOfMeshes(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	meshes := []string{"istio-system", "mesh-b"}
+	meshConfig := func(namespace string, autoInjectionNamespaces ...string) *istiov1beta1.Istio {
+		config := &istiov1beta1.Istio{
+			ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: namespace},
+			Spec: istiov1beta1.IstioSpec{
+				Version:                 "1.3.5",
+				AutoInjectionNamespaces: autoInjectionNamespaces,
+			},
+		}
+		istiov1beta1.SetDefaults(config)
+		config.Spec.SetMeshes(meshes)
+		return config
+	}
+	namespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	c := selectingClient{fake.NewFakeClient(
+		namespace("istio-system", nil),
+		namespace("mesh-b", nil),
+		namespace("apps-a", nil),
+		namespace("apps-b", nil),
+		namespace("assigned-b", map[string]string{istiov1beta1.MeshLabel: "mesh-b"}),
+		namespace("left-b", map[string]string{istiov1beta1.CitadelNamespaceLabel: "mesh-b"}),
+		namespace("default", nil),
+	)}
+	configs := []*istiov1beta1.Istio{
+		meshConfig("istio-system", "apps-a"),
+		meshConfig("mesh-b", "apps-b"),
+	}
+	for _, config := range configs {
+		g.Expect(New(c, config).reconcileAutoInjectionLabels(logf.NullLogger{})).To(gomega.Succeed())
+	}
+
+	var namespaces corev1.NamespaceList
+	g.Expect(c.List(context.TODO(), &client.ListOptions{}, &namespaces)).To(gomega.Succeed())
+	g.Expect(namespaces.Items).To(gomega.HaveLen(7))
+
+	expected := map[string]string{
+		"istio-system": "istio-system",
+		"mesh-b":       "mesh-b",
+		"apps-a":       "istio-system",
+		"apps-b":       "mesh-b",
+		"assigned-b":   "mesh-b",
+		"left-b":       "istio-system",
+		"default":      "istio-system",
+	}
+	for _, ns := range namespaces.Items {
+		var managers []string
+		for _, config := range configs {
+			if citadelManages(citadelDeployment(config), ns) {
+				managers = append(managers, config.Namespace)
+			}
+		}
+		g.Expect(managers).To(gomega.Equal([]string{expected[ns.Name]}), ns.Name)
+	}
+}
+
+func TestPlanAutoInjectionLabels(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{Name: "mesh", Namespace: "istio-system"},
+		Spec: istiov1beta1.IstioSpec{
+			Version:                 "1.3.5",
+			AutoInjectionNamespaces: []string{"apps", "missing"},
+		},
+	}
+	istiov1beta1.SetDefaults(config)
+	config.Spec.SetMeshes([]string{"istio-system"})
+
+	c := selectingClient{fake.NewFakeClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "istio-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "apps"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dropped", Labels: map[string]string{
+			autoInjectionLabelKey:              "enabled",
+			managedAutoInjectionLabelKey:       "enabled",
+			istiov1beta1.MeshLabel:             "istio-system",
+			istiov1beta1.CitadelNamespaceLabel: "istio-system",
+		}}},
+	)}
+	r := New(c, config)
+
+	actions, err := r.planAutoInjectionLabels()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	patches := make(map[string]string)
+	for _, action := range actions {
+		g.Expect(action.Kind).To(gomega.Equal("Namespace"))
+		g.Expect(action.Action).To(gomega.BeEquivalentTo("update"))
+		patches[action.Name] = action.Patch
+	}
+	g.Expect(patches).To(gomega.HaveLen(3))
+	g.Expect(patches["istio-system"]).To(gomega.MatchJSON(`{"metadata":{"labels":{"ca.istio.io/env":"istio-system"}}}`))
+	g.Expect(patches["apps"]).To(gomega.MatchJSON(`{"metadata":{"labels":{
+		"istio-injection":"enabled",
+		"istio-operator-managed-injection":"enabled",
+		"istio.banzaicloud.io/mesh":"istio-system",
+		"ca.istio.io/env":"istio-system"
+	}}}`))
+	g.Expect(patches["dropped"]).To(gomega.MatchJSON(`{"metadata":{"labels":{
+		"istio-injection":null,
+		"istio-operator-managed-injection":null,
+		"istio.banzaicloud.io/mesh":null,
+		"ca.istio.io/env":null
+	}}}`))
+
+	// the plan leaves the namespaces alone
+	var ns corev1.Namespace
+	g.Expect(c.Get(context.TODO(), client.ObjectKey{Name: "apps"}, &ns)).To(gomega.Succeed())
+	g.Expect(ns.Labels).To(gomega.BeEmpty())
+
+	// nothing is left to plan once the labels are reconciled
+	g.Expect(r.reconcileAutoInjectionLabels(logf.NullLogger{})).To(gomega.Succeed())
+	actions, err = r.planAutoInjectionLabels()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(actions).To(gomega.BeEmpty())
+}
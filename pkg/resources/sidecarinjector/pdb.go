@@ -27,9 +27,9 @@ import (
 )
 
 func (r *Reconciler) podDisruptionBudget() runtime.Object {
-	labels := util.MergeLabels(sidecarInjectorLabels, labelSelector)
+	labels := util.MergeLabels(sidecarInjectorLabels, r.labelSelector())
 	return &policyv1beta1.PodDisruptionBudget{
-		ObjectMeta: templates.ObjectMeta(pdbName, labels, r.Config),
+		ObjectMeta: templates.ObjectMeta(templates.RevisionedName(pdbName, r.Config), labels, r.Config),
 		Spec: policyv1beta1.PodDisruptionBudgetSpec{
 			MinAvailable: util.IntstrPointer(1),
 			Selector: &metav1.LabelSelector{
@@ -27,7 +27,7 @@ import (
 
 func (r *Reconciler) service() runtime.Object {
 	return &apiv1.Service{
-		ObjectMeta: templates.ObjectMeta(serviceName, util.MergeLabels(sidecarInjectorLabels, labelSelector), r.Config),
+		ObjectMeta: templates.ObjectMeta(r.serviceName(), util.MergeLabels(sidecarInjectorLabels, r.labelSelector()), r.Config),
 		Spec: apiv1.ServiceSpec{
 			Ports: []apiv1.ServicePort{
 				{
@@ -36,7 +36,7 @@ func (r *Reconciler) service() runtime.Object {
 					TargetPort: intstr.FromInt(443),
 				},
 			},
-			Selector: labelSelector,
+			Selector: r.labelSelector(),
 		},
 	}
 }
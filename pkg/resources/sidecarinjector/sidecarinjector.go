@@ -26,6 +26,7 @@ import (
 	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
 	"github.com/banzaicloud/istio-operator/pkg/k8sutil"
 	"github.com/banzaicloud/istio-operator/pkg/resources"
+	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
 )
 
 const (
@@ -65,11 +66,69 @@ func New(client client.Client, config *istiov1beta1.Istio) *Reconciler {
 	}
 }
 
+// the sidecar injector of each revision runs with its own service account to get a certificate for its own service name
+func (r *Reconciler) serviceAccountName() string {
+	return templates.RevisionedName(serviceAccountName, r.Config)
+}
+
+func (r *Reconciler) webhookName() string {
+	return templates.ClusterScopeName(templates.RevisionedName(webhookName, r.Config), r.Config)
+}
+
+func (r *Reconciler) serviceName() string {
+	return templates.RevisionedName(serviceName, r.Config)
+}
+
+func (r *Reconciler) labelSelector() map[string]string {
+	return templates.RevisionedLabelSelector(labelSelector, r.Config)
+}
+
 func (r *Reconciler) Reconcile(log logr.Logger) error {
 	log = log.WithValues("component", componentName)
 
 	log.Info("Reconciling")
 
+	for _, res := range r.Resources() {
+		o := res.Resource()
+		err := k8sutil.Reconcile(log, r.Client, o, res.DesiredState, r.Recorder)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+		}
+	}
+
+	// namespaces opt into revisions by labelling them on their own
+	if util.PointerToBool(r.Config.Spec.SidecarInjector.Enabled) && r.Config.Spec.GetRevision() == "" {
+		err := r.reconcileAutoInjectionLabels(log)
+		if err != nil {
+			return emperror.WrapWith(err, "failed to label namespaces")
+		}
+	}
+
+	log.Info("Reconciled")
+
+	return nil
+}
+
+// Plan returns the changes of the objects of the component and of the namespace labels without applying them
+func (r *Reconciler) Plan() ([]k8sutil.PlannedAction, error) {
+	actions, err := resources.PlanResources(r.Client, nil, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if util.PointerToBool(r.Config.Spec.SidecarInjector.Enabled) && r.Config.Spec.GetRevision() == "" {
+		labelActions, err := r.planAutoInjectionLabels()
+		if err != nil {
+			return nil, emperror.Wrap(err, "could not plan namespace labels")
+		}
+		actions = append(actions, labelActions...)
+	}
+
+	return actions, nil
+}
+
+// Resources returns the typed objects of the component with their desired state
+func (r *Reconciler) Resources() []resources.ResourceWithDesiredState {
 	var sidecarInjectorDesiredState k8sutil.DesiredState
 	if util.PointerToBool(r.Config.Spec.SidecarInjector.Enabled) {
 		sidecarInjectorDesiredState = k8sutil.DesiredStatePresent
@@ -77,7 +136,7 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		sidecarInjectorDesiredState = k8sutil.DesiredStateAbsent
 	}
 
-	for _, res := range []resources.Resource{
+	rs := []resources.Resource{
 		r.serviceAccount,
 		r.clusterRole,
 		r.clusterRoleBinding,
@@ -85,22 +144,31 @@ func (r *Reconciler) Reconcile(log logr.Logger) error {
 		r.deployment,
 		r.service,
 		r.webhook,
-	} {
-		o := res()
-		err := k8sutil.Reconcile(log, r.Client, o, sidecarInjectorDesiredState)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to reconcile resource", "resource", o.GetObjectKind().GroupVersionKind())
+	}
+	// the cluster role is shared between the control plane revisions
+	if r.Config.Spec.GetRevision() != "" {
+		rs = []resources.Resource{
+			r.serviceAccount,
+			r.clusterRoleBinding,
+			r.configMap,
+			r.deployment,
+			r.service,
+			r.webhook,
 		}
 	}
 
-	if util.PointerToBool(r.Config.Spec.SidecarInjector.Enabled) {
-		err := r.reconcileAutoInjectionLabels(log)
-		if err != nil {
-			return emperror.WrapWith(err, "failed to label namespaces")
-		}
+	resourcesWithDesiredState := make([]resources.ResourceWithDesiredState, 0, len(rs))
+	for _, res := range rs {
+		resourcesWithDesiredState = append(resourcesWithDesiredState, resources.ResourceWithDesiredState{
+			Resource:     res,
+			DesiredState: sidecarInjectorDesiredState,
+		})
 	}
 
-	log.Info("Reconciled")
+	return resourcesWithDesiredState
+}
 
+// DynamicResources returns the dynamic objects of the component with their desired state
+func (r *Reconciler) DynamicResources() []resources.DynamicResourceWithDesiredState {
 	return nil
 }
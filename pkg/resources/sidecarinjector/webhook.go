@@ -21,6 +21,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+
 	"github.com/banzaicloud/istio-operator/pkg/resources/templates"
 	"github.com/banzaicloud/istio-operator/pkg/util"
 )
@@ -29,13 +31,13 @@ func (r *Reconciler) webhook() runtime.Object {
 	fail := admissionv1beta1.Fail
 	unknownSideEffects := admissionv1beta1.SideEffectClassUnknown
 	webhook := &admissionv1beta1.MutatingWebhookConfiguration{
-		ObjectMeta: templates.ObjectMetaClusterScope(webhookName, sidecarInjectorLabels, r.Config),
+		ObjectMeta: templates.ObjectMetaClusterScope(templates.RevisionedName(webhookName, r.Config), sidecarInjectorLabels, r.Config),
 		Webhooks: []admissionv1beta1.Webhook{
 			{
 				Name: "sidecar-injector.istio.io",
 				ClientConfig: admissionv1beta1.WebhookClientConfig{
 					Service: &admissionv1beta1.ServiceReference{
-						Name:      serviceName,
+						Name:      r.serviceName(),
 						Namespace: r.Config.Namespace,
 						Path:      util.StrPointer("/inject"),
 					},
@@ -64,6 +66,17 @@ func (r *Reconciler) webhook() runtime.Object {
 		},
 	}
 
+	if revision := r.Config.Spec.GetRevision(); revision != "" {
+		webhook.Webhooks[0].NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				istiov1beta1.RevisionLabel: revision,
+			},
+		}
+		r.restrictToMesh(webhook.Webhooks[0].NamespaceSelector)
+
+		return webhook
+	}
+
 	if util.PointerToBool(r.Config.Spec.SidecarInjector.EnableNamespacesByDefault) {
 		webhook.Webhooks[0].NamespaceSelector = &metav1.LabelSelector{
 			MatchExpressions: []metav1.LabelSelectorRequirement{
@@ -81,5 +94,43 @@ func (r *Reconciler) webhook() runtime.Object {
 		}
 	}
 
+	// namespaces opted into a revision are injected by the sidecar injector of the revision
+	if revisions := r.Config.Spec.GetRevisionNames(); len(revisions) > 0 {
+		webhook.Webhooks[0].NamespaceSelector.MatchExpressions = append(webhook.Webhooks[0].NamespaceSelector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      istiov1beta1.RevisionLabel,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   revisions,
+		})
+	}
+	r.restrictToMesh(webhook.Webhooks[0].NamespaceSelector)
+
 	return webhook
 }
+
+// restrictToMesh limits the selector to the namespaces of the mesh when there are multiple control planes in
+// the cluster, the default mesh also injects the namespaces without a mesh label
+func (r *Reconciler) restrictToMesh(selector *metav1.LabelSelector) {
+	var others []string
+	for _, namespace := range r.Config.Spec.GetMeshes() {
+		if namespace != r.Config.Namespace {
+			others = append(others, namespace)
+		}
+	}
+	if len(others) == 0 {
+		return
+	}
+
+	requirement := metav1.LabelSelectorRequirement{
+		Key:      istiov1beta1.MeshLabel,
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   []string{r.Config.Namespace},
+	}
+	if r.Config.IsDefaultMesh() {
+		requirement = metav1.LabelSelectorRequirement{
+			Key:      istiov1beta1.MeshLabel,
+			Operator: metav1.LabelSelectorOpNotIn,
+			Values:   others,
+		}
+	}
+	selector.MatchExpressions = append(selector.MatchExpressions, requirement)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sidecarinjector
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func TestRestrictToMesh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	tests := []struct {
+		name      string
+		namespace string
+		meshes    []string
+		expected  []metav1.LabelSelectorRequirement
+	}{
+		{
+			name:      "single mesh",
+			namespace: "istio-system",
+			meshes:    []string{"istio-system"},
+		},
+		{
+			name:      "meshes not set",
+			namespace: "istio-system",
+		},
+		{
+			name:      "default mesh",
+			namespace: "istio-system",
+			meshes:    []string{"istio-system", "mesh-a", "mesh-b"},
+			expected: []metav1.LabelSelectorRequirement{
+				{
+					Key:      istiov1beta1.MeshLabel,
+					Operator: metav1.LabelSelectorOpNotIn,
+					Values:   []string{"mesh-a", "mesh-b"},
+				},
+			},
+		},
+		{
+			name:      "other mesh",
+			namespace: "mesh-a",
+			meshes:    []string{"istio-system", "mesh-a", "mesh-b"},
+			expected: []metav1.LabelSelectorRequirement{
+				{
+					Key:      istiov1beta1.MeshLabel,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"mesh-a"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		config := &istiov1beta1.Istio{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "mesh",
+				Namespace: test.namespace,
+			},
+		}
+		config.Spec.SetMeshes(test.meshes)
+
+		selector := &metav1.LabelSelector{}
+		New(nil, config).restrictToMesh(selector)
+		g.Expect(selector.MatchExpressions).To(gomega.Equal(test.expected), test.name)
+	}
+}
+
+func TestWebhookNamespaceSelectorOfOtherMesh(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mesh",
+			Namespace: "mesh-a",
+		},
+	}
+	config.Spec.SetMeshes([]string{"istio-system", "mesh-a"})
+
+	// the namespaces opted into injection are only injected by the control plane of their mesh
+	webhook := New(nil, config).webhook().(*admissionv1beta1.MutatingWebhookConfiguration)
+	g.Expect(webhook.Name).To(gomega.Equal("istio-sidecar-injector-mesh-a"))
+	g.Expect(webhook.Webhooks[0].NamespaceSelector.MatchLabels).To(gomega.HaveKeyWithValue("istio-injection", "enabled"))
+	g.Expect(webhook.Webhooks[0].NamespaceSelector.MatchExpressions).To(gomega.ConsistOf(metav1.LabelSelectorRequirement{
+		Key:      istiov1beta1.MeshLabel,
+		Operator: metav1.LabelSelectorOpIn,
+		Values:   []string{"mesh-a"},
+	}))
+}
@@ -27,7 +27,7 @@ func ObjectMeta(name string, labels map[string]string, config *istiov1beta1.Isti
 	return metav1.ObjectMeta{
 		Name:      name,
 		Namespace: config.Namespace,
-		Labels:    labels,
+		Labels:    revisionLabels(labels, config),
 		OwnerReferences: []metav1.OwnerReference{
 			{
 				APIVersion:         config.APIVersion,
@@ -49,8 +49,8 @@ func ObjectMetaWithAnnotations(name string, labels map[string]string, annotation
 
 func ObjectMetaClusterScope(name string, labels map[string]string, config *istiov1beta1.Istio) metav1.ObjectMeta {
 	return metav1.ObjectMeta{
-		Name:   name,
-		Labels: labels,
+		Name:   ClusterScopeName(name, config),
+		Labels: revisionLabels(labels, config),
 		OwnerReferences: []metav1.OwnerReference{
 			{
 				APIVersion:         config.APIVersion,
@@ -64,6 +64,48 @@ func ObjectMetaClusterScope(name string, labels map[string]string, config *istio
 	}
 }
 
+// ClusterScopeName returns the name of a cluster scoped resource of the control plane, it is suffixed with
+// the namespace of the control plane so that the control planes of multiple meshes don't share them
+func ClusterScopeName(name string, config *istiov1beta1.Istio) string {
+	return name + "-" + config.Namespace
+}
+
+// RevisionedName returns the name of a resource of the control plane revision the config belongs to
+func RevisionedName(name string, config *istiov1beta1.Istio) string {
+	if revision := config.Spec.GetRevision(); revision != "" {
+		return name + "-" + revision
+	}
+
+	return name
+}
+
+// RevisionedLabelSelector returns a label selector which only matches the pods of the control plane revision the config belongs to
+func RevisionedLabelSelector(selector map[string]string, config *istiov1beta1.Istio) map[string]string {
+	revision := config.Spec.GetRevision()
+	if revision == "" {
+		return selector
+	}
+
+	revisioned := make(map[string]string)
+	for key, value := range selector {
+		revisioned[key] = value + "-" + revision
+	}
+	revisioned[istiov1beta1.RevisionLabel] = revision
+
+	return revisioned
+}
+
+func revisionLabels(labels map[string]string, config *istiov1beta1.Istio) map[string]string {
+	revision := config.Spec.GetRevision()
+	if revision == "" {
+		return labels
+	}
+
+	return util.MergeLabels(labels, map[string]string{
+		istiov1beta1.RevisionLabel: revision,
+	})
+}
+
 func ControlPlaneAuthPolicy(enabled bool) string {
 	if enabled {
 		return "MUTUAL_TLS"
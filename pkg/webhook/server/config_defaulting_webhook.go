@@ -0,0 +1,163 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/builder"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/version"
+)
+
+func init() {
+	webhooks = append(webhooks, NewConfigDefaultingWebhook, NewRemoteConfigDefaultingWebhook)
+}
+
+const (
+	configDefaultingWebhookName       = "istio.defaulting.banzaicloud.io"
+	configDefaultingWebhookPath       = "/default-istio-config"
+	remoteConfigDefaultingWebhookName = "remoteistio.defaulting.banzaicloud.io"
+	remoteConfigDefaultingWebhookPath = "/default-remoteistio-config"
+)
+
+// NewConfigDefaultingWebhook initializes an Istio config resource defaulting webhook configuration
+func NewConfigDefaultingWebhook(mgr manager.Manager, logger logr.Logger) (*admission.Webhook, error) {
+	return builder.NewWebhookBuilder().
+		Name(configDefaultingWebhookName).
+		Path(configDefaultingWebhookPath).
+		FailurePolicy(admissionregistrationv1beta1.Ignore).
+		Mutating().
+		NamespaceSelector(&metav1.LabelSelector{}).
+		Rules(servedVersionsRule("istios", admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update)).
+		Handlers(&istioConfigDefaulter{
+			logger: logger,
+		}).
+		WithManager(mgr).
+		Build()
+}
+
+// NewRemoteConfigDefaultingWebhook initializes a RemoteIstio config resource defaulting webhook configuration
+func NewRemoteConfigDefaultingWebhook(mgr manager.Manager, logger logr.Logger) (*admission.Webhook, error) {
+	return builder.NewWebhookBuilder().
+		Name(remoteConfigDefaultingWebhookName).
+		Path(remoteConfigDefaultingWebhookPath).
+		FailurePolicy(admissionregistrationv1beta1.Ignore).
+		Mutating().
+		NamespaceSelector(&metav1.LabelSelector{}).
+		Rules(servedVersionsRule("remoteistios", admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update)).
+		Handlers(&remoteIstioConfigDefaulter{
+			logger: logger,
+		}).
+		WithManager(mgr).
+		Build()
+}
+
+type istioConfigDefaulter struct {
+	logger logr.Logger
+}
+
+// istioConfigDefaulter implements admission.Handler.
+var _ admission.Handler = &istioConfigDefaulter{}
+
+// Handle persists the default values the operator reconciles the Istio resource with, so the effective
+// configuration is visible in the resource
+func (wh *istioConfigDefaulter) Handle(ctx context.Context, req types.Request) types.Response {
+	config := &istiov1beta1.Istio{}
+	original, err := decodeObject(req.AdmissionRequest.Object.Raw, config)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	defaulted := config.DeepCopy()
+	if defaulted.Namespace == "" {
+		defaulted.Namespace = req.AdmissionRequest.Namespace
+	}
+
+	// the persisted default images belong to the previous version, they are replaced with the defaults
+	// of the new version on upgrade
+	if req.AdmissionRequest.Operation == admissionv1beta1.Update {
+		previous := &istiov1beta1.Istio{}
+		_, err := decodeObject(req.AdmissionRequest.OldObject.Raw, previous)
+		if err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		if previous.Spec.Version != defaulted.Spec.Version {
+			istiov1beta1.ResetDefaultImages(defaulted, previous.Spec.Version)
+		}
+	}
+
+	istiov1beta1.SetDefaults(defaulted)
+	defaulted.Namespace = config.Namespace
+
+	if reflect.DeepEqual(config.Spec, defaulted.Spec) {
+		return admission.ValidationResponse(true, "")
+	}
+
+	wh.logger.V(1).Info("persisting default values", "name", config.Name, "namespace", req.AdmissionRequest.Namespace)
+	setDefaultsVersion(defaulted)
+
+	return patchResponse(original, defaulted)
+}
+
+type remoteIstioConfigDefaulter struct {
+	logger logr.Logger
+}
+
+// remoteIstioConfigDefaulter implements admission.Handler.
+var _ admission.Handler = &remoteIstioConfigDefaulter{}
+
+// Handle persists the default values the operator reconciles the RemoteIstio resource with
+func (wh *remoteIstioConfigDefaulter) Handle(ctx context.Context, req types.Request) types.Response {
+	remoteConfig := &istiov1beta1.RemoteIstio{}
+	original, err := decodeObject(req.AdmissionRequest.Object.Raw, remoteConfig)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	defaulted := remoteConfig.DeepCopy()
+	istiov1beta1.SetRemoteIstioDefaults(defaulted)
+
+	if reflect.DeepEqual(remoteConfig.Spec, defaulted.Spec) {
+		return admission.ValidationResponse(true, "")
+	}
+
+	wh.logger.V(1).Info("persisting default values", "name", remoteConfig.Name, "namespace", req.AdmissionRequest.Namespace)
+	setDefaultsVersion(defaulted)
+
+	return patchResponse(original, defaulted)
+}
+
+// setDefaultsVersion records the version of the operator which persisted the defaults
+func setDefaultsVersion(o metav1.Object) {
+	annotations := o.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[istiov1beta1.DefaultsVersionAnnotation] = version.Version
+	o.SetAnnotations(annotations)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	"github.com/banzaicloud/istio-operator/pkg/version"
+)
+
+// patchedValues returns the values of the add and replace operations of the patch by their path
+func patchedValues(resp types.Response) map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, op := range resp.Patches {
+		if op.Operation == "add" || op.Operation == "replace" {
+			values[op.Path] = op.Value
+		}
+	}
+
+	return values
+}
+
+func defaultedIstio(t *testing.T, version istiov1beta1.IstioVersion) string {
+	config := &istiov1beta1.Istio{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: istiov1beta1.SchemeGroupVersion.String(),
+			Kind:       "Istio",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mesh",
+			Namespace: "istio-system",
+		},
+		Spec: istiov1beta1.IstioSpec{
+			Version: version,
+		},
+	}
+	istiov1beta1.SetDefaults(config)
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestIstioConfigDefaulter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	wh := &istioConfigDefaulter{
+		logger: logf.NullLogger{},
+	}
+
+	// the defaults are persisted with the version of the operator
+	resp := wh.Handle(context.TODO(), admissionRequest(admissionv1beta1.Create,
+		`{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.3.5", "pilot": {"image": "registry.example.com/pilot:1.3.5"}}}`, ""))
+	g.Expect(resp.Response.Allowed).To(gomega.BeTrue())
+	g.Expect(*resp.Response.PatchType).To(gomega.Equal(admissionv1beta1.PatchTypeJSONPatch))
+	values := patchedValues(resp)
+	g.Expect(values).To(gomega.HaveKeyWithValue("/metadata/annotations", map[string]interface{}{
+		istiov1beta1.DefaultsVersionAnnotation: version.Version,
+	}))
+	g.Expect(values).To(gomega.HaveKeyWithValue("/spec/citadel/image", "docker.io/istio/citadel:1.3.5"))
+	g.Expect(values).To(gomega.HaveKey("/spec/gateways/ingress"))
+	g.Expect(values).NotTo(gomega.HaveKey("/spec/pilot/image"))
+	g.Expect(values).NotTo(gomega.HaveKey("/metadata/namespace"))
+
+	// nothing is patched once the defaults are persisted
+	resp = wh.Handle(context.TODO(), admissionRequest(admissionv1beta1.Update, defaultedIstio(t, "1.3.5"), defaultedIstio(t, "1.3.5")))
+	g.Expect(resp.Response.Allowed).To(gomega.BeTrue())
+	g.Expect(resp.Patches).To(gomega.BeEmpty())
+
+	// the default images of the previous version are replaced on upgrade
+	upgraded := &istiov1beta1.Istio{}
+	g.Expect(json.Unmarshal([]byte(defaultedIstio(t, "1.2.5")), upgraded)).NotTo(gomega.HaveOccurred())
+	upgraded.Spec.Version = "1.3.5"
+	upgraded.Spec.Galley.Image = "registry.example.com/galley:1.2.5"
+	data, err := json.Marshal(upgraded)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	resp = wh.Handle(context.TODO(), admissionRequest(admissionv1beta1.Update, string(data), defaultedIstio(t, "1.2.5")))
+	g.Expect(resp.Response.Allowed).To(gomega.BeTrue())
+	values = patchedValues(resp)
+	g.Expect(values).To(gomega.HaveKeyWithValue("/spec/pilot/image", "docker.io/istio/pilot:1.3.5"))
+	g.Expect(values).To(gomega.HaveKeyWithValue("/spec/proxy/image", "docker.io/istio/proxyv2:1.3.5"))
+	g.Expect(values).To(gomega.HaveKeyWithValue("/spec/gateways/ingress/sds/image", "docker.io/istio/node-agent-k8s:1.3.5"))
+	g.Expect(values).NotTo(gomega.HaveKey("/spec/galley/image"))
+	g.Expect(values).To(gomega.HaveKey("/metadata/annotations"))
+}
+
+func TestIstioConfigDefaulterV1beta2(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	wh := &istioConfigDefaulter{
+		logger: logf.NullLogger{},
+	}
+
+	// the patch is calculated in the version the resource is written in
+	resp := wh.Handle(context.TODO(), admissionRequest(admissionv1beta1.Create,
+		`{"apiVersion": "istio.banzaicloud.io/v1beta2", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.3.5", "mtls": {"mode": "STRICT"}}}`, ""))
+	g.Expect(resp.Response.Allowed).To(gomega.BeTrue())
+	values := patchedValues(resp)
+	g.Expect(values).To(gomega.HaveKey("/metadata/annotations"))
+	g.Expect(values).To(gomega.HaveKey("/spec/gateways/configs"))
+	g.Expect(values).To(gomega.HaveKeyWithValue("/spec/citadel/image", "docker.io/istio/citadel:1.3.5"))
+	g.Expect(values).NotTo(gomega.HaveKey("/spec/gateways/ingress"))
+	g.Expect(values).NotTo(gomega.HaveKey("/spec/mtls"))
+	g.Expect(values).NotTo(gomega.HaveKey("/apiVersion"))
+}
+
+func TestRemoteIstioConfigDefaulter(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	wh := &remoteIstioConfigDefaulter{
+		logger: logf.NullLogger{},
+	}
+
+	resp := wh.Handle(context.TODO(), admissionRequest(admissionv1beta1.Create,
+		`{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "RemoteIstio", "metadata": {"name": "remote"}, "spec": {"enabledServices": [{"name": "istio-pilot"}]}}`, ""))
+	g.Expect(resp.Response.Allowed).To(gomega.BeTrue())
+	values := patchedValues(resp)
+	g.Expect(values).To(gomega.HaveKeyWithValue("/metadata/annotations", map[string]interface{}{
+		istiov1beta1.DefaultsVersionAnnotation: version.Version,
+	}))
+	g.Expect(values).To(gomega.HaveKey("/spec/includeIPRanges"))
+}
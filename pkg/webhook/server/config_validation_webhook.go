@@ -18,10 +18,15 @@ package server
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/go-logr/logr"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
@@ -39,7 +44,7 @@ func init() {
 const (
 	configValidationWebhookName               = "istio.validation.banzaicloud.io"
 	configValidationWebhookPath               = "/validate-istio-config"
-	configValidationWebhookAlreadyExistsError = "istio config resource already exists"
+	configValidationWebhookAlreadyExistsError = "istio config resource already exists in the namespace"
 )
 
 // NewConfigValidationWebhook initializes an Istio config resource validator webhook configuration
@@ -50,8 +55,7 @@ func NewConfigValidationWebhook(mgr manager.Manager, logger logr.Logger) (*admis
 		FailurePolicy(admissionregistrationv1beta1.Ignore).
 		Validating().
 		NamespaceSelector(&metav1.LabelSelector{}).
-		Operations(admissionregistrationv1beta1.Create).
-		ForType(&istiov1beta1.Istio{}).
+		Rules(servedVersionsRule("istios", admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update)).
 		Handlers(&istioConfigValidator{
 			logger: logger,
 		}).
@@ -71,17 +75,51 @@ var _ admission.Handler = &istioConfigValidator{}
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=istio.banzaicloud.io,resources=istios,verbs=get;list;watch
 func (wh *istioConfigValidator) Handle(ctx context.Context, req types.Request) types.Response {
-	var configs istiov1beta1.IstioList
-
-	err := wh.client.List(context.TODO(), &client.ListOptions{}, &configs)
+	config := &istiov1beta1.Istio{}
+	_, err := decodeObject(req.AdmissionRequest.Object.Raw, config)
 	if err != nil {
-		wh.logger.Error(err, "could not list istio config objects")
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	// the control planes of different meshes run in separate namespaces
+	if req.AdmissionRequest.Operation == admissionv1beta1.Create {
+		var configs istiov1beta1.IstioList
+		err := wh.client.List(context.TODO(), &client.ListOptions{Namespace: req.AdmissionRequest.Namespace}, &configs)
+		if err != nil {
+			wh.logger.Error(err, "could not list istio config objects")
+		}
+		if len(configs.Items) > 0 {
+			return admission.ValidationResponse(false, configValidationWebhookAlreadyExistsError)
+		}
+	}
+
+	errs := config.Validate()
+	if req.AdmissionRequest.Operation == admissionv1beta1.Update && len(req.AdmissionRequest.OldObject.Raw) > 0 {
+		old := &istiov1beta1.Istio{}
+		_, err := decodeObject(req.AdmissionRequest.OldObject.Raw, old)
+		if err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		errs = config.ValidateUpdate(old)
 	}
-	if len(configs.Items) == 0 {
-		return admission.ValidationResponse(true, "")
+	if len(errs) > 0 {
+		return invalidResponse(istiov1beta1.SchemeGroupVersion.WithKind("Istio").GroupKind(), config.Name, errs)
 	}
 
-	return admission.ValidationResponse(false, configValidationWebhookAlreadyExistsError)
+	return admission.ValidationResponse(true, "")
+}
+
+// invalidResponse denies the request with the field errors in the same format the API server
+// reports the violations of the validation schema
+func invalidResponse(kind schema.GroupKind, name string, errs field.ErrorList) types.Response {
+	status := apierrors.NewInvalid(kind, name, errs).ErrStatus
+
+	return types.Response{
+		Response: &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		},
+	}
 }
 
 // istioConfigValidator implements inject.Client.
@@ -0,0 +1,187 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onsi/gomega"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func init() {
+	if err := istiov1beta1.SchemeBuilder.AddToScheme(scheme.Scheme); err != nil {
+		panic(err)
+	}
+}
+
+func admissionRequest(operation admissionv1beta1.Operation, object string, oldObject string) types.Request {
+	req := &admissionv1beta1.AdmissionRequest{
+		Namespace: "istio-system",
+		Operation: operation,
+		Object:    runtime.RawExtension{Raw: []byte(object)},
+	}
+	if oldObject != "" {
+		req.OldObject = runtime.RawExtension{Raw: []byte(oldObject)}
+	}
+
+	return types.Request{AdmissionRequest: req}
+}
+
+func TestServedVersionsRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	rule := servedVersionsRule("istios", admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update)
+	g.Expect(rule.Operations).To(gomega.ConsistOf(admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update))
+	g.Expect(rule.APIGroups).To(gomega.ConsistOf("istio.banzaicloud.io"))
+	g.Expect(rule.APIVersions).To(gomega.ConsistOf("v1beta1", "v1beta2"))
+	g.Expect(rule.Resources).To(gomega.ConsistOf("istios"))
+}
+
+func TestDecodeObject(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	config := &istiov1beta1.Istio{}
+	original, err := decodeObject([]byte(`{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "Istio", "spec": {"version": "1.3.5", "mtls": true}}`), config)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(original).To(gomega.BeIdenticalTo(config))
+	g.Expect(config.Spec.MTLS).To(gomega.BeTrue())
+
+	config = &istiov1beta1.Istio{}
+	original, err = decodeObject([]byte(`{"apiVersion": "istio.banzaicloud.io/v1beta2", "kind": "Istio", "spec": {"version": "1.3.5", "mtls": {"mode": "STRICT"}, "gateways": {"configs": [{"name": "ingress", "replicaCount": 2}]}}}`), config)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(original.GetObjectKind().GroupVersionKind().Version).To(gomega.Equal("v1beta2"))
+	g.Expect(config.Spec.MTLS).To(gomega.BeTrue())
+	g.Expect(config.Spec.Gateways.Configs["ingress"].ReplicaCount).To(gomega.Equal(int32(2)))
+
+	_, err = decodeObject([]byte(`{"apiVersion": "istio.banzaicloud.io/v1alpha1", "kind": "Istio"}`), &istiov1beta1.Istio{})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestIstioConfigValidatorVersions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	existing := &istiov1beta1.Istio{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mesh",
+			Namespace: "istio-system",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		objects   []runtime.Object
+		operation admissionv1beta1.Operation
+		object    string
+		allowed   bool
+	}{
+		{
+			name:      "valid v1beta1",
+			operation: admissionv1beta1.Create,
+			object:    `{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.3.5"}}`,
+			allowed:   true,
+		},
+		{
+			name:      "valid v1beta2",
+			operation: admissionv1beta1.Create,
+			object:    `{"apiVersion": "istio.banzaicloud.io/v1beta2", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.3.5", "mtls": {"mode": "STRICT"}}}`,
+			allowed:   true,
+		},
+		{
+			name:      "invalid v1beta2",
+			operation: admissionv1beta1.Update,
+			object:    `{"apiVersion": "istio.banzaicloud.io/v1beta2", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.1.0"}}`,
+		},
+		{
+			name:      "second v1beta2 in the namespace",
+			objects:   []runtime.Object{existing},
+			operation: admissionv1beta1.Create,
+			object:    `{"apiVersion": "istio.banzaicloud.io/v1beta2", "kind": "Istio", "metadata": {"name": "other"}, "spec": {"version": "1.3.5"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		wh := &istioConfigValidator{
+			client: fake.NewFakeClient(test.objects...),
+			logger: logf.NullLogger{},
+		}
+		resp := wh.Handle(context.TODO(), admissionRequest(test.operation, test.object, ""))
+		g.Expect(resp.Response.Allowed).To(gomega.Equal(test.allowed), test.name)
+	}
+}
+
+func TestIstioConfigValidatorCARotationRemoval(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	old := `{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.3.5", "citadel": {"caSecretName": "cacerts", "caRotation": {"newCASecretName": "cacerts-2020"}}}, "status": {"caRotation": {"newCASecretName": "cacerts-2020", "stage": "%s"}}}`
+	updated := `{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.3.5", "citadel": {"caSecretName": "%s"}}}`
+
+	tests := []struct {
+		name         string
+		stage        istiov1beta1.CARotationStage
+		caSecretName string
+		allowed      bool
+	}{
+		{
+			name:         "aborted while distributing the trust bundle",
+			stage:        istiov1beta1.CARotationDistributingTrustBundle,
+			caSecretName: "cacerts",
+			allowed:      true,
+		},
+		{
+			name:         "removed after signing with the new CA",
+			stage:        istiov1beta1.CARotationSigningWithNewCA,
+			caSecretName: "cacerts",
+		},
+		{
+			name:         "switched to the new CA while signing with it",
+			stage:        istiov1beta1.CARotationSigningWithNewCA,
+			caSecretName: "cacerts-2020",
+		},
+		{
+			name:         "switched to the new CA while waiting for the workload certificates",
+			stage:        istiov1beta1.CARotationWaitingForWorkloadCerts,
+			caSecretName: "cacerts-2020",
+		},
+		{
+			name:         "finished with the new CA",
+			stage:        istiov1beta1.CARotationCompleted,
+			caSecretName: "cacerts-2020",
+			allowed:      true,
+		},
+	}
+
+	for _, test := range tests {
+		wh := &istioConfigValidator{
+			client: fake.NewFakeClient(),
+			logger: logf.NullLogger{},
+		}
+		resp := wh.Handle(context.TODO(), admissionRequest(admissionv1beta1.Update, fmt.Sprintf(updated, test.caSecretName), fmt.Sprintf(old, test.stage)))
+		g.Expect(resp.Response.Allowed).To(gomega.Equal(test.allowed), test.name)
+	}
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"reflect"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	istiov1beta2 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta2"
+)
+
+const (
+	conversionWebhookPath     = "/convert"
+	conversionCACertName      = "ca-cert.pem"
+	conversionConfigurePeriod = time.Minute
+)
+
+// convertibleCRDs are the CRDs served in every version of the API, the versions other than the hub
+// version are only served once the conversion webhook is configured
+var convertibleCRDs = []string{
+	"istios.istio.banzaicloud.io",
+	"remoteistios.istio.banzaicloud.io",
+}
+
+// hub is implemented by the storage version of a type
+type hub interface {
+	runtime.Object
+	Hub()
+}
+
+// convertible is implemented by the other versions of a type, they are converted through the hub version
+type convertible interface {
+	runtime.Object
+	ConvertTo(hub runtime.Object) error
+	ConvertFrom(hub runtime.Object) error
+}
+
+var (
+	_ hub         = &istiov1beta1.Istio{}
+	_ hub         = &istiov1beta1.RemoteIstio{}
+	_ convertible = &istiov1beta2.Istio{}
+	_ convertible = &istiov1beta2.RemoteIstio{}
+)
+
+// conversionWebhook converts Istio and RemoteIstio resources between the API versions for the API server
+type conversionWebhook struct {
+	scheme *runtime.Scheme
+	logger logr.Logger
+}
+
+func (wh *conversionWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var review apiextensionsv1beta1.ConversionReview
+	err := json.NewDecoder(r.Body).Decode(&review)
+	if err != nil || review.Request == nil {
+		wh.logger.Error(err, "could not decode conversion review")
+		http.Error(w, "invalid conversion review", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = wh.convert(review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(review)
+	if err != nil {
+		wh.logger.Error(err, "could not encode conversion review")
+	}
+}
+
+func (wh *conversionWebhook) convert(req *apiextensionsv1beta1.ConversionRequest) *apiextensionsv1beta1.ConversionResponse {
+	resp := &apiextensionsv1beta1.ConversionResponse{
+		UID: req.UID,
+	}
+
+	gv, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		resp.Result = conversionFailure(err)
+		return resp
+	}
+
+	for _, raw := range req.Objects {
+		converted, err := wh.convertObject(raw.Raw, gv)
+		if err != nil {
+			wh.logger.Error(err, "conversion failed", "desiredAPIVersion", req.DesiredAPIVersion)
+			resp.Result = conversionFailure(err)
+			resp.ConvertedObjects = nil
+			return resp
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	resp.Result = metav1.Status{
+		Status: metav1.StatusSuccess,
+	}
+
+	return resp
+}
+
+func (wh *conversionWebhook) convertObject(data []byte, gv schema.GroupVersion) ([]byte, error) {
+	var typeMeta metav1.TypeMeta
+	err := json.Unmarshal(data, &typeMeta)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not decode type of object")
+	}
+	srcGVK := typeMeta.GroupVersionKind()
+	if srcGVK.GroupVersion() == gv {
+		return data, nil
+	}
+
+	src, err := wh.scheme.New(srcGVK)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "unknown kind", "kind", srcGVK.String())
+	}
+	err = json.Unmarshal(data, src)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not decode object", "kind", srcGVK.String())
+	}
+
+	dstGVK := gv.WithKind(srcGVK.Kind)
+	dst, err := wh.scheme.New(dstGVK)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "unknown kind", "kind", dstGVK.String())
+	}
+
+	switch {
+	case isHub(src) && isConvertible(dst):
+		err = dst.(convertible).ConvertFrom(src)
+	case isConvertible(src) && isHub(dst):
+		err = src.(convertible).ConvertTo(dst)
+	default:
+		err = errors.Errorf("cannot convert %s to %s", srcGVK, dstGVK)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(dst)
+}
+
+func isHub(o runtime.Object) bool {
+	_, ok := o.(hub)
+	return ok
+}
+
+func isConvertible(o runtime.Object) bool {
+	_, ok := o.(convertible)
+	return ok
+}
+
+func conversionFailure(err error) metav1.Status {
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: err.Error(),
+	}
+}
+
+// conversionConfigurator points the conversion of the CRDs to the conversion webhook and starts serving
+// their other versions. The CRDs are checked periodically, since re-applying their manifests resets them.
+type conversionConfigurator struct {
+	client      client.Client
+	certDir     string
+	serviceName string
+	namespace   string
+	logger      logr.Logger
+}
+
+var _ manager.Runnable = &conversionConfigurator{}
+
+func (c *conversionConfigurator) Start(stop <-chan struct{}) error {
+	wait.Until(func() {
+		caBundle, err := ioutil.ReadFile(path.Join(c.certDir, conversionCACertName))
+		if err != nil {
+			// the certificates are not provisioned yet
+			c.logger.V(1).Info("waiting for webhook certificates", "error", err.Error())
+			return
+		}
+
+		for _, name := range convertibleCRDs {
+			err := c.configure(name, caBundle)
+			if err != nil {
+				c.logger.Error(err, "could not configure conversion webhook, only the storage version is served", "crd", name)
+			}
+		}
+	}, conversionConfigurePeriod, stop)
+
+	return nil
+}
+
+func (c *conversionConfigurator) configure(name string, caBundle []byte) error {
+	var crd apiextensionsv1beta1.CustomResourceDefinition
+	err := c.client.Get(context.TODO(), client.ObjectKey{Name: name}, &crd)
+	if err != nil {
+		return emperror.Wrap(err, "could not get CRD")
+	}
+
+	desired := crd.DeepCopy()
+	desired.Spec.Conversion = &apiextensionsv1beta1.CustomResourceConversion{
+		Strategy: apiextensionsv1beta1.WebhookConverter,
+		WebhookClientConfig: &apiextensionsv1beta1.WebhookClientConfig{
+			Service: &apiextensionsv1beta1.ServiceReference{
+				Namespace: c.namespace,
+				Name:      c.serviceName,
+				Path:      &[]string{conversionWebhookPath}[0],
+			},
+			CABundle: caBundle,
+		},
+	}
+	for i := range desired.Spec.Versions {
+		desired.Spec.Versions[i].Served = true
+	}
+
+	if reflect.DeepEqual(crd.Spec, desired.Spec) {
+		return nil
+	}
+
+	err = c.client.Update(context.TODO(), desired)
+	if err != nil {
+		return emperror.Wrap(err, "could not update CRD")
+	}
+	c.logger.Info("conversion webhook configured", "crd", name)
+
+	return nil
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	istiov1beta2 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta2"
+)
+
+func serveConversionReview(t *testing.T, body []byte) (*httptest.ResponseRecorder, *apiextensionsv1beta1.ConversionReview) {
+	wh := &conversionWebhook{
+		scheme: apiScheme,
+		logger: logf.NullLogger{},
+	}
+
+	recorder := httptest.NewRecorder()
+	wh.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, conversionWebhookPath, bytes.NewReader(body)))
+
+	var review apiextensionsv1beta1.ConversionReview
+	if recorder.Code == http.StatusOK {
+		if err := json.Unmarshal(recorder.Body.Bytes(), &review); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return recorder, &review
+}
+
+func conversionReview(t *testing.T, desiredAPIVersion string, objects ...string) []byte {
+	review := apiextensionsv1beta1.ConversionReview{
+		Request: &apiextensionsv1beta1.ConversionRequest{
+			UID:               "review",
+			DesiredAPIVersion: desiredAPIVersion,
+		},
+	}
+	for _, o := range objects {
+		review.Request.Objects = append(review.Request.Objects, runtime.RawExtension{Raw: []byte(o)})
+	}
+
+	data, err := json.Marshal(review)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestConversionWebhookMixedVersions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	recorder, review := serveConversionReview(t, conversionReview(t, istiov1beta2.SchemeGroupVersion.String(),
+		`{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.3.5", "mtls": true, "gateways": {"ingress": {"replicaCount": 2}}}, "status": {"Status": "Available"}}`,
+		`{"apiVersion": "istio.banzaicloud.io/v1beta2", "kind": "Istio", "metadata": {"name": "other"}, "spec": {"version": "1.4.0", "mtls": {"mode": "PERMISSIVE"}}}`,
+		`{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "RemoteIstio", "metadata": {"name": "remote"}, "spec": {"enabledServices": [{"name": "istio-pilot"}]}, "status": {"ErrorMessage": "unreachable"}}`,
+	))
+
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(review.Request).To(gomega.BeNil())
+	g.Expect(review.Response.UID).To(gomega.BeEquivalentTo("review"))
+	g.Expect(review.Response.Result.Status).To(gomega.Equal(metav1.StatusSuccess))
+	g.Expect(review.Response.ConvertedObjects).To(gomega.HaveLen(3))
+
+	var istio istiov1beta2.Istio
+	g.Expect(json.Unmarshal(review.Response.ConvertedObjects[0].Raw, &istio)).NotTo(gomega.HaveOccurred())
+	g.Expect(istio.APIVersion).To(gomega.Equal(istiov1beta2.SchemeGroupVersion.String()))
+	g.Expect(istio.Name).To(gomega.Equal("mesh"))
+	g.Expect(istio.Spec.MTLS.Mode).To(gomega.Equal(istiov1beta2.MTLSModeStrict))
+	g.Expect(istio.Spec.Gateways.Configs).To(gomega.HaveLen(1))
+	g.Expect(istio.Spec.Gateways.Configs[0].Name).To(gomega.Equal("ingress"))
+	g.Expect(istio.Spec.Gateways.Configs[0].ReplicaCount).To(gomega.Equal(int32(2)))
+	g.Expect(istio.Status.Status).To(gomega.Equal(istiov1beta2.Available))
+
+	// objects already in the desired version are returned as they are
+	g.Expect(review.Response.ConvertedObjects[1].Raw).To(gomega.MatchJSON(`{"apiVersion": "istio.banzaicloud.io/v1beta2", "kind": "Istio", "metadata": {"name": "other"}, "spec": {"version": "1.4.0", "mtls": {"mode": "PERMISSIVE"}}}`))
+
+	var remoteIstio istiov1beta2.RemoteIstio
+	g.Expect(json.Unmarshal(review.Response.ConvertedObjects[2].Raw, &remoteIstio)).NotTo(gomega.HaveOccurred())
+	g.Expect(remoteIstio.APIVersion).To(gomega.Equal(istiov1beta2.SchemeGroupVersion.String()))
+	g.Expect(remoteIstio.Spec.EnabledServices).To(gomega.Equal([]istiov1beta2.IstioService{{Name: "istio-pilot"}}))
+	g.Expect(remoteIstio.Status.ErrorMessage).To(gomega.Equal("unreachable"))
+}
+
+func TestConversionWebhookToHub(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	recorder, review := serveConversionReview(t, conversionReview(t, istiov1beta1.SchemeGroupVersion.String(),
+		`{"apiVersion": "istio.banzaicloud.io/v1beta2", "kind": "Istio", "metadata": {"name": "mesh"}, "spec": {"version": "1.4.0", "mtls": {"mode": "STRICT"}, "gateways": {"configs": [{"name": "egress", "replicaCount": 1}]}}, "status": {"gatewayAddress": ["10.1.1.1"]}}`,
+	))
+
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(review.Response.Result.Status).To(gomega.Equal(metav1.StatusSuccess))
+	g.Expect(review.Response.ConvertedObjects).To(gomega.HaveLen(1))
+
+	var istio istiov1beta1.Istio
+	g.Expect(json.Unmarshal(review.Response.ConvertedObjects[0].Raw, &istio)).NotTo(gomega.HaveOccurred())
+	g.Expect(istio.APIVersion).To(gomega.Equal(istiov1beta1.SchemeGroupVersion.String()))
+	g.Expect(istio.Spec.MTLS).To(gomega.BeTrue())
+	g.Expect(istio.Spec.Gateways.Configs).To(gomega.HaveKey("egress"))
+	g.Expect(istio.Status.GatewayAddress).To(gomega.Equal([]string{"10.1.1.1"}))
+}
+
+func TestConversionWebhookFailure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	// a single object which cannot be converted fails the whole review
+	recorder, review := serveConversionReview(t, conversionReview(t, istiov1beta2.SchemeGroupVersion.String(),
+		`{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "Istio", "metadata": {"name": "mesh"}}`,
+		`{"apiVersion": "istio.banzaicloud.io/v1beta1", "kind": "Unknown", "metadata": {"name": "unknown"}}`,
+	))
+
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(review.Response.Result.Status).To(gomega.Equal(metav1.StatusFailure))
+	g.Expect(review.Response.Result.Message).NotTo(gomega.BeEmpty())
+	g.Expect(review.Response.ConvertedObjects).To(gomega.BeEmpty())
+
+	recorder, _ = serveConversionReview(t, []byte(`{"kind": "ConversionReview"}`))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusBadRequest))
+}
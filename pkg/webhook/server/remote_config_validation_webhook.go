@@ -0,0 +1,101 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/runtime/inject"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/builder"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+)
+
+func init() {
+	webhooks = append(webhooks, NewRemoteConfigValidationWebhook)
+}
+
+const (
+	remoteConfigValidationWebhookName = "remoteistio.validation.banzaicloud.io"
+	remoteConfigValidationWebhookPath = "/validate-remoteistio-config"
+)
+
+// NewRemoteConfigValidationWebhook initializes a RemoteIstio config resource validator webhook configuration
+func NewRemoteConfigValidationWebhook(mgr manager.Manager, logger logr.Logger) (*admission.Webhook, error) {
+	return builder.NewWebhookBuilder().
+		Name(remoteConfigValidationWebhookName).
+		Path(remoteConfigValidationWebhookPath).
+		FailurePolicy(admissionregistrationv1beta1.Ignore).
+		Validating().
+		NamespaceSelector(&metav1.LabelSelector{}).
+		Rules(servedVersionsRule("remoteistios", admissionregistrationv1beta1.Create, admissionregistrationv1beta1.Update)).
+		Handlers(&remoteIstioConfigValidator{
+			logger: logger,
+		}).
+		WithManager(mgr).
+		Build()
+}
+
+type remoteIstioConfigValidator struct {
+	client client.Client
+	logger logr.Logger
+}
+
+// remoteIstioConfigValidator implements admission.Handler.
+var _ admission.Handler = &remoteIstioConfigValidator{}
+
+func (wh *remoteIstioConfigValidator) Handle(ctx context.Context, req types.Request) types.Response {
+	remoteConfig := &istiov1beta1.RemoteIstio{}
+	_, err := decodeObject(req.AdmissionRequest.Object.Raw, remoteConfig)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	errs := remoteConfig.Validate()
+	if key := remoteConfig.GetIstioKey(); len(errs) == 0 && key.Name != "" {
+		err := wh.client.Get(ctx, key, &istiov1beta1.Istio{})
+		if apierrors.IsNotFound(err) {
+			errs = append(errs, field.NotFound(field.NewPath("spec", "istioRef", "name"), key.Name))
+		} else if err != nil {
+			wh.logger.Error(err, "could not get referenced istio resource", "name", key.Name, "namespace", key.Namespace)
+		}
+	}
+	if len(errs) > 0 {
+		return invalidResponse(istiov1beta1.SchemeGroupVersion.WithKind("RemoteIstio").GroupKind(), remoteConfig.Name, errs)
+	}
+
+	return admission.ValidationResponse(true, "")
+}
+
+// remoteIstioConfigValidator implements inject.Client.
+var _ inject.Client = &remoteIstioConfigValidator{}
+
+// InjectClient injects the client into the remoteIstioConfigValidator
+func (wh *remoteIstioConfigValidator) InjectClient(c client.Client) error {
+	wh.client = c
+	return nil
+}
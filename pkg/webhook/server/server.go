@@ -27,7 +27,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-const podNamespaceEnvVar = "POD_NAMESPACE"
+const (
+	podNamespaceEnvVar = "POD_NAMESPACE"
+	certDir            = "/tmp/cert"
+)
 
 var webhooks []func(manager.Manager, logr.Logger) (*admission.Webhook, error)
 
@@ -45,8 +48,9 @@ func add(mgr manager.Manager) error {
 	}
 
 	svr, err := webhook.NewServer(name, mgr, webhook.ServerOptions{
-		CertDir: "/tmp/cert",
+		CertDir: certDir,
 		BootstrapOptions: &webhook.BootstrapOptions{
+			MutatingWebhookConfigName:   name,
 			ValidatingWebhookConfigName: name,
 			Service: &webhook.Service{
 				Namespace: namespace,
@@ -74,9 +78,24 @@ func add(mgr manager.Manager) error {
 	if err := svr.Register(webhooksToRegister...); err != nil {
 		log.Error(err, "could not register webhooks")
 		os.Exit(2)
-	} else {
-		log.Info("started")
 	}
 
+	svr.Handle(conversionWebhookPath, &conversionWebhook{
+		scheme: mgr.GetScheme(),
+		logger: log.WithName("conversion"),
+	})
+	err = mgr.Add(&conversionConfigurator{
+		client:      mgr.GetClient(),
+		certDir:     certDir,
+		serviceName: name,
+		namespace:   namespace,
+		logger:      log.WithName("conversion"),
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not add conversion configurator")
+	}
+
+	log.Info("started")
+
 	return nil
 }
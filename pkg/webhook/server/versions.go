@@ -0,0 +1,120 @@
+/*
+Copyright 2019 Banzai Cloud.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/goph/emperror"
+	"github.com/pkg/errors"
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	istiov1beta1 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta1"
+	istiov1beta2 "github.com/banzaicloud/istio-operator/pkg/apis/istio/v1beta2"
+)
+
+// apiScheme knows every version of the API the admission requests can be written in
+var apiScheme = runtime.NewScheme()
+
+func init() {
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		istiov1beta1.SchemeBuilder.AddToScheme,
+		istiov1beta2.SchemeBuilder.AddToScheme,
+	} {
+		if err := addToScheme(apiScheme); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// servedVersionsRule returns the admission rule matching the resource in every version of the API, the
+// objects are validated and defaulted in the hub version regardless of the version they are written in
+func servedVersionsRule(resource string, operations ...admissionregistrationv1beta1.OperationType) admissionregistrationv1beta1.RuleWithOperations {
+	return admissionregistrationv1beta1.RuleWithOperations{
+		Operations: operations,
+		Rule: admissionregistrationv1beta1.Rule{
+			APIGroups: []string{istiov1beta1.SchemeGroupVersion.Group},
+			APIVersions: []string{
+				istiov1beta1.SchemeGroupVersion.Version,
+				istiov1beta2.SchemeGroupVersion.Version,
+			},
+			Resources: []string{resource},
+		},
+	}
+}
+
+// decodeObject decodes the object of an admission request into the hub version, the objects written in
+// other versions are converted. It returns the object in the version it was written in as well.
+func decodeObject(raw []byte, into hub) (runtime.Object, error) {
+	var typeMeta metav1.TypeMeta
+	err := json.Unmarshal(raw, &typeMeta)
+	if err != nil {
+		return nil, emperror.Wrap(err, "could not decode type of object")
+	}
+
+	gvk := typeMeta.GroupVersionKind()
+	if gvk.Empty() || gvk.GroupVersion() == istiov1beta1.SchemeGroupVersion {
+		err = json.Unmarshal(raw, into)
+		if err != nil {
+			return nil, emperror.Wrap(err, "could not decode object")
+		}
+		return into, nil
+	}
+
+	src, err := apiScheme.New(gvk)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "unknown kind", "kind", gvk.String())
+	}
+	err = json.Unmarshal(raw, src)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not decode object", "kind", gvk.String())
+	}
+	c, ok := src.(convertible)
+	if !ok {
+		return nil, errors.Errorf("cannot convert %s to the hub version", gvk)
+	}
+	err = c.ConvertTo(into)
+	if err != nil {
+		return nil, emperror.WrapWith(err, "could not convert object", "kind", gvk.String())
+	}
+
+	return src, nil
+}
+
+// patchResponse returns the patch turning the original object into the defaulted one, the patch is
+// calculated in the version the original object is written in
+func patchResponse(original runtime.Object, defaulted hub) types.Response {
+	if _, ok := original.(convertible); !ok {
+		return admission.PatchResponse(original, defaulted)
+	}
+
+	current, err := apiScheme.New(original.GetObjectKind().GroupVersionKind())
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	err = current.(convertible).ConvertFrom(defaulted)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponse(original, current)
+}